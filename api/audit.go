@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// queryTime parses optional RFC 3339 time query parameter
+func queryTime(r *http.Request, name string) (*time.Time, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, badRequest("invalid '%s' parameter, expected RFC 3339 time", name)
+	}
+	return &t, nil
+}
+
+// routeAudit dispatches /audit routes
+func (s *Server) routeAudit(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	if len(parts) != 0 {
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+		return
+	}
+
+	s.listAuditLog(w, r)
+}
+
+// GET /audit?postId=&actor=&operation=&from=&to=&limit=&skip=
+func (s *Server) listAuditLog(w http.ResponseWriter, r *http.Request) {
+	var (
+		query   = r.URL.Query()
+		entries []models.AuditLogDocument
+		params  = types.GetAuditLogOptions{
+			PostId:    query.Get("postId"),
+			Actor:     query.Get("actor"),
+			Operation: query.Get("operation"),
+		}
+		err error
+	)
+
+	if params.From, err = queryTime(r, "from"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if params.To, err = queryTime(r, "to"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if params.Limit, err = queryInt(r, "limit"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if params.Skip, err = queryInt(r, "skip"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if entries, err = s.svc.Audit.GetAuditLog(params); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if entries == nil {
+		entries = []models.AuditLogDocument{}
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
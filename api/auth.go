@@ -0,0 +1,108 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/config"
+)
+
+// TokenFile is the name of file under config root holding API bearer token
+const TokenFile = "api-token"
+
+// number of random bytes in generated token
+const tokenBytes = 32
+
+// TokenPath returns path of API token file of this install
+func TokenPath() string {
+	return filepath.Join(config.RootDir(), TokenFile)
+}
+
+// LoadToken returns API token of this install, a new one is generated
+// and stored readable by the owner only on first use
+func LoadToken() (string, error) {
+	file := TokenPath()
+
+	data, err := os.ReadFile(file)
+	if err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	buf := make([]byte, tokenBytes)
+	if _, err = rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return "", err
+	}
+
+	if err = os.WriteFile(file, []byte(token+"\n"), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// allowedHosts returns Host header values accepted by server listening on addr,
+// other hosts are rejected to stop DNS rebinding
+func allowedHosts(addr string) (map[string]bool, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if port == "" || port == "0" {
+		return nil, fmt.Errorf("listen address '%s' must have a fixed port", addr)
+	}
+
+	return map[string]bool{
+		net.JoinHostPort("127.0.0.1", port): true,
+		net.JoinHostPort("localhost", port): true,
+	}, nil
+}
+
+// authorize checks host, origin and bearer token of request and
+// content type of its body, writing error response when any is rejected
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if !s.hosts[strings.ToLower(r.Host)] {
+		writeJSON(w, http.StatusForbidden, &errorBody{Error: "host not allowed"})
+		return false
+	}
+
+	// browsers send Origin on cross-site requests, the API has no browser clients
+	if _, ok := r.Header["Origin"]; ok {
+		writeJSON(w, http.StatusForbidden, &errorBody{Error: "cross-origin requests are not allowed"})
+		return false
+	}
+
+	auth := r.Header.Get("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	if token == auth || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeJSON(w, http.StatusUnauthorized, &errorBody{Error: "missing or invalid bearer token"})
+		return false
+	}
+
+	if r.ContentLength != 0 {
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+			writeJSON(w, http.StatusUnsupportedMediaType, &errorBody{Error: "request body must be application/json"})
+			return false
+		}
+	}
+
+	return true
+}
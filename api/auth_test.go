@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	server, err := NewServer(nil, DefaultAddr, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		host    string
+		headers map[string]string
+		body    string
+		want    int
+	}{
+		{"authorized", "127.0.0.1:7480", map[string]string{"Authorization": "Bearer secret"}, "", http.StatusNotFound},
+		{"localhost", "localhost:7480", map[string]string{"Authorization": "Bearer secret"}, "", http.StatusNotFound},
+		{"missing token", "127.0.0.1:7480", nil, "", http.StatusUnauthorized},
+		{"wrong token", "127.0.0.1:7480", map[string]string{"Authorization": "Bearer other"}, "", http.StatusUnauthorized},
+		{"basic scheme", "127.0.0.1:7480", map[string]string{"Authorization": "Basic secret"}, "", http.StatusUnauthorized},
+		{"foreign host", "evil.example:7480", map[string]string{"Authorization": "Bearer secret"}, "", http.StatusForbidden},
+		{"other port", "127.0.0.1:80", map[string]string{"Authorization": "Bearer secret"}, "", http.StatusForbidden},
+		{"origin", "127.0.0.1:7480", map[string]string{"Authorization": "Bearer secret", "Origin": "http://127.0.0.1:7480"}, "", http.StatusForbidden},
+		{"json body", "127.0.0.1:7480", map[string]string{"Authorization": "Bearer secret", "Content-Type": "application/json; charset=utf-8"}, "{}", http.StatusNotFound},
+		{"form body", "127.0.0.1:7480", map[string]string{"Authorization": "Bearer secret", "Content-Type": "text/plain"}, "{}", http.StatusUnsupportedMediaType},
+		{"untyped body", "127.0.0.1:7480", map[string]string{"Authorization": "Bearer secret"}, "{}", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://"+tt.host+Prefix+"unknown", strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewServerAddr(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "127.0.0.1:0"} {
+		if _, err := NewServer(nil, addr, "secret"); err == nil {
+			t.Errorf("NewServer(%q) accepted address without fixed port", addr)
+		}
+	}
+
+	if _, err := NewServer(nil, DefaultAddr, ""); err == nil {
+		t.Error("NewServer accepted empty token")
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+)
+
+// routeAuthors dispatches /authors routes
+func (s *Server) routeAuthors(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	switch {
+	case len(parts) == 0:
+		writeJSON(w, http.StatusOK, s.svc.Author.GetAllAuthors())
+	case len(parts) == 1:
+		s.getAuthor(w, parts[0])
+	default:
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+	}
+}
+
+// GET /authors/{id}
+func (s *Server) getAuthor(w http.ResponseWriter, id string) {
+	author, err := s.svc.Author.GetAuthorById(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, author)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/rajatxs/go-fconsole/models"
+)
+
+// routeIndex dispatches /index routes
+func (s *Server) routeIndex(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 1 {
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+		return
+	}
+
+	switch {
+	case parts[0] == "outbox" && r.Method == http.MethodGet:
+		s.listIndexOutbox(w, r)
+	case parts[0] == "outbox":
+		methodNotAllowed(w, http.MethodGet)
+	case parts[0] == "reconcile" && (r.Method == http.MethodGet || r.Method == http.MethodPost):
+		s.reconcileIndex(w, r)
+	case parts[0] == "reconcile":
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	default:
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+	}
+}
+
+// GET /index/reconcile reports differences, POST /index/reconcile repairs them
+func (s *Server) reconcileIndex(w http.ResponseWriter, r *http.Request) {
+	report, err := s.svc.Post.ReconcileIndex(r.Method == http.MethodPost)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// GET /index/outbox?stuck=true
+func (s *Server) listIndexOutbox(w http.ResponseWriter, r *http.Request) {
+	var (
+		entries []models.IndexOutboxDocument
+		stuck   bool
+		err     error
+	)
+
+	if raw := r.URL.Query().Get("stuck"); raw != "" {
+		if stuck, err = strconv.ParseBool(raw); err != nil {
+			writeError(w, badRequest("invalid 'stuck' parameter, expected boolean"))
+			return
+		}
+	}
+
+	if entries, err = s.svc.Post.GetIndexOutbox(stuck); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if entries == nil {
+		entries = []models.IndexOutboxDocument{}
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
@@ -0,0 +1,412 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// scopePayload is the request body of scope change
+type scopePayload struct {
+	Scope string `json:"scope"`
+}
+
+// formatPayload is the request body of format conversion
+type formatPayload struct {
+	Format string `json:"format"`
+}
+
+// routePosts dispatches /posts routes
+func (s *Server) routePosts(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			s.listPosts(w, r)
+		case http.MethodPost:
+			s.createPost(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+
+	case len(parts) == 1 && parts[0] == "count":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		s.countPosts(w, r)
+
+	case len(parts) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.getPost(w, r, parts[0])
+		case http.MethodPut:
+			s.updatePost(w, r, parts[0])
+		case http.MethodPatch:
+			s.patchPost(w, r, parts[0])
+		case http.MethodDelete:
+			s.setPostDeleteFlag(w, parts[0], true)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete)
+		}
+
+	case len(parts) == 2 && parts[1] == "scope":
+		if r.Method != http.MethodPatch {
+			methodNotAllowed(w, http.MethodPatch)
+			return
+		}
+		s.updatePostScope(w, r, parts[0])
+
+	case len(parts) == 2 && parts[1] == "format":
+		if r.Method != http.MethodPatch {
+			methodNotAllowed(w, http.MethodPatch)
+			return
+		}
+		s.convertPostFormat(w, r, parts[0])
+
+	case len(parts) == 2 && parts[1] == "html":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		s.getPostHTML(w, parts[0])
+
+	case len(parts) == 2 && parts[1] == "markdown":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		s.getPostMarkdown(w, parts[0])
+
+	case len(parts) == 2 && parts[1] == "restore":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		s.setPostDeleteFlag(w, parts[0], false)
+
+	default:
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+	}
+}
+
+// queryBool parses optional boolean query parameter
+func queryBool(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, badRequest("invalid '%s' parameter", name)
+	}
+	return v, nil
+}
+
+// queryInt parses optional integer query parameter
+func queryInt(r *http.Request, name string) (int64, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || v < 0 {
+		return 0, badRequest("invalid '%s' parameter", name)
+	}
+	return v, nil
+}
+
+// GET /posts?private=&topic=&author=&sortBy=&limit=&skip=
+func (s *Server) listPosts(w http.ResponseWriter, r *http.Request) {
+	var (
+		params = &types.GetPostsMetadataOptions{Topic: "all", SortBy: "newest"}
+		posts  []models.PostMetadataDocument
+		err    error
+	)
+
+	if params.Private, err = queryBool(r, "private"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if params.Limit, err = queryInt(r, "limit"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if params.Skip, err = queryInt(r, "skip"); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if topic := r.URL.Query().Get("topic"); topic != "" {
+		params.Topic = topic
+	}
+
+	params.AuthorId = r.URL.Query().Get("author")
+
+	if sortBy := r.URL.Query().Get("sortBy"); sortBy != "" {
+		params.SortBy = sortBy
+	}
+
+	if posts, err = s.svc.Post.GetPostsMetadata(params); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if posts == nil {
+		posts = []models.PostMetadataDocument{}
+	}
+
+	writeJSON(w, http.StatusOK, posts)
+}
+
+// GET /posts/count?scope=&deleted=&topic=
+func (s *Server) countPosts(w http.ResponseWriter, r *http.Request) {
+	var (
+		count int64
+		err   error
+	)
+
+	if topic := r.URL.Query().Get("topic"); topic != "" {
+		count, err = s.svc.Post.GetPublicPostCountByTopic(topic)
+	} else {
+		var deleted bool
+
+		if deleted, err = queryBool(r, "deleted"); err != nil {
+			writeError(w, err)
+			return
+		}
+		count, err = s.svc.Post.GetPostCount(r.URL.Query().Get("scope"), deleted)
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int64{"count": count})
+}
+
+// GET /posts/{id}
+func (s *Server) getPost(w http.ResponseWriter, r *http.Request, id string) {
+	post, err := s.svc.Post.GetPostById(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, post)
+}
+
+// GET /posts/{id}/html
+func (s *Server) getPostHTML(w http.ResponseWriter, id string) {
+	body, err := s.svc.Post.RenderPostHTML(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(body))
+}
+
+// GET /posts/{id}/markdown
+func (s *Server) getPostMarkdown(w http.ResponseWriter, id string) {
+	source, err := s.svc.Post.ExportPostMarkdown(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(source))
+}
+
+// POST /posts
+func (s *Server) createPost(w http.ResponseWriter, r *http.Request) {
+	var payload types.CreatePostPayload
+
+	if err := readJSON(w, r, &payload); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res, err := s.svc.Post.CreatePost(&payload)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+// PUT /posts/{id}
+func (s *Server) updatePost(w http.ResponseWriter, r *http.Request, id string) {
+	var (
+		payload types.UpdatePostPayload
+		err     error
+	)
+
+	if err = readJSON(w, r, &payload); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeUpdate(w, id, payload)
+}
+
+// storedPayload returns update payload holding stored fields of post
+func storedPayload(post *models.PostObjectView) types.UpdatePostPayload {
+	payload := types.UpdatePostPayload{
+		Title:        post.Title,
+		Slug:         post.Slug,
+		Desc:         post.Desc,
+		Tags:         post.Tags,
+		Topic:        post.Topic,
+		Body:         post.Body,
+		Public:       post.Public,
+		License:      post.License,
+		RelatedPosts: make([]string, len(post.RelatedPosts)),
+	}
+
+	if post.CoverImage != nil {
+		payload.CoverImageId = post.CoverImage.Id
+		payload.CoverImagePath = post.CoverImage.Path
+		payload.CoverImageRefName = post.CoverImage.RefName
+		payload.CoverImageRefUrl = post.CoverImage.RefUrl
+	}
+
+	for i, related := range post.RelatedPosts {
+		payload.RelatedPosts[i] = related.Id.Hex()
+	}
+	return payload
+}
+
+// mergePayload applies JSON merge patch over given payload, fields missing
+// from patch keep their value and given fields replace it as a whole
+func mergePayload(payload types.UpdatePostPayload, patch map[string]json.RawMessage) (types.UpdatePostPayload, error) {
+	// body object is replaced instead of merged key by key
+	if _, ok := patch["body"]; ok {
+		payload.Body = nil
+	}
+
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		return payload, err
+	}
+
+	if err = json.Unmarshal(raw, &payload); err != nil {
+		return payload, badRequest("invalid JSON body: %s", err.Error())
+	}
+	return payload, nil
+}
+
+// PATCH /posts/{id}
+func (s *Server) patchPost(w http.ResponseWriter, r *http.Request, id string) {
+	var (
+		patch   map[string]json.RawMessage
+		post    *models.PostObjectView
+		payload types.UpdatePostPayload
+		err     error
+	)
+
+	if err = readJSON(w, r, &patch); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if post, err = s.svc.Post.GetPostById(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if payload, err = mergePayload(storedPayload(post), patch); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeUpdate(w, id, payload)
+}
+
+// writeUpdate updates post by given id and writes update result
+func (s *Server) writeUpdate(w http.ResponseWriter, id string, payload types.UpdatePostPayload) {
+	var (
+		res *mongo.UpdateResult
+		err error
+	)
+
+	if res, err = s.svc.Post.UpdatePostById(id, payload); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if res.MatchedCount == 0 {
+		writeError(w, mongo.ErrNoDocuments)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// PATCH /posts/{id}/scope
+func (s *Server) updatePostScope(w http.ResponseWriter, r *http.Request, id string) {
+	var payload scopePayload
+
+	if err := readJSON(w, r, &payload); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if payload.Scope != "public" && payload.Scope != "private" {
+		writeError(w, badRequest("scope must be 'public' or 'private'"))
+		return
+	}
+
+	if err := s.svc.Post.UpdatePostScope(id, payload.Scope); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// PATCH /posts/{id}/format
+func (s *Server) convertPostFormat(w http.ResponseWriter, r *http.Request, id string) {
+	var payload formatPayload
+
+	if err := readJSON(w, r, &payload); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if payload.Format != models.PostFormatBlock && payload.Format != models.PostFormatMarkdown {
+		writeError(w, badRequest("format must be '%s' or '%s'", models.PostFormatBlock, models.PostFormatMarkdown))
+		return
+	}
+
+	if err := s.svc.Post.ConvertPostFormat(id, payload.Format); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// DELETE /posts/{id} and POST /posts/{id}/restore
+func (s *Server) setPostDeleteFlag(w http.ResponseWriter, id string, value bool) {
+	if err := s.svc.Post.SetPostDeleteFlag(id, value); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMergePayload(t *testing.T) {
+	stored := types.UpdatePostPayload{
+		Title:          "Title",
+		Slug:           "title",
+		Tags:           []string{"go", "web"},
+		Topic:          "go",
+		Body:           bson.M{"source": "text", "extra": "old"},
+		CoverImagePath: "covers/a",
+		License:        "CC-BY-4.0",
+		RelatedPosts:   []string{"652e7f0c9a1b2c3d4e5f6a7b"},
+	}
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    func(p *types.UpdatePostPayload)
+		wantErr bool
+	}{
+		{"empty patch keeps everything", `{}`, func(p *types.UpdatePostPayload) {}, false},
+		{"title only", `{"title": "New"}`, func(p *types.UpdatePostPayload) { p.Title = "New" }, false},
+		{"tags replaced", `{"tags": ["rust"]}`, func(p *types.UpdatePostPayload) { p.Tags = []string{"rust"} }, false},
+		{"body replaced as a whole", `{"body": {"source": "new"}}`, func(p *types.UpdatePostPayload) { p.Body = bson.M{"source": "new"} }, false},
+		{"null clears related posts", `{"relatedPosts": null}`, func(p *types.UpdatePostPayload) { p.RelatedPosts = nil }, false},
+		{"wrong type", `{"title": 1}`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatal(err)
+			}
+
+			current := stored
+			current.Tags = append([]string{}, stored.Tags...)
+			current.Body = bson.M{"source": "text", "extra": "old"}
+
+			got, err := mergePayload(current, patch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergePayload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := stored
+			want.Tags = append([]string{}, stored.Tags...)
+			want.Body = bson.M{"source": "text", "extra": "old"}
+			tt.want(&want)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePayload() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Prefix is the base path of versioned API routes
+const Prefix = "/api/v1/"
+
+// DefaultAddr binds API server to loopback interface only
+const DefaultAddr = "127.0.0.1:7480"
+
+// maximum accepted request body size
+const maxBodyBytes = 8 << 20
+
+// Server exposes console services as HTTP/JSON API
+type Server struct {
+	svc   *bootstrap.Services
+	token string
+	hosts map[string]bool
+}
+
+// NewServer creates new instance of Server backed by given services,
+// accepting requests to addr authorized with given bearer token
+func NewServer(svc *bootstrap.Services, addr string, token string) (*Server, error) {
+	hosts, err := allowedHosts(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		return nil, errors.New("API token is required")
+	}
+
+	return &Server{svc: svc, token: token, hosts: hosts}, nil
+}
+
+// errorBody is the JSON shape of every error response
+type errorBody struct {
+	Error  string                  `json:"error"`
+	Fields []validation.FieldError `json:"fields,omitempty"`
+}
+
+// writeJSON writes given value with status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+// writeError maps service error to HTTP status code
+func writeError(w http.ResponseWriter, err error) {
+	var (
+		status = http.StatusInternalServerError
+		verr   *validation.Error
+	)
+
+	switch {
+	case errors.As(err, &verr):
+		writeJSON(w, http.StatusBadRequest, &errorBody{Error: err.Error(), Fields: verr.Fields})
+		return
+	case errors.Is(err, mongo.ErrNoDocuments):
+		status = http.StatusNotFound
+	case errors.Is(err, primitive.ErrInvalidHex), errors.Is(err, errBadRequest):
+		status = http.StatusBadRequest
+	}
+
+	if status == http.StatusInternalServerError {
+		util.Log.Error(fmt.Sprintf("[api] %s", err.Error()))
+	}
+
+	writeJSON(w, status, &errorBody{Error: err.Error()})
+}
+
+// errBadRequest marks client errors
+var errBadRequest = errors.New("bad request")
+
+// badRequest returns client error with given message
+func badRequest(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", errBadRequest, fmt.Sprintf(format, args...))
+}
+
+// readJSON decodes request body into given value
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalid JSON body: %s", err.Error())
+	}
+	return nil
+}
+
+// methodNotAllowed writes 405 response listing allowed methods
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeJSON(w, http.StatusMethodNotAllowed, &errorBody{Error: "method not allowed"})
+}
+
+// ServeHTTP routes request to resource handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, Prefix) {
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+		return
+	}
+
+	if !s.authorize(w, r) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+
+	switch parts[0] {
+	case "posts":
+		s.routePosts(w, r, parts[1:])
+	case "topics":
+		s.routeTopics(w, r, parts[1:])
+	case "authors":
+		s.routeAuthors(w, r, parts[1:])
+	case "audit":
+		s.routeAudit(w, r, parts[1:])
+	case "index":
+		s.routeIndex(w, r, parts[1:])
+	default:
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+	}
+}
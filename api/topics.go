@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// routeTopics dispatches /topics routes
+func (s *Server) routeTopics(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	switch {
+	case len(parts) == 0:
+		s.listTopics(w, r)
+	case len(parts) == 1:
+		s.getTopic(w, parts[0])
+	default:
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "not found"})
+	}
+}
+
+// GET /topics?scope=public|private
+func (s *Server) listTopics(w http.ResponseWriter, r *http.Request) {
+	var topics types.Topics
+
+	switch scope := r.URL.Query().Get("scope"); scope {
+	case "public":
+		topics = s.svc.Topic.GetPublicTopics()
+	case "private":
+		topics = s.svc.Topic.GetPrivateTopics()
+	case "":
+		topics = *s.svc.Topic.GetAllTopics()
+	default:
+		writeError(w, badRequest("scope must be 'public' or 'private'"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, topics)
+}
+
+// GET /topics/{id}
+func (s *Server) getTopic(w http.ResponseWriter, id string) {
+	if s.svc.Topic.GetTopicNameById(id) == "" {
+		writeJSON(w, http.StatusNotFound, &errorBody{Error: "topic not found"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.svc.Topic.GetTopicById(id))
+}
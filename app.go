@@ -7,44 +7,163 @@ import (
 	"os/exec"
 	"os/user"
 	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/rajatxs/go-fconsole/bootstrap"
 	"github.com/rajatxs/go-fconsole/config"
-	"github.com/rajatxs/go-fconsole/db"
 	"github.com/rajatxs/go-fconsole/types"
 	"github.com/rajatxs/go-fconsole/util"
 	wails_runtime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// EventProfileSwitched is emitted with public config variables of new profile
+// after SwitchProfile succeeds
+const EventProfileSwitched = "profile:switched"
+
+// EventIndexProgress is emitted with types.IndexProgress while search index is reconciled
+const EventIndexProgress = "index:progress"
+
+// jobInterval is tick of draft sync, post scheduler and search index worker
+const jobInterval = time.Minute
+
 // App struct
 type App struct {
 	ctx context.Context
+	svc *bootstrap.Services
+	mu  sync.Mutex
+
+	// started is set to 1 under mu once services are connected and loaded,
+	// it is read without lock by draft pushes
+	started int32
+
+	// retry stops background connect attempts of offline console
+	retry chan struct{}
 }
 
-// NewApp creates a new App application struct
-func NewApp() *App {
-	return &App{}
+// NewApp creates a new App application struct with given services
+func NewApp(svc *bootstrap.Services) *App {
+	return &App{svc: svc}
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
+// Backends are connected once secrets vault is unlocked
+// Unreachable backends leave the console offline, drafts are saved locally
+// and pushed once a background connect attempt succeeds
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.svc.Post.OnIndexProgress = func(progress types.IndexProgress) {
+		wails_runtime.EventsEmit(a.ctx, EventIndexProgress, progress)
+	}
+	a.svc.Draft.Online = a.isStarted
+
+	if config.VaultLocked() {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.connect(); err != nil {
+		util.Log.Warning(fmt.Sprintf("[App.startup] %s, starting offline", err.Error()))
+		a.goOffline()
+	}
+}
+
+// isStarted reports whether services are connected and loaded
+func (a *App) isStarted() bool {
+	return atomic.LoadInt32(&a.started) == 1
+}
+
+// connect connects backends, loads services and starts background jobs,
+// caller must hold a.mu
+func (a *App) connect() error {
+	if err := bootstrap.Connect(a.ctx); err != nil {
+		return err
+	}
+
+	if err := a.svc.Start(a.ctx); err != nil {
+		return err
+	}
+
+	atomic.StoreInt32(&a.started, 1)
+	a.startJobs()
+	return nil
+}
+
+// goOffline wires services without storage and retries to connect
+// every jobInterval until it succeeds, caller must hold a.mu
+func (a *App) goOffline() {
+	a.svc.Bind(a.ctx)
+
+	if a.retry != nil {
+		return
+	}
 
-	util.Attempt(db.ConnectMongoDb(ctx))
-	util.Log.Info("[App] Connected to MongoDB")
+	stop := make(chan struct{})
+	a.retry = stop
 
-	util.Attempt(util.InitCloudinary())
-	util.Log.Info("[App] Cloudinary initiated")
+	go func() {
+		ticker := time.NewTicker(jobInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if a.tryConnect(stop) {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// tryConnect makes single background connect attempt of offline console
+// and reports whether retries are over
+func (a *App) tryConnect(stop chan struct{}) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// retries were stopped while waiting for the lock
+	if a.retry != stop {
+		return true
+	}
+
+	if !a.isStarted() {
+		if err := a.connect(); err != nil {
+			util.Log.Debug(fmt.Sprintf("[App.tryConnect] %s", err.Error()))
+			return false
+		}
+		util.Log.Info("[App.tryConnect] Backends connected, console is online")
+	}
 
-	util.InitAlgolia()
-	util.Log.Info("[App] Algolia initiated")
+	a.retry = nil
+	return true
+}
+
+// stopRetry stops background connect attempts, caller must hold a.mu
+func (a *App) stopRetry() {
+	if a.retry != nil {
+		close(a.retry)
+		a.retry = nil
+	}
 }
 
 // terminate is called when the app shutdown.
 func (a *App) terminate(ctx context.Context) {
 	var err error
 
-	if err = db.DisconnectMongoDb(ctx); err != nil {
+	a.mu.Lock()
+	a.stopRetry()
+	a.mu.Unlock()
+
+	a.stopJobs()
+
+	if err = bootstrap.Disconnect(ctx); err != nil {
 		util.Log.Error(fmt.Sprintf("[App] %s", err.Error()))
 	} else {
 		util.Log.Info("[App] MongoDB disconnected")
@@ -74,9 +193,86 @@ func (a *App) GetAppConfigVariables() (env *types.AppPublicConfigVariables) {
 	env.ENV = config.Env()
 	env.ADMIN_ID = config.AdminId()
 	env.CLOUDINARY_ID = config.CloudinaryId()
+	env.MEDIA_STORE = config.MediaStore()
+	env.PROFILE = config.ProfileName()
+	env.VAULT_LOCKED = config.VaultLocked()
 	return env
 }
 
+// UnlockVault decrypts secrets vault with given passphrase and connects backends
+func (a *App) UnlockVault(passphrase string) (env *types.AppPublicConfigVariables, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.isStarted() {
+		return a.GetAppConfigVariables(), nil
+	}
+
+	// connection failed after unlock is retried without passphrase check
+	if config.VaultLocked() {
+		if err = bootstrap.UnlockVault(passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	// backends are connected for the first time once secrets are known,
+	// the console keeps retrying in background when they are unreachable
+	if err = a.connect(); err != nil {
+		a.goOffline()
+		return nil, err
+	}
+
+	a.stopRetry()
+	return a.GetAppConfigVariables(), nil
+}
+
+// GetProfiles returns names of profiles defined in config file
+func (a *App) GetProfiles() ([]string, error) {
+	return config.Profiles()
+}
+
+// SwitchProfile connects the console to backends of given profile without restart,
+// background jobs are paused while services are switched
+func (a *App) SwitchProfile(name string) (env *types.AppPublicConfigVariables, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if config.VaultLocked() {
+		return nil, fmt.Errorf("unlock secrets vault before switching profile")
+	}
+
+	// offline console is brought online by profile it switched to
+	a.stopJobs()
+	defer func() {
+		if a.isStarted() {
+			a.startJobs()
+		}
+	}()
+
+	if err = a.svc.SwitchProfile(a.ctx, name); err != nil {
+		return nil, err
+	}
+
+	a.stopRetry()
+	atomic.StoreInt32(&a.started, 1)
+
+	env = a.GetAppConfigVariables()
+	wails_runtime.EventsEmit(a.ctx, EventProfileSwitched, env)
+	return env, nil
+}
+
+// startJobs starts draft sync, post scheduler and search index worker
+func (a *App) startJobs() {
+	a.svc.Lifecycle.StartDraftSync(jobInterval)
+	a.svc.Lifecycle.StartJobs(jobInterval)
+}
+
+// stopJobs stops draft sync, post scheduler and search index worker
+func (a *App) stopJobs() {
+	a.svc.Lifecycle.StopDraftSync()
+	a.svc.Lifecycle.StopJobs()
+}
+
 // GetVersions returns app version information
 func (a *App) GetVersions() (ver *types.AppVersions) {
 	ver = &types.AppVersions{}
@@ -0,0 +1,291 @@
+// Package backup reads and writes portable content archives
+//
+// An archive is a zip file holding manifest.json, posts.jsonl and topics.jsonl,
+// where every line is a document in MongoDB relaxed Extended JSON, and
+// optionally the referenced images under media/.
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Version is the archive layout version written by Writer
+const Version = 1
+
+const (
+	manifestFile = "manifest.json"
+	postsFile    = "posts.jsonl"
+	topicsFile   = "topics.jsonl"
+	mediaDir     = "media/"
+
+	// maximum size of single JSON line, post bodies may be large
+	maxLineBytes = 64 << 20
+)
+
+// extensions of image content types stored in archive
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Writer writes archive into underlying writer
+type Writer struct {
+	zw       *zip.Writer
+	manifest types.BackupManifest
+}
+
+// NewWriter creates new instance of Writer
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		zw: zip.NewWriter(w),
+		manifest: types.BackupManifest{
+			Version:   Version,
+			CreatedAt: time.Now().UTC(),
+			Images:    []types.BackupImage{},
+		},
+	}
+}
+
+// writeLines writes documents as JSON lines into archive file
+func (w *Writer) writeLines(name string, count int, doc func(i int) interface{}) error {
+	f, err := w.zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < count; i++ {
+		line, err := bson.MarshalExtJSON(doc(i), false, false)
+		if err != nil {
+			return fmt.Errorf("%s line %d: %w", name, i+1, err)
+		}
+
+		if _, err = f.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WritePosts writes post documents
+func (w *Writer) WritePosts(posts []models.PostDocument) error {
+	w.manifest.Posts = len(posts)
+	return w.writeLines(postsFile, len(posts), func(i int) interface{} { return &posts[i] })
+}
+
+// WriteTopics writes topic documents
+func (w *Writer) WriteTopics(topics []models.TopicDocument) error {
+	w.manifest.Topics = len(topics)
+	return w.writeLines(topicsFile, len(topics), func(i int) interface{} { return &topics[i] })
+}
+
+// WriteImage writes original image by given public id
+func (w *Writer) WriteImage(publicId string, data []byte) error {
+	name := mediaDir + strings.TrimPrefix(path.Clean("/"+publicId), "/") + imageExtensions[http.DetectContentType(data)]
+
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+
+	w.manifest.Images = append(w.manifest.Images, types.BackupImage{PublicId: publicId, File: name})
+	return nil
+}
+
+// AddMissing records referenced image that could not be stored
+func (w *Writer) AddMissing(publicId string) {
+	w.manifest.Missing = append(w.manifest.Missing, publicId)
+}
+
+// Close writes manifest and finishes archive
+func (w *Writer) Close() (*types.BackupManifest, error) {
+	f, err := w.zw.Create(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+
+	if err = enc.Encode(&w.manifest); err != nil {
+		return nil, err
+	}
+
+	if err = w.zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &w.manifest, nil
+}
+
+// Archive is an opened backup archive
+type Archive struct {
+	Manifest types.BackupManifest
+	Posts    []models.PostDocument
+	Topics   []models.TopicDocument
+
+	zr     *zip.ReadCloser
+	images map[string]*zip.File
+}
+
+// findFile returns archive file by given name
+func findFile(zr *zip.ReadCloser, name string) *zip.File {
+	for _, f := range zr.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// readLines decodes JSON lines of archive file, each line is passed to decode
+func readLines(f *zip.File, decode func(line []byte) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		if err = decode(line); err != nil {
+			return fmt.Errorf("%s line %d: %w", f.Name, n, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Open reads manifest and documents of archive file
+// Images are read on demand, Close must be called when done
+func Open(file string) (*Archive, error) {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Archive{zr: zr, images: make(map[string]*zip.File)}
+	if err = a.load(); err != nil {
+		zr.Close()
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// load reads manifest and documents
+func (a *Archive) load() error {
+	mf := findFile(a.zr, manifestFile)
+	if mf == nil {
+		return errors.New("not a backup archive, manifest.json is missing")
+	}
+
+	rc, err := mf.Open()
+	if err != nil {
+		return err
+	}
+
+	err = json.NewDecoder(rc).Decode(&a.Manifest)
+	rc.Close()
+
+	if err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	if a.Manifest.Version < 1 || a.Manifest.Version > Version {
+		return fmt.Errorf("unsupported archive version %d, expected at most %d", a.Manifest.Version, Version)
+	}
+
+	if f := findFile(a.zr, postsFile); f != nil {
+		if err = readLines(f, func(line []byte) error {
+			var post models.PostDocument
+			if err := bson.UnmarshalExtJSON(line, false, &post); err != nil {
+				return err
+			}
+			a.Posts = append(a.Posts, post)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if f := findFile(a.zr, topicsFile); f != nil {
+		if err = readLines(f, func(line []byte) error {
+			var topic models.TopicDocument
+			if err := bson.UnmarshalExtJSON(line, false, &topic); err != nil {
+				return err
+			}
+			a.Topics = append(a.Topics, topic)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(a.Posts) != a.Manifest.Posts || len(a.Topics) != a.Manifest.Topics {
+		return fmt.Errorf(
+			"archive is incomplete, manifest lists %d posts and %d topics but %d and %d were found",
+			a.Manifest.Posts, a.Manifest.Topics, len(a.Posts), len(a.Topics))
+	}
+
+	for _, img := range a.Manifest.Images {
+		if f := findFile(a.zr, img.File); f != nil {
+			a.images[img.PublicId] = f
+		}
+	}
+
+	return nil
+}
+
+// HasImage reports whether archive holds image by given public id
+func (a *Archive) HasImage(publicId string) bool {
+	_, ok := a.images[publicId]
+	return ok
+}
+
+// Image returns stored image by given public id
+func (a *Archive) Image(publicId string) ([]byte, error) {
+	f, ok := a.images[publicId]
+	if !ok {
+		return nil, fmt.Errorf("image '%s' is not in archive", publicId)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// Close closes archive file
+func (a *Archive) Close() error {
+	return a.zr.Close()
+}
@@ -0,0 +1,353 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/indexer"
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/repository"
+	"github.com/rajatxs/go-fconsole/services"
+	"github.com/rajatxs/go-fconsole/util"
+)
+
+// Services holds service instances shared by the desktop app and the CLI
+type Services struct {
+	Post   *services.PostService
+	Topic  *services.TopicService
+	Author *services.AuthorService
+	Draft  *services.DraftService
+	Backup *services.BackupService
+	Audit  *services.AuditService
+	Media  media.MediaStore
+
+	// Lifecycle loads services and runs their background jobs
+	Lifecycle *services.Lifecycle
+
+	backends backends
+	indexer  *indexer.SwitchIndexer
+}
+
+// backends describes storage kinds picked by config when services were created,
+// services keep their repositories and media store for their whole life
+type backends struct {
+	postStore  string
+	mediaStore string
+}
+
+// ErrBackendsChanged is returned when active config needs storage kinds
+// other than the ones services were created with
+var ErrBackendsChanged = errors.New("profile uses different storage backends, restart the console to use it")
+
+// activeBackends returns storage kinds picked by active config
+func activeBackends() backends {
+	return backends{
+		postStore:  config.PostStore(),
+		mediaStore: config.MediaStore(),
+	}
+}
+
+// checkBackends reports whether active config uses storage kinds of services
+func (s *Services) checkBackends() error {
+	if active := activeBackends(); active != s.backends {
+		return fmt.Errorf("%w (post store '%s', media store '%s')", ErrBackendsChanged, active.postStore, active.mediaStore)
+	}
+	return nil
+}
+
+// Preconfig parses config with given profile, creates root config directory,
+// initiates logger, unlocks secrets vault and validates config
+// Locked vault is left to the caller when passphrase is nil,
+// it must call UnlockVault before Connect
+func Preconfig(profile string, passphrase func() (string, error)) (err error) {
+	// parse config file and environment
+	if err = config.Parse(profile); err != nil {
+		return err
+	}
+
+	// create the root configuration directory if it does not exist
+	if err = os.MkdirAll(config.RootDir(), 0755); err != nil {
+		return err
+	}
+
+	util.InitLogger()
+
+	if name := config.ProfileName(); name != "" {
+		util.Log.Info(fmt.Sprintf("[bootstrap.Preconfig] Using config profile '%s'", name))
+	}
+
+	if config.VaultLocked() {
+		var phrase string
+
+		if passphrase == nil {
+			util.Log.Info("[bootstrap.Preconfig] Secrets vault is locked")
+			return nil
+		}
+
+		if phrase, err = passphrase(); err != nil {
+			return err
+		}
+		return UnlockVault(phrase)
+	}
+
+	return config.Validate()
+}
+
+// UnlockVault loads secrets of vault with given passphrase and validates config
+func UnlockVault(passphrase string) error {
+	if err := config.UnlockVault(passphrase); err != nil {
+		util.Log.Error(fmt.Sprintf("[bootstrap.UnlockVault] %s", err.Error()))
+		return err
+	}
+
+	util.Log.Info("[bootstrap.UnlockVault] Secrets vault unlocked")
+	return config.Validate()
+}
+
+// NewPostRepository returns post storage configured by FMC_POST_STORE
+func NewPostRepository() repository.PostRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryPostRepository()
+	} else {
+		return repository.NewMongoPostRepository()
+	}
+}
+
+// NewPostRevisionRepository returns revision storage, kept in the same backend as posts
+func NewPostRevisionRepository() repository.PostRevisionRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryPostRevisionRepository()
+	} else {
+		return repository.NewMongoPostRevisionRepository()
+	}
+}
+
+// NewSlugRedirectRepository returns slug redirect storage, kept in the same backend as posts
+func NewSlugRedirectRepository() repository.SlugRedirectRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemorySlugRedirectRepository()
+	} else {
+		return repository.NewMongoSlugRedirectRepository()
+	}
+}
+
+// NewAuthorRepository returns author storage, kept in the same backend as posts
+func NewAuthorRepository() repository.AuthorRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryAuthorRepository()
+	} else {
+		return repository.NewMongoAuthorRepository()
+	}
+}
+
+// NewAuditLogRepository returns audit log storage, kept in the same backend as posts
+func NewAuditLogRepository() repository.AuditLogRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryAuditLogRepository()
+	} else {
+		return repository.NewMongoAuditLogRepository()
+	}
+}
+
+// NewTopicRepository returns topic storage, kept in the same backend as posts
+func NewTopicRepository() repository.TopicRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryTopicRepository()
+	} else {
+		return repository.NewMongoTopicRepository()
+	}
+}
+
+// NewIndexOutboxRepository returns search index outbox, kept in the same backend as posts
+func NewIndexOutboxRepository() repository.IndexOutboxRepository {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryIndexOutboxRepository()
+	} else {
+		return repository.NewMongoIndexOutboxRepository()
+	}
+}
+
+// NewTransactor returns transaction runner of post storage backend
+func NewTransactor() repository.Transactor {
+	if config.PostStore() == "memory" {
+		return repository.NewMemoryTransactor()
+	} else {
+		return repository.NewMongoTransactor()
+	}
+}
+
+// NewSearchIndexer returns Algolia index in production and local index otherwise
+func NewSearchIndexer() (indexer.SearchIndexer, error) {
+	if config.IsProd() {
+		return indexer.NewAlgoliaIndexer(), nil
+	} else {
+		return indexer.NewLocalIndexer(filepath.Join(config.RootDir(), "index"))
+	}
+}
+
+// NewMediaStore returns media storage configured by FMC_MEDIA_STORE
+func NewMediaStore() (media.MediaStore, error) {
+	if config.MediaStore() == "local" {
+		return media.NewLocalStore(filepath.Join(config.RootDir(), "media"))
+	} else {
+		return media.NewCloudinaryStore(), nil
+	}
+}
+
+// NewServices creates service instances with configured backends
+func NewServices() (*Services, error) {
+	idx, err := NewSearchIndexer()
+	if err != nil {
+		return nil, err
+	}
+	search := indexer.NewSwitchIndexer(idx)
+
+	store, err := NewMediaStore()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Services{
+		Post: services.NewPostService(
+			NewPostRepository(),
+			NewPostRevisionRepository(),
+			NewSlugRedirectRepository(),
+			search,
+			store,
+			NewIndexOutboxRepository(),
+			NewTransactor(),
+		),
+		Topic:  services.NewTopicService(NewTopicRepository(), store),
+		Author: services.NewAuthorService(NewAuthorRepository(), store),
+		Draft:  services.NewDraftService(filepath.Join(config.RootDir(), "drafts")),
+		Backup: services.NewBackupService(filepath.Join(config.RootDir(), "backups")),
+		Audit:  services.NewAuditService(NewAuditLogRepository()),
+		Media:  store,
+
+		backends: activeBackends(),
+		indexer:  search,
+	}
+
+	s.Lifecycle = &services.Lifecycle{Post: s.Post, Topic: s.Topic, Author: s.Author, Audit: s.Audit, Draft: s.Draft}
+	return s, nil
+}
+
+// Start sets runtime context, wires up service references, loads topics
+// and authors and prepares audit log and post indexes
+// Connect must be called before Start
+func (s *Services) Start(ctx context.Context) error {
+	if err := s.checkBackends(); err != nil {
+		return err
+	}
+
+	s.Bind(ctx)
+	return s.Lifecycle.Load()
+}
+
+// Bind sets runtime context and wires up service references without touching
+// storage, so local drafts work while backends are unreachable
+func (s *Services) Bind(ctx context.Context) {
+	s.Topic.Ctx = ctx
+	s.Topic.PostServiceRef = s.Post
+	s.Topic.AuditServiceRef = s.Audit
+	s.Post.Ctx = ctx
+	s.Post.TopicServiceRef = s.Topic
+	s.Post.AuthorServiceRef = s.Author
+	s.Post.AuditServiceRef = s.Audit
+	s.Author.Ctx = ctx
+	s.Author.PostServiceRef = s.Post
+	s.Author.AuditServiceRef = s.Audit
+	s.Draft.Ctx = ctx
+	s.Draft.PostServiceRef = s.Post
+	s.Backup.Ctx = ctx
+	s.Backup.PostServiceRef = s.Post
+	s.Backup.TopicServiceRef = s.Topic
+	s.Audit.Ctx = ctx
+}
+
+// SwitchProfile activates given config profile and reconnects external backends
+// of running services to it
+// Previous profile is restored when the new one cannot be connected
+func (s *Services) SwitchProfile(ctx context.Context, name string) (err error) {
+	previous := config.ProfileName()
+
+	if err = config.Use(name); err != nil {
+		return err
+	}
+
+	if err = s.Reconnect(ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[bootstrap.SwitchProfile] %s", err.Error()))
+
+		// backends stay connected to previous profile when it was rejected up front
+		if restoreErr := config.Use(previous); restoreErr == nil && !errors.Is(err, ErrBackendsChanged) {
+			if restoreErr = s.Reconnect(ctx); restoreErr != nil {
+				util.Log.Error(fmt.Sprintf("[bootstrap.SwitchProfile] %s", restoreErr.Error()))
+			}
+		}
+		return err
+	}
+
+	util.Log.Info(fmt.Sprintf("[bootstrap.SwitchProfile] Switched config profile (from='%s', to='%s')", previous, config.ProfileName()))
+	return nil
+}
+
+// Reconnect connects external backends with active config and reloads
+// service caches, background jobs must be stopped by the caller
+// Services keep their repositories, which reach MongoDB, Cloudinary and
+// Algolia through clients swapped by Connect, so calls running meanwhile use
+// either the previous or the new client and in-memory storage is kept
+// Search index follows environment of active config
+// Active config must use storage kinds services were created with
+func (s *Services) Reconnect(ctx context.Context) error {
+	if err := s.checkBackends(); err != nil {
+		return err
+	}
+
+	if err := Connect(ctx); err != nil {
+		return err
+	}
+
+	idx, err := NewSearchIndexer()
+	if err != nil {
+		return err
+	}
+	s.indexer.Use(idx)
+
+	return s.Lifecycle.Load()
+}
+
+// Connect opens connections to configured external backends, replacing
+// open ones only after the new connection succeeds
+func Connect(ctx context.Context) (err error) {
+	if config.PostStore() == "memory" {
+		util.Log.Info("[bootstrap.Connect] Using in-memory post storage")
+	} else {
+		if err = db.ConnectMongoDb(ctx); err != nil {
+			return err
+		}
+		util.Log.Info("[bootstrap.Connect] Connected to MongoDB")
+	}
+
+	if config.MediaStore() == "local" {
+		util.Log.Info("[bootstrap.Connect] Using local media storage")
+	} else {
+		if err = util.InitCloudinary(); err != nil {
+			return err
+		}
+		util.Log.Info("[bootstrap.Connect] Cloudinary initiated")
+	}
+
+	util.InitAlgolia()
+	util.Log.Info("[bootstrap.Connect] Algolia initiated")
+	return nil
+}
+
+// Disconnect closes connections opened by Connect
+func Disconnect(ctx context.Context) error {
+	return db.DisconnectMongoDb(ctx)
+}
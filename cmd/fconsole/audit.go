@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func auditList(svc *bootstrap.Services, args []string) error {
+	var (
+		params types.GetAuditLogOptions
+		err    error
+	)
+
+	fs, asJSON := newFlagSet("audit list")
+	fs.StringVar(&params.PostId, "post", "", "post id")
+	fs.StringVar(&params.Actor, "actor", "", "actor name")
+	fs.StringVar(&params.Operation, "op", "", "operation, e.g. post.update")
+	from := fs.String("from", "", "earliest time (RFC 3339)")
+	to := fs.String("to", "", "latest time (RFC 3339)")
+	fs.Int64Var(&params.Limit, "limit", 50, "maximum number of entries")
+	fs.Int64Var(&params.Skip, "skip", 0, "number of entries to skip")
+
+	if _, err = parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if params.From, err = parseTime(*from); err != nil {
+		return err
+	}
+
+	if params.To, err = parseTime(*to); err != nil {
+		return err
+	}
+
+	entries, err := svc.Audit.GetAuditLog(params)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(entries)
+	}
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{
+			e.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			e.Actor,
+			e.Operation,
+			e.TargetId,
+			e.Outcome,
+			truncate(e.Error, 48),
+		}
+	}
+
+	return printTable([]string{"TIME", "ACTOR", "OPERATION", "TARGET", "OUTCOME", "ERROR"}, rows)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func authorsList(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("authors list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	authors := svc.Author.GetAllAuthors()
+	if *asJSON {
+		return printJSON(authors)
+	}
+
+	rows := make([][]string, len(authors))
+	for i, author := range authors {
+		rows[i] = []string{
+			author.Id.Hex(),
+			author.Name,
+			truncate(author.Bio, 48),
+			fmt.Sprint(len(author.Links)),
+		}
+	}
+
+	return printTable([]string{"ID", "NAME", "BIO", "LINKS"}, rows)
+}
+
+func authorsCreate(svc *bootstrap.Services, args []string) error {
+	var payload types.AuthorPayload
+
+	fs, asJSON := newFlagSet("authors create")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<payload.json>"); err != nil {
+		return err
+	}
+
+	if err = readPayload(args[0], &payload); err != nil {
+		return err
+	}
+
+	id, err := svc.Author.CreateAuthor(payload)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(map[string]string{"id": id})
+	}
+
+	fmt.Println(id)
+	return nil
+}
+
+func authorsUpdate(svc *bootstrap.Services, args []string) error {
+	var payload types.AuthorPayload
+
+	fs, _ := newFlagSet("authors update")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<id> <payload.json>"); err != nil {
+		return err
+	}
+
+	if err = readPayload(args[1], &payload); err != nil {
+		return err
+	}
+
+	return svc.Author.UpdateAuthor(args[0], payload)
+}
+
+func authorsDelete(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("authors delete")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	return svc.Author.DeleteAuthor(args[0])
+}
+
+func authorsAvatar(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("authors avatar")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<id> <file>"); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	res, err := svc.Author.UploadAuthorAvatar(args[0], data)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(res)
+	}
+
+	fmt.Println(res.PublicId)
+	return nil
+}
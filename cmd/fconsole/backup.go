@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func backupCreate(svc *bootstrap.Services, args []string) error {
+	var file string
+
+	fs, asJSON := newFlagSet("backup create")
+	images := fs.Bool("images", false, "include cover and embedded images")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return fmt.Errorf("expected arguments: [file]")
+	} else if len(args) == 1 {
+		file = args[0]
+	}
+
+	res, err := svc.Backup.CreateBackup(file, *images)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(res)
+	}
+
+	fmt.Printf(
+		"%s\nposts: %d, topics: %d, images: %d, missing images: %d\n",
+		res.File,
+		res.Manifest.Posts,
+		res.Manifest.Topics,
+		len(res.Manifest.Images),
+		len(res.Manifest.Missing))
+	return nil
+}
+
+func backupInspect(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("backup inspect")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<file>"); err != nil {
+		return err
+	}
+
+	manifest, err := svc.Backup.GetBackupManifest(args[0])
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(manifest)
+	}
+
+	return printTable([]string{"VERSION", "CREATED", "POSTS", "TOPICS", "IMAGES", "MISSING"}, [][]string{{
+		fmt.Sprint(manifest.Version),
+		manifest.CreatedAt.Local().Format("2006-01-02 15:04"),
+		fmt.Sprint(manifest.Posts),
+		fmt.Sprint(manifest.Topics),
+		fmt.Sprint(len(manifest.Images)),
+		fmt.Sprint(len(manifest.Missing)),
+	}})
+}
+
+func backupRestore(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("backup restore")
+	mode := fs.String("mode", "empty", "empty, skip-existing or overwrite")
+	dryRun := fs.Bool("dry-run", false, "report changes without writing")
+	images := fs.Bool("images", false, "restore archived images")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<file>"); err != nil {
+		return err
+	}
+
+	report, rerr := svc.Backup.RestoreBackup(args[0], types.RestoreOptions{
+		Mode:   *mode,
+		DryRun: *dryRun,
+		Images: *images,
+	})
+	if report == nil {
+		return rerr
+	}
+
+	if *asJSON {
+		if err = printJSON(report); err != nil {
+			return err
+		}
+		return rerr
+	}
+
+	count := func(c types.RestoreCount) []string {
+		return []string{fmt.Sprint(c.Created), fmt.Sprint(c.Overwritten), fmt.Sprint(c.Skipped)}
+	}
+
+	if err = printTable([]string{"KIND", "CREATED", "OVERWRITTEN", "SKIPPED"}, [][]string{
+		append([]string{"posts"}, count(report.Posts)...),
+		append([]string{"topics"}, count(report.Topics)...),
+		append([]string{"images"}, count(report.Images)...),
+	}); err != nil {
+		return err
+	}
+
+	for _, msg := range report.Errors {
+		fmt.Println("error:", msg)
+	}
+
+	if report.DryRun {
+		fmt.Println("dry run, nothing was written")
+	}
+
+	return rerr
+}
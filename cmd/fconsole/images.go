@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func imagesUpload(svc *bootstrap.Services, args []string) error {
+	var res *types.UploadedImageFile
+
+	fs, asJSON := newFlagSet("images upload")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<cover|embed> <file>"); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "cover":
+		res, err = svc.Post.UploadPostCoverImage(data)
+	case "embed":
+		res, err = svc.Post.UploadPostEmbedImage(data)
+	default:
+		return fmt.Errorf("unknown image kind '%s'", args[0])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(res)
+	}
+
+	return printTable(
+		[]string{"PUBLIC ID", "ASSET ID", "FORMAT"},
+		[][]string{{res.PublicId, res.AssetId, res.Format}})
+}
+
+func imagesDelete(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("images delete")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<publicId>"); err != nil {
+		return err
+	}
+
+	return svc.Post.DeletePostImage(args[0])
+}
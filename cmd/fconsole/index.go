@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+)
+
+func indexOutbox(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("index outbox")
+	stuck := fs.Bool("stuck", false, "only entries that keep failing")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	entries, err := svc.Post.GetIndexOutbox(*stuck)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(entries)
+	}
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{
+			e.PostId.Hex(),
+			e.Action,
+			strconv.Itoa(e.Attempts),
+			e.NextAttemptAt.Local().Format("2006-01-02 15:04:05"),
+			truncate(e.LastError, 48),
+		}
+	}
+
+	return printTable([]string{"POST", "ACTION", "ATTEMPTS", "NEXT ATTEMPT", "ERROR"}, rows)
+}
+
+func indexRetry(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("index retry")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	applied, err := svc.Post.RetryIndexOutbox()
+	if err != nil {
+		return err
+	}
+
+	pending, err := svc.Post.GetIndexOutbox(false)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(map[string]int{"applied": applied, "pending": len(pending)})
+	}
+
+	fmt.Printf("applied %d, pending %d\n", applied, len(pending))
+	return nil
+}
+
+func indexReconcile(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("index reconcile")
+	fix := fs.Bool("fix", false, "save missing and stale records and delete orphaned ones")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	// partial report of failed fix is still printed
+	report, rerr := svc.Post.ReconcileIndex(*fix)
+	if report == nil {
+		return rerr
+	}
+
+	if *asJSON {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+		return rerr
+	}
+
+	rows := [][]string{}
+	for status, ids := range map[string][]string{"missing": report.Missing, "orphaned": report.Orphaned, "stale": report.Stale} {
+		for _, id := range ids {
+			rows = append(rows, []string{id, status})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+
+	if err := printTable([]string{"POST", "STATUS"}, rows); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nposts: %d, records: %d, missing: %d, orphaned: %d, stale: %d\n",
+		report.Posts, report.Records, len(report.Missing), len(report.Orphaned), len(report.Stale))
+
+	if report.Fix {
+		fmt.Printf("saved: %d, deleted: %d\n", report.Saved, report.Deleted)
+	}
+	return rerr
+}
@@ -0,0 +1,193 @@
+// Command fconsole drives console services from the command line
+// without opening the desktop window.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+)
+
+const usage = `Usage: fconsole [--profile name] <command> [arguments]
+
+Commands:
+  posts list [--private] [--topic id] [--author id] [--sort by] [--limit n] [--skip n]
+  posts get <id>
+  posts html <id>
+  posts create <payload.json>
+  posts export <id> [--out file]
+  posts import <post.md>
+  posts migrate <export> [--source wordpress|ghost] [--topic id] [--map category=topic]
+                [--site url] [--license id] [--public] [--pages] [--no-images] [--dry-run]
+  posts update <id> <payload.json>
+  posts scope <id> <public|private>
+  posts convert <id> <block|markdown>
+  posts delete <id> [--restore]
+  posts schedule <id> [--publish time] [--unpublish time] [--clear]
+  posts schedules
+  posts dedupe-slugs [--dry-run]
+  topics list [--public | --private]
+  authors list
+  authors create <payload.json>
+  authors update <id> <payload.json>
+  authors delete <id>
+  authors avatar <id> <file>
+  redirects list [--post id]
+  redirects add <from> <postId>
+  redirects delete <id>
+  images upload <cover|embed> <file>
+  images delete <publicId>
+  audit list [--post id] [--actor name] [--op operation] [--from time] [--to time]
+             [--limit n] [--skip n]
+  index outbox [--stuck]
+  index retry
+  index reconcile [--fix]
+  backup create [file] [--images]
+  backup inspect <file>
+  backup restore <file> [--mode empty|skip-existing|overwrite] [--dry-run] [--images]
+  serve [--addr host:port]
+  vault set <name>
+  vault list
+  vault remove <name>
+  vault rotate
+
+Every command accepts --json to print JSON instead of a table.
+--profile selects a profile of the config file, overriding FMC_PROFILE.
+Vault passphrase is asked on the terminal unless FMC_VAULT_PASSPHRASE is set,
+and secret values are read from stdin.
+`
+
+// command handles single subcommand with its remaining arguments
+type command func(svc *bootstrap.Services, args []string) error
+
+var commands = map[string]map[string]command{
+	"posts": {
+		"list":         postsList,
+		"get":          postsGet,
+		"html":         postsHTML,
+		"create":       postsCreate,
+		"export":       postsExport,
+		"import":       postsImport,
+		"migrate":      postsMigrate,
+		"update":       postsUpdate,
+		"scope":        postsScope,
+		"convert":      postsConvert,
+		"delete":       postsDelete,
+		"schedule":     postsSchedule,
+		"schedules":    postsSchedules,
+		"dedupe-slugs": postsDedupeSlugs,
+	},
+	"topics": {
+		"list": topicsList,
+	},
+	"authors": {
+		"list":   authorsList,
+		"create": authorsCreate,
+		"update": authorsUpdate,
+		"delete": authorsDelete,
+		"avatar": authorsAvatar,
+	},
+	"redirects": {
+		"list":   redirectsList,
+		"add":    redirectsAdd,
+		"delete": redirectsDelete,
+	},
+	"images": {
+		"upload": imagesUpload,
+		"delete": imagesDelete,
+	},
+	"audit": {
+		"list": auditList,
+	},
+	"index": {
+		"outbox":    indexOutbox,
+		"retry":     indexRetry,
+		"reconcile": indexReconcile,
+	},
+	"backup": {
+		"create":  backupCreate,
+		"inspect": backupInspect,
+		"restore": backupRestore,
+	},
+	"serve": {
+		"": serve,
+	},
+}
+
+// lookup returns command by given arguments and its remaining arguments
+func lookup(args []string) (command, []string, error) {
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("missing command\n\n%s", usage)
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown command '%s'\n\n%s", args[0], usage)
+	}
+
+	if cmd, ok := group[""]; ok {
+		return cmd, args[1:], nil
+	}
+
+	if len(args) < 2 {
+		return nil, nil, fmt.Errorf("missing %s subcommand\n\n%s", args[0], usage)
+	}
+
+	if cmd, ok := group[args[1]]; ok {
+		return cmd, args[2:], nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown command '%s %s'\n\n%s", args[0], args[1], usage)
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("fconsole", flag.ContinueOnError)
+	profile := fs.String("profile", "", "config profile name")
+	fs.SetOutput(io.Discard)
+
+	// global flags stop at the command name
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 0 && fs.Arg(0) == "vault" {
+		return runVault(fs.Args()[1:])
+	}
+
+	cmd, rest, err := lookup(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	if err = bootstrap.Preconfig(*profile, readPassphrase); err != nil {
+		return err
+	}
+
+	svc, err := bootstrap.NewServices()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err = bootstrap.Connect(ctx); err != nil {
+		return err
+	}
+	defer bootstrap.Disconnect(ctx)
+
+	if err = svc.Start(ctx); err != nil {
+		return err
+	}
+
+	return cmd(svc, rest)
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "fconsole:", err)
+		os.Exit(1)
+	}
+}
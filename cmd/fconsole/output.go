@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// newFlagSet creates flag set of a subcommand with common --json flag
+func newFlagSet(name string) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs, fs.Bool("json", false, "print JSON output")
+}
+
+// parseArgs parses flags which may appear before or after positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err = fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// expectArgs returns error unless exactly n positional arguments are given
+func expectArgs(args []string, n int, names string) error {
+	if len(args) != n {
+		return fmt.Errorf("expected arguments: %s", names)
+	}
+	return nil
+}
+
+// printJSON writes given value as indented JSON to stdout
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes aligned rows with given header to stdout
+func printTable(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// truncate shortens long text for table cells
+func truncate(text string, n int) string {
+	if r := []rune(text); len(r) > n {
+		return string(r[:n-3]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,502 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// readPayload decodes JSON file into given payload
+func readPayload(file string, payload interface{}) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, payload); err != nil {
+		return fmt.Errorf("invalid payload '%s': %w", file, err)
+	}
+	return nil
+}
+
+func postsList(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("posts list")
+	private := fs.Bool("private", false, "list private posts")
+	topic := fs.String("topic", "all", "topic id")
+	author := fs.String("author", "", "author id")
+	sortBy := fs.String("sort", "newest", "title, topic, newest, oldest or updated")
+	limit := fs.Int64("limit", 0, "maximum number of posts")
+	skip := fs.Int64("skip", 0, "number of posts to skip")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	posts, err := svc.Post.GetPostsMetadata(&types.GetPostsMetadataOptions{
+		Private:  *private,
+		Topic:    *topic,
+		AuthorId: *author,
+		SortBy:   *sortBy,
+		Limit:    *limit,
+		Skip:     *skip,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(posts)
+	}
+
+	rows := make([][]string, len(posts))
+	for i, post := range posts {
+		rows[i] = []string{
+			post.Id.Hex(),
+			truncate(post.Title, 48),
+			post.Slug,
+			post.Topic,
+			post.UpdatedAt.Format("2006-01-02 15:04"),
+		}
+	}
+
+	return printTable([]string{"ID", "TITLE", "SLUG", "TOPIC", "UPDATED"}, rows)
+}
+
+func postsGet(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("posts get")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	post, err := svc.Post.GetPostById(args[0])
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(post)
+	}
+
+	related := make([]string, len(post.RelatedPosts))
+	for i, rp := range post.RelatedPosts {
+		related[i] = rp.Id.Hex()
+	}
+
+	return printTable([]string{"FIELD", "VALUE"}, [][]string{
+		{"id", post.Id.Hex()},
+		{"title", post.Title},
+		{"slug", post.Slug},
+		{"desc", truncate(post.Desc, 80)},
+		{"topic", post.Topic},
+		{"tags", strings.Join(post.Tags, ", ")},
+		{"format", post.Format},
+		{"public", fmt.Sprint(post.Public)},
+		{"license", post.License},
+		{"related", strings.Join(related, ", ")},
+		{"createdAt", post.CreatedAt.Format("2006-01-02 15:04")},
+		{"updatedAt", post.UpdatedAt.Format("2006-01-02 15:04")},
+	})
+}
+
+func postsHTML(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("posts html")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	body, err := svc.Post.RenderPostHTML(args[0])
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(map[string]string{"html": body})
+	}
+
+	fmt.Print(body)
+	return nil
+}
+
+func postsExport(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("posts export")
+	out := fs.String("out", "", "write Markdown to file instead of stdout")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	source, err := svc.Post.ExportPostMarkdown(args[0])
+	if err != nil {
+		return err
+	}
+
+	if *out != "" {
+		return os.WriteFile(*out, []byte(source), 0644)
+	}
+
+	fmt.Print(source)
+	return nil
+}
+
+func postsImport(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("posts import")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<post.md>"); err != nil {
+		return err
+	}
+
+	source, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	res, err := svc.Post.CreatePostFromMarkdown(string(source))
+	if err != nil {
+		return err
+	}
+
+	id := res.InsertedID.(primitive.ObjectID).Hex()
+	if *asJSON {
+		return printJSON(map[string]string{"id": id})
+	}
+
+	fmt.Println(id)
+	return nil
+}
+
+func postsCreate(svc *bootstrap.Services, args []string) error {
+	var payload types.CreatePostPayload
+
+	fs, asJSON := newFlagSet("posts create")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<payload.json>"); err != nil {
+		return err
+	}
+
+	if err = readPayload(args[0], &payload); err != nil {
+		return err
+	}
+
+	if payload.AuthorId == "" {
+		payload.AuthorId = config.AdminId()
+	}
+
+	if payload.Format == "" {
+		payload.Format = "block"
+	}
+
+	res, err := svc.Post.CreatePost(&payload)
+	if err != nil {
+		return err
+	}
+
+	id := res.InsertedID.(primitive.ObjectID).Hex()
+	if *asJSON {
+		return printJSON(map[string]string{"id": id})
+	}
+
+	fmt.Println(id)
+	return nil
+}
+
+func postsUpdate(svc *bootstrap.Services, args []string) error {
+	var payload types.UpdatePostPayload
+
+	fs, asJSON := newFlagSet("posts update")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<id> <payload.json>"); err != nil {
+		return err
+	}
+
+	if err = readPayload(args[1], &payload); err != nil {
+		return err
+	}
+
+	res, err := svc.Post.UpdatePostById(args[0], payload)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(res)
+	}
+
+	fmt.Printf("matched %d, modified %d\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func postsScope(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("posts scope")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<id> <public|private>"); err != nil {
+		return err
+	}
+
+	if args[1] != "public" && args[1] != "private" {
+		return fmt.Errorf("unknown scope '%s'", args[1])
+	}
+
+	return svc.Post.UpdatePostScope(args[0], args[1])
+}
+
+func postsConvert(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("posts convert")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<id> <block|markdown>"); err != nil {
+		return err
+	}
+
+	return svc.Post.ConvertPostFormat(args[0], args[1])
+}
+
+func postsDelete(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("posts delete")
+	restore := fs.Bool("restore", false, "clear delete flag instead")
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	return svc.Post.SetPostDeleteFlag(args[0], !*restore)
+}
+
+// parseTime parses optional RFC 3339 time flag
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time '%s', expected RFC 3339", value)
+	}
+	return &t, nil
+}
+
+func postsSchedule(svc *bootstrap.Services, args []string) error {
+	var (
+		schedule types.PostSchedulePayload
+		err      error
+	)
+
+	fs, _ := newFlagSet("posts schedule")
+	publish := fs.String("publish", "", "publish time (RFC 3339)")
+	unpublish := fs.String("unpublish", "", "unpublish time (RFC 3339)")
+	remove := fs.Bool("clear", false, "remove schedule instead")
+
+	if args, err = parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	if *remove {
+		return svc.Post.ClearPostSchedule(args[0])
+	}
+
+	if schedule.PublishAt, err = parseTime(*publish); err != nil {
+		return err
+	}
+
+	if schedule.UnpublishAt, err = parseTime(*unpublish); err != nil {
+		return err
+	}
+
+	return svc.Post.SetPostSchedule(args[0], schedule)
+}
+
+func postsSchedules(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("posts schedules")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	schedules, err := svc.Post.GetScheduledPosts()
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(schedules)
+	}
+
+	format := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.Local().Format("2006-01-02 15:04")
+	}
+
+	rows := make([][]string, len(schedules))
+	for i, s := range schedules {
+		rows[i] = []string{
+			s.PostId,
+			truncate(s.Title, 48),
+			fmt.Sprint(s.Public),
+			format(s.PublishAt),
+			format(s.UnpublishAt),
+		}
+	}
+
+	return printTable([]string{"ID", "TITLE", "PUBLIC", "PUBLISH", "UNPUBLISH"}, rows)
+}
+
+func postsDedupeSlugs(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("posts dedupe-slugs")
+	dryRun := fs.Bool("dry-run", false, "only report renames")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	// renames done before a failure are still printed
+	report, rerr := svc.Post.MigrateDuplicateSlugs(*dryRun)
+	if report == nil {
+		return rerr
+	}
+
+	if *asJSON {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+		return rerr
+	}
+
+	rows := make([][]string, len(report.Renamed))
+	for i, r := range report.Renamed {
+		rows[i] = []string{r.PostId, truncate(r.Title, 48), r.From, r.To}
+	}
+
+	if err := printTable([]string{"ID", "TITLE", "FROM", "TO"}, rows); err != nil {
+		return err
+	}
+
+	if report.DryRun {
+		fmt.Printf("\n%d posts would be renamed\n", len(report.Renamed))
+	} else {
+		fmt.Printf("\nrenamed %d posts\n", len(report.Renamed))
+	}
+	return rerr
+}
+
+func postsMigrate(svc *bootstrap.Services, args []string) error {
+	var opts = types.ImportOptions{Topics: make(map[string]string)}
+
+	fs, asJSON := newFlagSet("posts migrate")
+	fs.StringVar(&opts.Source, "source", "", "wordpress or ghost, detected when empty")
+	fs.StringVar(&opts.Topic, "topic", "", "topic of entries without matching category")
+	fs.StringVar(&opts.License, "license", "", "license of imported posts")
+	fs.StringVar(&opts.SiteUrl, "site", "", "url of exported site, resolves relative image urls")
+	fs.BoolVar(&opts.Public, "public", false, "keep published entries public")
+	fs.BoolVar(&opts.Pages, "pages", false, "import pages as posts")
+	fs.BoolVar(&opts.SkipImages, "no-images", false, "keep images on exported site")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "report changes without writing")
+	fs.Func("map", "map category to topic as category=topic, repeatable", func(value string) error {
+		category, topic, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("invalid mapping '%s', expected category=topic", value)
+		}
+		opts.Topics[category] = topic
+		return nil
+	})
+
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<export>"); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	report, err := svc.Post.ImportPosts(data, opts)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(report)
+	}
+
+	rows := make([][]string, len(report.Items))
+	for i, item := range report.Items {
+		rows[i] = []string{
+			item.SourceId,
+			item.Status,
+			item.PostId,
+			item.Topic,
+			truncate(item.Title, 40),
+			item.Reason,
+		}
+	}
+
+	if err = printTable([]string{"SOURCE ID", "STATUS", "POST ID", "TOPIC", "TITLE", "REASON"}, rows); err != nil {
+		return err
+	}
+
+	for _, item := range report.Items {
+		for _, warning := range item.Warnings {
+			fmt.Printf("warning: %s: %s\n", item.SourceId, warning)
+		}
+	}
+
+	fmt.Printf("\ncreated: %d, skipped: %d, failed: %d\n", report.Created, report.Skipped, report.Failed)
+	if report.DryRun {
+		fmt.Println("dry run, nothing was written")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+)
+
+func redirectsList(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("redirects list")
+	post := fs.String("post", "", "list redirects of given post id only")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	redirects, err := svc.Post.GetSlugRedirects(*post)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(redirects)
+	}
+
+	rows := make([][]string, len(redirects))
+	for i, r := range redirects {
+		rows[i] = []string{
+			r.Id.Hex(),
+			r.From,
+			r.To,
+			r.PostId.Hex(),
+			r.UpdatedAt.Format("2006-01-02 15:04"),
+		}
+	}
+
+	return printTable([]string{"ID", "FROM", "TO", "POST", "UPDATED"}, rows)
+}
+
+func redirectsAdd(svc *bootstrap.Services, args []string) error {
+	fs, asJSON := newFlagSet("redirects add")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 2, "<from> <postId>"); err != nil {
+		return err
+	}
+
+	redirect, err := svc.Post.AddSlugRedirect(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(redirect)
+	}
+
+	fmt.Println(redirect.Id.Hex())
+	return nil
+}
+
+func redirectsDelete(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("redirects delete")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<id>"); err != nil {
+		return err
+	}
+
+	return svc.Post.DeleteSlugRedirect(args[0])
+}
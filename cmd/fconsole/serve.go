@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/api"
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/util"
+)
+
+func serve(svc *bootstrap.Services, args []string) error {
+	fs, _ := newFlagSet("serve")
+	addr := fs.String("addr", api.DefaultAddr, "listen address")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	token, err := api.LoadToken()
+	if err != nil {
+		return err
+	}
+
+	server, err := api.NewServer(svc, *addr, token)
+	if err != nil {
+		return err
+	}
+
+	svc.Lifecycle.StartJobs(time.Minute)
+	defer svc.Lifecycle.StopJobs()
+
+	util.Log.Info(fmt.Sprintf("[serve] Listening on %s", *addr))
+	fmt.Printf("Serving API on http://%s%s\n", *addr, api.Prefix)
+	fmt.Printf("Bearer token is stored in %s\n", api.TokenPath())
+
+	return http.ListenAndServe(*addr, server)
+}
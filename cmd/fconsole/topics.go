@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rajatxs/go-fconsole/bootstrap"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func topicsList(svc *bootstrap.Services, args []string) error {
+	var topics types.Topics
+
+	fs, asJSON := newFlagSet("topics list")
+	public := fs.Bool("public", false, "list public topics only")
+	private := fs.Bool("private", false, "list private topics only")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	switch {
+	case *public:
+		topics = svc.Topic.GetPublicTopics()
+	case *private:
+		topics = svc.Topic.GetPrivateTopics()
+	default:
+		topics = *svc.Topic.GetAllTopics()
+	}
+
+	if *asJSON {
+		return printJSON(topics)
+	}
+
+	ids := make([]string, 0, len(topics))
+	for id := range topics {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	rows := make([][]string, len(ids))
+	for i, id := range ids {
+		rows[i] = []string{id, topics[id].Name, fmt.Sprint(topics[id].Public)}
+	}
+
+	return printTable([]string{"ID", "NAME", "PUBLIC"}, rows)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/vault"
+	"golang.org/x/term"
+)
+
+// environment variables holding vault passphrases for scripts
+const (
+	passphraseEnv    = "FMC_VAULT_PASSPHRASE"
+	newPassphraseEnv = "FMC_VAULT_NEW_PASSPHRASE"
+)
+
+// vaultCommand handles vault subcommand, it runs without connecting backends
+type vaultCommand func(args []string) error
+
+var vaultCommands = map[string]vaultCommand{
+	"set":    vaultSet,
+	"list":   vaultList,
+	"remove": vaultRemove,
+	"rotate": vaultRotate,
+}
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// runVault runs vault subcommand, vault commands must work while config is incomplete
+func runVault(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing vault subcommand\n\n%s", usage)
+	}
+
+	cmd, ok := vaultCommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command 'vault %s'\n\n%s", args[0], usage)
+	}
+
+	config.ParseRootDir()
+	if err := os.MkdirAll(config.RootDir(), 0755); err != nil {
+		return err
+	}
+
+	return cmd(args[1:])
+}
+
+// isTerminal reports whether stdin is an interactive terminal
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readHidden reads single line from stdin, prompting with echo disabled on terminals
+func readHidden(prompt string) (string, error) {
+	if isTerminal() {
+		fmt.Fprint(os.Stderr, prompt)
+		line, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+
+		if err != nil {
+			return "", fmt.Errorf("cannot read input: %w", err)
+		}
+		return string(line), nil
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("cannot read input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readPassphrase returns current vault passphrase from FMC_VAULT_PASSPHRASE or terminal
+func readPassphrase() (string, error) {
+	if phrase := os.Getenv(passphraseEnv); phrase != "" {
+		return phrase, nil
+	}
+	return readHidden("Vault passphrase: ")
+}
+
+// readNewPassphrase returns new vault passphrase from given variable,
+// or asks for it twice on terminal
+func readNewPassphrase(env string) (string, error) {
+	if phrase := os.Getenv(env); phrase != "" {
+		return phrase, nil
+	}
+
+	phrase, err := readHidden("New vault passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if isTerminal() {
+		confirm, err := readHidden("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+
+		if confirm != phrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+
+	return phrase, nil
+}
+
+// openVault decrypts existing vault
+func openVault() (*vault.Vault, error) {
+	if !vault.Exists(config.VaultPath()) {
+		return nil, fmt.Errorf("no vault at %s, add a secret with 'fconsole vault set <name>'", config.VaultPath())
+	}
+
+	phrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	return vault.Open(config.VaultPath(), phrase)
+}
+
+func vaultSet(args []string) error {
+	var (
+		v   *vault.Vault
+		err error
+	)
+
+	fs, _ := newFlagSet("vault set")
+	if args, err = parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<name>"); err != nil {
+		return err
+	}
+
+	if err = config.CheckSecretName(args[0]); err != nil {
+		return err
+	}
+
+	if vault.Exists(config.VaultPath()) {
+		v, err = openVault()
+	} else {
+		var phrase string
+
+		if phrase, err = readNewPassphrase(passphraseEnv); err == nil {
+			v, err = vault.Create(config.VaultPath(), phrase)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// value is read from stdin so it does not show up in process list or shell history
+	value, err := readHidden(fmt.Sprintf("Value of %s: ", args[0]))
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		return errors.New("secret value must not be empty")
+	}
+
+	return v.Set(args[0], value)
+}
+
+func vaultList(args []string) error {
+	fs, asJSON := newFlagSet("vault list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	v, err := openVault()
+	if err != nil {
+		return err
+	}
+
+	names := v.Names()
+	if *asJSON {
+		return printJSON(names)
+	}
+
+	rows := make([][]string, len(names))
+	for i, name := range names {
+		rows[i] = []string{name}
+	}
+
+	return printTable([]string{"NAME"}, rows)
+}
+
+func vaultRemove(args []string) error {
+	fs, _ := newFlagSet("vault remove")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err = expectArgs(args, 1, "<name>"); err != nil {
+		return err
+	}
+
+	v, err := openVault()
+	if err != nil {
+		return err
+	}
+
+	if removed, err := v.Remove(args[0]); err != nil {
+		return err
+	} else if !removed {
+		return fmt.Errorf("no secret named '%s'", args[0])
+	}
+	return nil
+}
+
+func vaultRotate(args []string) error {
+	fs, _ := newFlagSet("vault rotate")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	v, err := openVault()
+	if err != nil {
+		return err
+	}
+
+	phrase, err := readNewPassphrase(newPassphraseEnv)
+	if err != nil {
+		return err
+	}
+	return v.Rotate(phrase)
+}
@@ -8,6 +8,7 @@ import (
 
 var (
 	rootDir string
+	osUser  string
 )
 
 // RootDir returns absolute path of config directory
@@ -15,8 +16,15 @@ func RootDir() string {
 	return rootDir
 }
 
-// Parse config variables
-func Parse() error {
+// Parse config variables and activates given profile of config file,
+// empty profile selects the default one
+func Parse(profile string) error {
+	ParseRootDir()
+	return loadProfile(profile)
+}
+
+// ParseRootDir sets root config directory and OS user without reading config file
+func ParseRootDir() {
 	var dir string
 
 	usr, err := user.Current()
@@ -24,8 +32,21 @@ func Parse() error {
 		dir = os.TempDir()
 	} else {
 		dir = usr.HomeDir
+		osUser = usr.Username
 	}
 
 	rootDir = filepath.Join(dir, ".fconsole")
-	return nil
+}
+
+// Actor returns name recorded as author of console operations,
+// which is the OS user or the configured admin id when user is unknown
+func Actor() string {
+	switch {
+	case osUser != "":
+		return osUser
+	case AdminId() != "":
+		return "admin:" + AdminId()
+	default:
+		return "unknown"
+	}
 }
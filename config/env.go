@@ -1,11 +1,7 @@
 package config
 
-import (
-	"os"
-)
-
 func Env() string {
-	if os.Getenv("FMC_ENV") == "production" {
+	if value(settingEnv) == "production" {
 		return "prod"
 	} else {
 		return "dev"
@@ -17,33 +13,51 @@ func IsProd() bool {
 }
 
 func MongoDbConnectionUrl() string {
-	return os.Getenv("FMC_MONGODB_CONN_URL")
+	return value(settingMongoDbUrl)
 }
 
 func MongoDbName() string {
-	return os.Getenv("FMC_MONGODB_NAME")
+	return value(settingMongoDbName)
 }
 
 func AdminId() string {
-	return os.Getenv("FMC_ADMIN_ID")
+	return value(settingAdminId)
 }
 
 func CloudinaryId() string {
-	return os.Getenv("FMC_CLOUDINARY_ID")
+	return value(settingCloudinaryId)
 }
 
 func CloudinaryURL() string {
-	return os.Getenv("CLOUDINARY_URL")
+	return value(settingCloudinaryUrl)
 }
 
 func AlgoliaAppId() string {
-	return os.Getenv("FMC_ALGOLIA_APP_ID")
+	return value(settingAlgoliaAppId)
 }
 
 func AlgoliaApiKey() string {
-	return os.Getenv("FMC_ALGOLIA_API_KEY")
+	return value(settingAlgoliaApiKey)
 }
 
 func ClientUrl() string {
-	return os.Getenv("FMC_CLIENT_URL")
+	return value(settingClientUrl)
+}
+
+// PostStore returns name of post storage backend ("mongodb" or "memory")
+func PostStore() string {
+	if value(settingPostStore) == "memory" {
+		return "memory"
+	} else {
+		return "mongodb"
+	}
+}
+
+// MediaStore returns name of image storage backend ("cloudinary" or "local")
+func MediaStore() string {
+	if value(settingMediaStore) == "local" {
+		return "local"
+	} else {
+		return "cloudinary"
+	}
 }
@@ -0,0 +1,275 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileName is name of config file kept in RootDir
+const FileName = "config.toml"
+
+// setting is single profile key with the environment variable overriding it
+type setting struct {
+	key string
+	env string
+}
+
+var (
+	settingEnv           = setting{"env", "FMC_ENV"}
+	settingAdminId       = setting{"admin_id", "FMC_ADMIN_ID"}
+	settingClientUrl     = setting{"client_url", "FMC_CLIENT_URL"}
+	settingMongoDbUrl    = setting{"mongodb_url", "FMC_MONGODB_CONN_URL"}
+	settingMongoDbName   = setting{"mongodb_name", "FMC_MONGODB_NAME"}
+	settingCloudinaryId  = setting{"cloudinary_id", "FMC_CLOUDINARY_ID"}
+	settingCloudinaryUrl = setting{"cloudinary_url", "CLOUDINARY_URL"}
+	settingAlgoliaAppId  = setting{"algolia_app_id", "FMC_ALGOLIA_APP_ID"}
+	settingAlgoliaApiKey = setting{"algolia_api_key", "FMC_ALGOLIA_API_KEY"}
+	settingMediaStore    = setting{"media_store", "FMC_MEDIA_STORE"}
+	settingPostStore     = setting{"post_store", "FMC_POST_STORE"}
+)
+
+// settings lists every key accepted in a profile
+var settings = []setting{
+	settingEnv,
+	settingAdminId,
+	settingClientUrl,
+	settingMongoDbUrl,
+	settingMongoDbName,
+	settingCloudinaryId,
+	settingCloudinaryUrl,
+	settingAlgoliaAppId,
+	settingAlgoliaApiKey,
+	settingMediaStore,
+	settingPostStore,
+}
+
+var (
+	mu            sync.RWMutex
+	profileName   string
+	profileValues = map[string]string{}
+	unknownKeys   []string
+)
+
+// FilePath returns absolute path of config file
+func FilePath() string {
+	return filepath.Join(rootDir, FileName)
+}
+
+// ProfileName returns name of active profile, empty when settings
+// come from environment variables only
+func ProfileName() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return profileName
+}
+
+// value returns setting from environment variable, unlocked vault
+// or active profile, in that order
+func value(s setting) string {
+	if v := os.Getenv(s.env); v != "" {
+		return v
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if v, ok := secret(s); ok {
+		return v
+	}
+	return profileValues[s.key]
+}
+
+// readFile parses config file, tables are nil when file does not exist
+func readFile() (tomlTables, error) {
+	file, err := os.Open(FilePath())
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	defer file.Close()
+
+	tables, err := parseTOML(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FilePath(), err)
+	}
+	return tables, nil
+}
+
+// loadProfile reads config file and activates profile by given name, FMC_PROFILE
+// or the "profile" key of config file, in that order
+// Missing config file is fine unless a profile is requested
+func loadProfile(name string) error {
+	var unknown []string
+
+	if name == "" {
+		name = os.Getenv("FMC_PROFILE")
+	}
+
+	tables, err := readFile()
+	if err != nil {
+		return err
+	}
+
+	if tables == nil {
+		if name != "" {
+			return fmt.Errorf("profile '%s' is selected but %s does not exist", name, FilePath())
+		}
+		activate("", map[string]string{}, nil)
+		return nil
+	}
+
+	names := profileNames(tables)
+
+	if name == "" {
+		name = tables[""]["profile"]
+	}
+
+	if name == "" {
+		if len(names) == 0 {
+			activate("", map[string]string{}, nil)
+			return nil
+		}
+		return fmt.Errorf("%s: no profile selected, use --profile with one of %s", FilePath(), strings.Join(names, ", "))
+	}
+
+	values, ok := tables["profiles."+name]
+	if !ok {
+		return fmt.Errorf("%s: unknown profile '%s', available profiles are %s", FilePath(), name, strings.Join(names, ", "))
+	}
+
+	for key := range values {
+		if !knownKey(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	activate(name, values, unknown)
+	return nil
+}
+
+// activate replaces active profile
+func activate(name string, values map[string]string, unknown []string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	profileName, profileValues, unknownKeys = name, values, unknown
+}
+
+// Use activates given profile at runtime, empty name selects the default one
+// Previous profile stays active when the new one cannot be loaded or is invalid
+func Use(name string) (err error) {
+	mu.RLock()
+	prevName, prevValues, prevUnknown := profileName, profileValues, unknownKeys
+	mu.RUnlock()
+
+	if err = loadProfile(name); err == nil {
+		err = Validate()
+	}
+
+	if err != nil {
+		activate(prevName, prevValues, prevUnknown)
+	}
+	return err
+}
+
+// Profiles returns sorted names of profiles defined in config file
+func Profiles() ([]string, error) {
+	tables, err := readFile()
+	if err != nil {
+		return nil, err
+	}
+	return profileNames(tables), nil
+}
+
+// profileNames returns sorted names of profiles defined in parsed config file
+func profileNames(tables tomlTables) []string {
+	names := []string{}
+
+	for table := range tables {
+		if name := strings.TrimPrefix(table, "profiles."); name != table && !strings.Contains(name, ".") {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// knownKey reports whether key is accepted in a profile
+func knownKey(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredSettings returns settings needed by configured backends
+func requiredSettings() []setting {
+	required := []setting{settingAdminId, settingClientUrl}
+
+	if PostStore() == "mongodb" {
+		required = append(required, settingMongoDbUrl, settingMongoDbName)
+	}
+
+	if MediaStore() == "cloudinary" {
+		required = append(required, settingCloudinaryId, settingCloudinaryUrl)
+	}
+
+	if IsProd() {
+		required = append(required, settingAlgoliaAppId, settingAlgoliaApiKey)
+	}
+
+	return required
+}
+
+// Validate reports every required key missing from both active profile
+// and environment, along with unknown keys of the profile
+func Validate() error {
+	var (
+		missing  []string
+		problems []string
+		source   = "environment"
+	)
+
+	mu.RLock()
+	name, unknown := profileName, unknownKeys
+	mu.RUnlock()
+
+	if name != "" {
+		source = fmt.Sprintf("profile '%s'", name)
+	}
+
+	for _, s := range requiredSettings() {
+		if value(s) == "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", s.key, s.env))
+		}
+	}
+
+	if len(missing) > 0 {
+		problems = append(problems, "missing keys "+strings.Join(missing, ", "))
+	}
+
+	if len(unknown) > 0 {
+		problems = append(problems, "unknown keys "+strings.Join(unknown, ", "))
+	}
+
+	if len(missing) > 0 && VaultLocked() {
+		problems = append(problems, fmt.Sprintf("vault %s is locked", VaultPath()))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid config of %s: %s", source, strings.Join(problems, "; "))
+}
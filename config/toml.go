@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	tomlKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	tomlTablePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+)
+
+// tomlTables maps dotted table names to their string values,
+// keys before the first table header belong to the "" table
+type tomlTables map[string]map[string]string
+
+// parseTOML reads the subset of TOML used by config file: table headers,
+// basic and literal strings, booleans and comments
+func parseTOML(r io.Reader) (tomlTables, error) {
+	var (
+		tables  = tomlTables{"": {}}
+		current = ""
+		scanner = bufio.NewScanner(r)
+		lineNo  = 0
+	)
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			end := strings.IndexByte(line, ']')
+			if end < 0 || !isTOMLComment(line[end+1:]) {
+				return nil, fmt.Errorf("line %d: malformed table header", lineNo)
+			}
+
+			name := strings.TrimSpace(line[1:end])
+			if !tomlTablePattern.MatchString(name) {
+				return nil, fmt.Errorf("line %d: invalid table name '%s'", lineNo, name)
+			}
+
+			if _, ok := tables[name]; ok {
+				return nil, fmt.Errorf("line %d: table '%s' defined twice", lineNo, name)
+			}
+
+			tables[name] = map[string]string{}
+			current = name
+			continue
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+
+		key := strings.TrimSpace(line[:eq])
+		if !tomlKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key '%s'", lineNo, key)
+		}
+
+		value, err := parseTOMLValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNo, err.Error())
+		}
+
+		if _, ok := tables[current][key]; ok {
+			return nil, fmt.Errorf("line %d: key '%s' defined twice", lineNo, key)
+		}
+		tables[current][key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// parseTOMLValue returns string of quoted or boolean value followed by optional comment
+func parseTOMLValue(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		// find closing quote, skipping escaped characters
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '\\':
+				i++
+			case '"':
+				if !isTOMLComment(raw[i+1:]) {
+					return "", fmt.Errorf("unexpected text after value")
+				}
+
+				value, err := strconv.Unquote(raw[:i+1])
+				if err != nil {
+					return "", fmt.Errorf("invalid string %s", raw[:i+1])
+				}
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("unterminated string")
+
+	case strings.HasPrefix(raw, "'"):
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+
+		if !isTOMLComment(raw[end+2:]) {
+			return "", fmt.Errorf("unexpected text after value")
+		}
+		return raw[1 : end+1], nil
+
+	default:
+		value := raw
+		if hash := strings.IndexByte(raw, '#'); hash >= 0 {
+			value = strings.TrimSpace(raw[:hash])
+		}
+
+		if value == "true" || value == "false" {
+			return value, nil
+		}
+		return "", fmt.Errorf("value must be a quoted string or boolean, got '%s'", value)
+	}
+}
+
+// isTOMLComment reports whether rest of line is blank or comment
+func isTOMLComment(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
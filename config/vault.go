@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/vault"
+)
+
+// VaultFileName is name of encrypted secrets file kept in RootDir
+const VaultFileName = "vault.json"
+
+// secrets of unlocked vault, nil while vault is locked or absent
+var secrets map[string]string
+
+// VaultPath returns absolute path of secrets vault
+func VaultPath() string {
+	return filepath.Join(rootDir, VaultFileName)
+}
+
+// VaultLocked reports whether vault exists but its secrets are not loaded yet
+func VaultLocked() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return secrets == nil && vault.Exists(VaultPath())
+}
+
+// UnlockVault decrypts vault with given passphrase and resolves settings from its secrets
+func UnlockVault(passphrase string) error {
+	v, err := vault.Open(VaultPath(), passphrase)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	secrets = v.Secrets()
+	return nil
+}
+
+// secret returns vault value of setting for active profile, a secret named
+// "<profile>.<key>" takes precedence over one named "<key>"
+// Caller must hold mu
+func secret(s setting) (string, bool) {
+	if profileName != "" {
+		if v, ok := secrets[profileName+"."+s.key]; ok {
+			return v, true
+		}
+	}
+
+	v, ok := secrets[s.key]
+	return v, ok
+}
+
+// CheckSecretName returns error unless name is a profile key, optionally
+// prefixed with profile name as in "production.mongodb_url"
+func CheckSecretName(name string) error {
+	key := name
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		if dot == 0 || !tomlKeyPattern.MatchString(name[:dot]) {
+			return fmt.Errorf("invalid profile in secret name '%s'", name)
+		}
+		key = name[dot+1:]
+	}
+
+	if !knownKey(key) {
+		keys := make([]string, len(settings))
+		for i, s := range settings {
+			keys[i] = s.key
+		}
+		return fmt.Errorf("unknown secret name '%s', expected one of %s with optional '<profile>.' prefix", name, strings.Join(keys, ", "))
+	}
+	return nil
+}
@@ -2,38 +2,78 @@ package db
 
 import (
 	"context"
+	"sync"
 
 	"github.com/rajatxs/go-fconsole/config"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var client *mongo.Client
+var (
+	mu       sync.RWMutex
+	client   *mongo.Client
+	database *mongo.Database
+)
 
+// MongoDb returns database of active client, calls made after
+// DisconnectMongoDb fail with mongo.ErrClientDisconnected
 func MongoDb() *mongo.Database {
-	return client.Database(config.MongoDbName())
+	mu.RLock()
+	defer mu.RUnlock()
+	return database
 }
 
+// ConnectMongoDb connects new client with active config and replaces the
+// current one once it answers ping, the current client stays in use on failure
 func ConnectMongoDb(ctx context.Context) (err error) {
+	var next *mongo.Client
 	clientOptions := options.Client().ApplyURI(config.MongoDbConnectionUrl())
 
 	// connect to MongoDB
-	if client, err = mongo.Connect(ctx, clientOptions); err != nil {
+	if next, err = mongo.Connect(ctx, clientOptions); err != nil {
 		return err
 	}
 
 	// check the connection
-	if err = client.Ping(ctx, nil); err != nil {
+	if err = next.Ping(ctx, nil); err != nil {
+		next.Disconnect(ctx)
 		return err
 	}
 
+	mu.Lock()
+	previous := client
+	client = next
+	database = next.Database(config.MongoDbName())
+	mu.Unlock()
+
+	// in-use connections of previous client are closed once returned
+	if previous != nil {
+		previous.Disconnect(ctx)
+	}
 	return nil
 }
 
+func PingMongoDb(ctx context.Context) error {
+	mu.RLock()
+	current := client
+	mu.RUnlock()
+
+	if current == nil {
+		return mongo.ErrClientDisconnected
+	} else {
+		return current.Ping(ctx, nil)
+	}
+}
+
+// DisconnectMongoDb closes active client, which is kept so later calls
+// fail instead of dereferencing missing client
 func DisconnectMongoDb(ctx context.Context) (err error) {
-	if client == nil {
+	mu.RLock()
+	current := client
+	mu.RUnlock()
+
+	if current == nil {
 		return nil
-	} else {
-		return client.Disconnect(ctx)
 	}
+	return current.Disconnect(ctx)
 }
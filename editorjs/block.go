@@ -0,0 +1,228 @@
+package editorjs
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Block is a single Editor.js content block
+type Block struct {
+	Id   string                 `json:"id,omitempty"`
+	Type string                 `json:"type"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// Document is the Editor.js output stored as post body
+type Document struct {
+	Time    int64   `json:"time,omitempty"`
+	Blocks  []Block `json:"blocks"`
+	Version string  `json:"version,omitempty"`
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Parse decodes Editor.js document from stored post body
+func Parse(body bson.M) (*Document, error) {
+	var doc Document
+
+	if body == nil {
+		return &doc, nil
+	}
+
+	// body may hold bson or plain JSON values, round trip normalizes both
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("invalid block body: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// Body encodes document as stored post body
+func (d *Document) Body() (bson.M, error) {
+	var body bson.M
+
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// blockIdChars is the alphabet of block ids generated by the editor
+const blockIdChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_-"
+
+// NewBlockId returns random block id in the format used by the editor
+func NewBlockId() string {
+	buf := make([]byte, 10)
+	rand.Read(buf)
+
+	for i, b := range buf {
+		buf[i] = blockIdChars[int(b)%len(blockIdChars)]
+	}
+	return string(buf)
+}
+
+// StripTags removes inline HTML markup and decodes entities
+func StripTags(text string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(strings.ReplaceAll(text, "<br>", "\n"), ""))
+}
+
+// String returns string value of data property
+func (b *Block) String(key string) string {
+	if v, ok := b.Data[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// Int returns integer value of data property
+func (b *Block) Int(key string) int {
+	switch v := b.Data[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return 0
+}
+
+// Bool returns boolean value of data property
+func (b *Block) Bool(key string) bool {
+	v, _ := b.Data[key].(bool)
+	return v
+}
+
+// ListItem is a single item of list block, nested lists keep child items
+type ListItem struct {
+	Content string
+	Items   []ListItem
+}
+
+// parseListItems reads plain or nested list items
+func parseListItems(raw interface{}) (items []ListItem) {
+	list, _ := raw.([]interface{})
+
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			items = append(items, ListItem{Content: v})
+		case map[string]interface{}:
+			content, _ := v["content"].(string)
+			items = append(items, ListItem{Content: content, Items: parseListItems(v["items"])})
+		}
+	}
+
+	return items
+}
+
+// ListItems returns items of list block
+func (b *Block) ListItems() []ListItem {
+	return parseListItems(b.Data["items"])
+}
+
+// TableContent returns rows of table block
+func (b *Block) TableContent() (rows [][]string) {
+	content, _ := b.Data["content"].([]interface{})
+
+	for _, rawRow := range content {
+		cells, _ := rawRow.([]interface{})
+		row := make([]string, len(cells))
+
+		for i, cell := range cells {
+			row[i], _ = cell.(string)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// ImageUrl returns url of image block file
+func (b *Block) ImageUrl() string {
+	if file, ok := b.Data["file"].(map[string]interface{}); ok {
+		if url, ok := file["url"].(string); ok {
+			return url
+		}
+	}
+	return b.String("url")
+}
+
+// ImagePublicId returns storage public id of image block file,
+// empty for images referenced by url only
+func (b *Block) ImagePublicId() string {
+	file, _ := b.Data["file"].(map[string]interface{})
+
+	for _, key := range []string{"path", "publicId"} {
+		if id, ok := file[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// listText flattens list items into lines
+func listText(items []ListItem, depth int) []string {
+	var lines []string
+
+	for _, item := range items {
+		lines = append(lines, strings.Repeat("  ", depth)+StripTags(item.Content))
+		lines = append(lines, listText(item.Items, depth+1)...)
+	}
+
+	return lines
+}
+
+// PlainText returns readable text content of block
+func (b *Block) PlainText() string {
+	switch b.Type {
+	case "paragraph", "header", "quote":
+		return StripTags(b.String("text"))
+
+	case "list":
+		return strings.Join(listText(b.ListItems(), 0), "\n")
+
+	case "code":
+		return b.String("code")
+
+	case "warning":
+		return strings.TrimSpace(StripTags(b.String("title")) + "\n" + StripTags(b.String("message")))
+
+	case "image":
+		return StripTags(b.String("caption"))
+
+	case "table":
+		var lines []string
+
+		for _, row := range b.TableContent() {
+			for i, cell := range row {
+				row[i] = StripTags(cell)
+			}
+			lines = append(lines, strings.Join(row, " | "))
+		}
+		return strings.Join(lines, "\n")
+
+	default:
+		raw, _ := json.Marshal(b.Data)
+		return string(raw)
+	}
+}
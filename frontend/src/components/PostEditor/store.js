@@ -19,6 +19,12 @@ export const state = reactive({
    /** @type {object} */
    body: null,
 
+   /** @type {'block'|'markdown'} */
+   format: 'block',
+
+   /** @type {string} */
+   markdown: '',
+
    /** @type {boolean} */
    publicScope: true,
 
@@ -37,10 +43,72 @@ export const state = reactive({
    /** @type {string} */
    license: 'CC-BY-4.0',
 
+   /** @type {string} */
+   authorId: '',
+
    /** @type {Array<{title: string, value: string}>} */
    relatedPosts: [],
+
+   /** @type {string} */
+   draftId: '',
 });
 
+/**
+ * Returns post payload of current state with given `body`
+ * @param {object} body
+ * @returns {import('../../../wailsjs/go/models').types.CreatePostPayload}
+ */
+export function getPayload(body) {
+   return {
+      title: state.title,
+      slug: state.slug,
+      desc: state.desc,
+      topic: state.topic || 'other',
+      tags: state.tags,
+      body,
+      public: state.publicScope,
+      format: state.format,
+      coverImageId: state.coverImageAssetId,
+      coverImagePath: state.coverImagePublicId,
+      coverImageRefName: state.coverImageRefName,
+      coverImageRefUrl: state.coverImageRefUrl,
+      authorId: state.authorId,
+      license: state.license,
+      relatedPosts: state.relatedPosts.map(p => p.value),
+   };
+}
+
+/**
+ * Sets state properties from payload of recovered `draft`
+ * @param {import('../../../wailsjs/go/models').types.Draft} draft
+ */
+export function setDraft(draft) {
+   const payload = draft.payload;
+
+   state.draftId = draft.id;
+   state.title = payload.title;
+   state.slug = payload.slug;
+   state.topic = payload.topic;
+   state.desc = payload.desc;
+   state.tags = payload.tags || [];
+   state.publicScope = payload.public;
+   state.body = payload.body;
+   state.format = payload.format === 'markdown' ? 'markdown' : 'block';
+   state.markdown = state.format === 'markdown' && payload.body ? payload.body.source || '' : '';
+   state.license = payload.license;
+   state.authorId = payload.authorId;
+   state.coverImageRefName = payload.coverImageRefName;
+   state.coverImageRefUrl = payload.coverImageRefUrl;
+   state.coverImagePublicId = payload.coverImagePath;
+   state.coverImageAssetId = payload.coverImageId;
+
+   // titles of related posts are not kept in drafts
+   state.relatedPosts = (payload.relatedPosts || []).map(id => {
+      const known = state.relatedPosts.find(p => p.value === id);
+      return {title: known ? known.title : id, value: id};
+   });
+}
+
 /**
  * Sets state properties from given `data` payload
  * @param {import('../../../wailsjs/go/models').models.PostObjectView} data 
@@ -53,7 +121,10 @@ export function setMetadata(data) {
    state.tags = data.tags;
    state.publicScope = data.public;
    state.body = data.body;
+   state.format = data.format === 'markdown' ? 'markdown' : 'block';
+   state.markdown = state.format === 'markdown' && data.body ? data.body.source || '' : '';
    state.license = data.license;
+   state.authorId = data.authorId;
 
    if (Array.isArray(data.relatedPosts)) {
       state.relatedPosts = data.relatedPosts.map(p => {
@@ -80,11 +151,15 @@ export function clearMetadata() {
    state.desc = '';
    state.tags = [];
    state.body = null;
+   state.format = 'block';
+   state.markdown = '';
    state.publicScope = true;
    state.coverImageRefName = '';
    state.coverImageRefUrl = '';
    state.coverImagePublicId = '';
    state.coverImageAssetId = '';
    state.license = 'CC-BY-4.0';
+   state.authorId = '';
    state.relatedPosts = [];
+   state.draftId = '';
 }
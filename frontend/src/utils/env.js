@@ -36,3 +36,11 @@ export function getAdminId() {
 export function getCloudinaryId() {
    return Reflect.get(_env, 'CLOUDINARY_ID') || '';
 }
+
+/**
+ * Active config profile
+ * @returns {string}
+ */
+export function getProfile() {
+   return Reflect.get(_env, 'PROFILE') || '';
+}
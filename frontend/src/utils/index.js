@@ -62,12 +62,24 @@ export function formatTime(time) {
    }
 }
 
+/**
+ * Returns url of locally stored image when local media store is active
+ * @param {string} imagePath - Image path
+ * @returns {string|null}
+ */
+function getLocalImageUrl(imagePath) {
+   if (getVariable('MEDIA_STORE') === 'local') {
+      return `/media/${imagePath}`;
+   }
+   return null;
+}
+
 /**
  * Returns absolute image url of cover image by given `imagePath`
  * @param {string} imagePath - Post cover image path
  */
 export function getPostCoverImageURL(imagePath) {
-   return `https://res.cloudinary.com/${getVariable('CLOUDINARY_ID')}/image/upload/c_scale,h_600/${imagePath}.webp`
+   return getLocalImageUrl(imagePath) || `https://res.cloudinary.com/${getVariable('CLOUDINARY_ID')}/image/upload/c_scale,h_600/${imagePath}.webp`
 }
 
 /**
@@ -75,7 +87,7 @@ export function getPostCoverImageURL(imagePath) {
  * @param {string} imagePath - Image path
  */
 export function getPostEmbeddedImageUrl(imagePath) {
-   return `https://res.cloudinary.com/${getVariable('CLOUDINARY_ID')}/image/upload/c_scale,h_600/${imagePath}`
+   return getLocalImageUrl(imagePath) || `https://res.cloudinary.com/${getVariable('CLOUDINARY_ID')}/image/upload/c_scale,h_600/${imagePath}`
 }
 
 /**
@@ -83,7 +95,7 @@ export function getPostEmbeddedImageUrl(imagePath) {
  * @param {string} imagePath - Image path
  */
 export function getPostTopicImageUrl(imagePath) {
-   return `https://res.cloudinary.com/${getVariable('CLOUDINARY_ID')}/image/upload/c_scale,h_400/${imagePath}.webp`
+   return getLocalImageUrl(imagePath) || `https://res.cloudinary.com/${getVariable('CLOUDINARY_ID')}/image/upload/c_scale,h_400/${imagePath}.webp`
 }
 
 /**
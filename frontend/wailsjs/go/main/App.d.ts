@@ -6,6 +6,12 @@ export function ClipboardSetText(arg1:string):Promise<void>;
 
 export function GetAppConfigVariables():Promise<types.AppPublicConfigVariables>;
 
+export function GetProfiles():Promise<Array<string>>;
+
 export function GetVersions():Promise<types.AppVersions>;
 
 export function OpenBrowser(arg1:string):Promise<void>;
+
+export function SwitchProfile(arg1:string):Promise<types.AppPublicConfigVariables>;
+
+export function UnlockVault(arg1:string):Promise<types.AppPublicConfigVariables>;
@@ -10,6 +10,10 @@ export function GetAppConfigVariables() {
   return window['go']['main']['App']['GetAppConfigVariables']();
 }
 
+export function GetProfiles() {
+  return window['go']['main']['App']['GetProfiles']();
+}
+
 export function GetVersions() {
   return window['go']['main']['App']['GetVersions']();
 }
@@ -17,3 +21,11 @@ export function GetVersions() {
 export function OpenBrowser(arg1) {
   return window['go']['main']['App']['OpenBrowser'](arg1);
 }
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
+export function UnlockVault(arg1) {
+  return window['go']['main']['App']['UnlockVault'](arg1);
+}
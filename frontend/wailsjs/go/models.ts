@@ -1,5 +1,162 @@
 export namespace models {
 	
+	export class AuditLogDocument {
+	    _id: number[];
+	    actor: string;
+	    operation: string;
+	    targetId: string;
+	    before?: {[key: string]: any};
+	    after?: {[key: string]: any};
+	    outcome: string;
+	    error?: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditLogDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this._id = source["_id"];
+	        this.actor = source["actor"];
+	        this.operation = source["operation"];
+	        this.targetId = source["targetId"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	        this.outcome = source["outcome"];
+	        this.error = source["error"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AuthorLink {
+	    name: string;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthorLink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	    }
+	}
+	export class AuthorDocument {
+	    _id: number[];
+	    name: string;
+	    bio: string;
+	    avatarId: string;
+	    avatarPath: string;
+	    links: AuthorLink[];
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthorDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this._id = source["_id"];
+	        this.name = source["name"];
+	        this.bio = source["bio"];
+	        this.avatarId = source["avatarId"];
+	        this.avatarPath = source["avatarPath"];
+	        this.links = this.convertValues(source["links"], AuthorLink);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class IndexOutboxDocument {
+	    _id: number[];
+	    postId: number[];
+	    action: string;
+	    version: number;
+	    attempts: number;
+	    lastError?: string;
+	    // Go type: time
+	    nextAttemptAt: any;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexOutboxDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this._id = source["_id"];
+	        this.postId = source["postId"];
+	        this.action = source["action"];
+	        this.version = source["version"];
+	        this.attempts = source["attempts"];
+	        this.lastError = source["lastError"];
+	        this.nextAttemptAt = this.convertValues(source["nextAttemptAt"], null);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class PostCoverImage {
 	    id: string;
 	    path: string;
@@ -18,6 +175,138 @@ export namespace models {
 	        this.refUrl = source["refUrl"];
 	    }
 	}
+	export class PostDocument {
+	    _id: number[];
+	    title: string;
+	    slug: string;
+	    desc: string;
+	    tags: string[];
+	    topic: string;
+	    body: {[key: string]: any};
+	    format: string;
+	    stars: number;
+	    public: boolean;
+	    deleted: boolean;
+	    coverImage?: PostCoverImage;
+	    authorId: number[];
+	    license: string;
+	    related: string[];
+	    // Go type: time
+	    publishAt?: any;
+	    // Go type: time
+	    unpublishAt?: any;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PostDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this._id = source["_id"];
+	        this.title = source["title"];
+	        this.slug = source["slug"];
+	        this.desc = source["desc"];
+	        this.tags = source["tags"];
+	        this.topic = source["topic"];
+	        this.body = source["body"];
+	        this.format = source["format"];
+	        this.stars = source["stars"];
+	        this.public = source["public"];
+	        this.deleted = source["deleted"];
+	        this.coverImage = this.convertValues(source["coverImage"], PostCoverImage);
+	        this.authorId = source["authorId"];
+	        this.license = source["license"];
+	        this.related = source["related"];
+	        this.publishAt = this.convertValues(source["publishAt"], null);
+	        this.unpublishAt = this.convertValues(source["unpublishAt"], null);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PostIndex {
+	    objectID: string;
+	    schema: number;
+	    name: string;
+	    topic: string;
+	    author: string;
+	    description: string;
+	    tags: string[];
+	    url: string;
+	    image: string;
+	    excerpt: string;
+	    headings: string[];
+	    wordCount: number;
+	    readingTime: number;
+	    license: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PostIndex(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.objectID = source["objectID"];
+	        this.schema = source["schema"];
+	        this.name = source["name"];
+	        this.topic = source["topic"];
+	        this.author = source["author"];
+	        this.description = source["description"];
+	        this.tags = source["tags"];
+	        this.url = source["url"];
+	        this.image = source["image"];
+	        this.excerpt = source["excerpt"];
+	        this.headings = source["headings"];
+	        this.wordCount = source["wordCount"];
+	        this.readingTime = source["readingTime"];
+	        this.license = source["license"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class PostMetadataDocument {
 	    _id: number[];
 	    title: string;
@@ -190,120 +479,1011 @@ export namespace models {
 		    return a;
 		}
 	}
-
-}
-
-export namespace types {
 	
-	export class AppPublicConfigVariables {
-	    ENV: string;
-	    ADMIN_ID: string;
-	    CLOUDINARY_ID: string;
+	export class PostRevisionDocument {
+	    _id: number[];
+	    postId: number[];
+	    reason: string;
+	    title: string;
+	    snapshot?: PostDocument;
+	    // Go type: time
+	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new AppPublicConfigVariables(source);
+	        return new PostRevisionDocument(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.ENV = source["ENV"];
-	        this.ADMIN_ID = source["ADMIN_ID"];
-	        this.CLOUDINARY_ID = source["CLOUDINARY_ID"];
-	    }
-	}
-	export class AppVersions {
-	    app: string;
-	    date: string;
-	    wails: string;
-	    go: string;
-	    webview2: string;
-	    os: string;
-	    arch: string;
-	    uname: string;
-	    homedir: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new AppVersions(source);
+	        this._id = source["_id"];
+	        this.postId = source["postId"];
+	        this.reason = source["reason"];
+	        this.title = source["title"];
+	        this.snapshot = this.convertValues(source["snapshot"], PostDocument);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
 	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.app = source["app"];
-	        this.date = source["date"];
-	        this.wails = source["wails"];
-	        this.go = source["go"];
-	        this.webview2 = source["webview2"];
-	        this.os = source["os"];
-	        this.arch = source["arch"];
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PostRevisionSummary {
+	    _id: number[];
+	    postId: number[];
+	    reason: string;
+	    title: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PostRevisionSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this._id = source["_id"];
+	        this.postId = source["postId"];
+	        this.reason = source["reason"];
+	        this.title = source["title"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SlugRedirectDocument {
+	    _id: number[];
+	    postId: number[];
+	    from: string;
+	    to: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SlugRedirectDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this._id = source["_id"];
+	        this.postId = source["postId"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TopicDocument {
+	    id: string;
+	    name: string;
+	    thumbId: string;
+	    thumbPath: string;
+	    public: boolean;
+	    order: number;
+	    archived: boolean;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new TopicDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.thumbId = source["thumbId"];
+	        this.thumbPath = source["thumbPath"];
+	        this.public = source["public"];
+	        this.order = source["order"];
+	        this.archived = source["archived"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace types {
+	
+	export class AppPublicConfigVariables {
+	    ENV: string;
+	    ADMIN_ID: string;
+	    CLOUDINARY_ID: string;
+	    MEDIA_STORE: string;
+	    PROFILE: string;
+	    VAULT_LOCKED: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppPublicConfigVariables(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ENV = source["ENV"];
+	        this.ADMIN_ID = source["ADMIN_ID"];
+	        this.CLOUDINARY_ID = source["CLOUDINARY_ID"];
+	        this.MEDIA_STORE = source["MEDIA_STORE"];
+	        this.PROFILE = source["PROFILE"];
+	        this.VAULT_LOCKED = source["VAULT_LOCKED"];
+	    }
+	}
+	export class AppVersions {
+	    app: string;
+	    date: string;
+	    wails: string;
+	    go: string;
+	    webview2: string;
+	    os: string;
+	    arch: string;
+	    uname: string;
+	    homedir: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppVersions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.app = source["app"];
+	        this.date = source["date"];
+	        this.wails = source["wails"];
+	        this.go = source["go"];
+	        this.webview2 = source["webview2"];
+	        this.os = source["os"];
+	        this.arch = source["arch"];
 	        this.uname = source["uname"];
 	        this.homedir = source["homedir"];
 	    }
 	}
-	export class CreatePostPayload {
-	    title: string;
-	    slug: string;
-	    desc: string;
-	    tags: string[];
-	    topic: string;
-	    body: {[key: string]: any};
+	export class AuthorLink {
+	    name: string;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthorLink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	    }
+	}
+	export class AuthorPayload {
+	    name: string;
+	    bio: string;
+	    links: AuthorLink[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthorPayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.bio = source["bio"];
+	        this.links = this.convertValues(source["links"], AuthorLink);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BackupImage {
+	    publicId: string;
+	    file: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupImage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.publicId = source["publicId"];
+	        this.file = source["file"];
+	    }
+	}
+	export class BackupManifest {
+	    version: number;
+	    // Go type: time
+	    createdAt: any;
+	    posts: number;
+	    topics: number;
+	    images: BackupImage[];
+	    missing?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupManifest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.posts = source["posts"];
+	        this.topics = source["topics"];
+	        this.images = this.convertValues(source["images"], BackupImage);
+	        this.missing = source["missing"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BackupResult {
+	    file: string;
+	    manifest?: BackupManifest;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.manifest = this.convertValues(source["manifest"], BackupManifest);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TextChange {
+	    op: string;
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TextChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.op = source["op"];
+	        this.text = source["text"];
+	    }
+	}
+	export class BlockChange {
+	    kind: string;
+	    blockId: string;
+	    type: string;
+	    oldIndex: number;
+	    newIndex: number;
+	    oldText?: string;
+	    newText?: string;
+	    text?: TextChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BlockChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.blockId = source["blockId"];
+	        this.type = source["type"];
+	        this.oldIndex = source["oldIndex"];
+	        this.newIndex = source["newIndex"];
+	        this.oldText = source["oldText"];
+	        this.newText = source["newText"];
+	        this.text = this.convertValues(source["text"], TextChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CreatePostPayload {
+	    title: string;
+	    slug: string;
+	    desc: string;
+	    tags: string[];
+	    topic: string;
+	    body: {[key: string]: any};
+	    format: string;
+	    public: boolean;
+	    coverImageId: string;
+	    coverImagePath: string;
+	    coverImageRefName: string;
+	    coverImageRefUrl: string;
+	    authorId: string;
+	    license: string;
+	    relatedPosts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CreatePostPayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.slug = source["slug"];
+	        this.desc = source["desc"];
+	        this.tags = source["tags"];
+	        this.topic = source["topic"];
+	        this.body = source["body"];
+	        this.format = source["format"];
+	        this.public = source["public"];
+	        this.coverImageId = source["coverImageId"];
+	        this.coverImagePath = source["coverImagePath"];
+	        this.coverImageRefName = source["coverImageRefName"];
+	        this.coverImageRefUrl = source["coverImageRefUrl"];
+	        this.authorId = source["authorId"];
+	        this.license = source["license"];
+	        this.relatedPosts = source["relatedPosts"];
+	    }
+	}
+	export class CreateTopicPayload {
+	    id: string;
+	    name: string;
+	    public: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateTopicPayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.public = source["public"];
+	    }
+	}
+	export class Draft {
+	    id: string;
+	    postId: string;
+	    payload: CreatePostPayload;
+	    queued: boolean;
+	    pushError: string;
+	    // Go type: time
+	    savedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Draft(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.postId = source["postId"];
+	        this.payload = this.convertValues(source["payload"], CreatePostPayload);
+	        this.queued = source["queued"];
+	        this.pushError = source["pushError"];
+	        this.savedAt = this.convertValues(source["savedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FieldChange {
+	    field: string;
+	    old: any;
+	    new: any;
+	    added?: string[];
+	    removed?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.old = source["old"];
+	        this.new = source["new"];
+	        this.added = source["added"];
+	        this.removed = source["removed"];
+	    }
+	}
+	export class GetAuditLogOptions {
+	    postId: string;
+	    actor: string;
+	    operation: string;
+	    // Go type: time
+	    from?: any;
+	    // Go type: time
+	    to?: any;
+	    limit: number;
+	    skip: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetAuditLogOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.postId = source["postId"];
+	        this.actor = source["actor"];
+	        this.operation = source["operation"];
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.limit = source["limit"];
+	        this.skip = source["skip"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GetPostsMetadataOptions {
+	    private: boolean;
+	    topic: string;
+	    authorId: string;
+	    sortBy: string;
+	    limit: number;
+	    skip: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetPostsMetadataOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.private = source["private"];
+	        this.topic = source["topic"];
+	        this.authorId = source["authorId"];
+	        this.sortBy = source["sortBy"];
+	        this.limit = source["limit"];
+	        this.skip = source["skip"];
+	    }
+	}
+	export class ImportItem {
+	    sourceId: string;
+	    title: string;
+	    slug: string;
+	    topic: string;
+	    status: string;
+	    postId?: string;
+	    reason?: string;
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sourceId = source["sourceId"];
+	        this.title = source["title"];
+	        this.slug = source["slug"];
+	        this.topic = source["topic"];
+	        this.status = source["status"];
+	        this.postId = source["postId"];
+	        this.reason = source["reason"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class ImportOptions {
+	    source: string;
+	    topic: string;
+	    topics: {[key: string]: string};
+	    license: string;
+	    public: boolean;
+	    pages: boolean;
+	    skipImages: boolean;
+	    siteUrl: string;
+	    dryRun: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.topic = source["topic"];
+	        this.topics = source["topics"];
+	        this.license = source["license"];
+	        this.public = source["public"];
+	        this.pages = source["pages"];
+	        this.skipImages = source["skipImages"];
+	        this.siteUrl = source["siteUrl"];
+	        this.dryRun = source["dryRun"];
+	    }
+	}
+	export class ImportReport {
+	    source: string;
+	    dryRun: boolean;
+	    created: number;
+	    skipped: number;
+	    failed: number;
+	    items: ImportItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.dryRun = source["dryRun"];
+	        this.created = source["created"];
+	        this.skipped = source["skipped"];
+	        this.failed = source["failed"];
+	        this.items = this.convertValues(source["items"], ImportItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IndexReconcileReport {
+	    fix: boolean;
+	    posts: number;
+	    records: number;
+	    missing: string[];
+	    orphaned: string[];
+	    stale: string[];
+	    saved: number;
+	    deleted: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexReconcileReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fix = source["fix"];
+	        this.posts = source["posts"];
+	        this.records = source["records"];
+	        this.missing = source["missing"];
+	        this.orphaned = source["orphaned"];
+	        this.stale = source["stale"];
+	        this.saved = source["saved"];
+	        this.deleted = source["deleted"];
+	    }
+	}
+	export class MediaFile {
+	    publicId: string;
+	    assetId: string;
 	    format: string;
+	    bytes: number;
+	    width: number;
+	    height: number;
+	    url: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new MediaFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.publicId = source["publicId"];
+	        this.assetId = source["assetId"];
+	        this.format = source["format"];
+	        this.bytes = source["bytes"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.url = source["url"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PostDiff {
+	    fields: FieldChange[];
+	    blocks: BlockChange[];
+	    changed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PostDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fields = this.convertValues(source["fields"], FieldChange);
+	        this.blocks = this.convertValues(source["blocks"], BlockChange);
+	        this.changed = source["changed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PostSchedule {
+	    postId: string;
+	    title: string;
 	    public: boolean;
-	    coverImageId: string;
-	    coverImagePath: string;
-	    coverImageRefName: string;
-	    coverImageRefUrl: string;
-	    authorId: string;
-	    license: string;
-	    relatedPosts: string[];
+	    // Go type: time
+	    publishAt?: any;
+	    // Go type: time
+	    unpublishAt?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CreatePostPayload(source);
+	        return new PostSchedule(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.postId = source["postId"];
 	        this.title = source["title"];
-	        this.slug = source["slug"];
-	        this.desc = source["desc"];
-	        this.tags = source["tags"];
-	        this.topic = source["topic"];
-	        this.body = source["body"];
-	        this.format = source["format"];
 	        this.public = source["public"];
-	        this.coverImageId = source["coverImageId"];
-	        this.coverImagePath = source["coverImagePath"];
-	        this.coverImageRefName = source["coverImageRefName"];
-	        this.coverImageRefUrl = source["coverImageRefUrl"];
-	        this.authorId = source["authorId"];
-	        this.license = source["license"];
-	        this.relatedPosts = source["relatedPosts"];
+	        this.publishAt = this.convertValues(source["publishAt"], null);
+	        this.unpublishAt = this.convertValues(source["unpublishAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PostSchedulePayload {
+	    // Go type: time
+	    publishAt?: any;
+	    // Go type: time
+	    unpublishAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PostSchedulePayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.publishAt = this.convertValues(source["publishAt"], null);
+	        this.unpublishAt = this.convertValues(source["unpublishAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RestoreCount {
+	    created: number;
+	    overwritten: number;
+	    skipped: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.created = source["created"];
+	        this.overwritten = source["overwritten"];
+	        this.skipped = source["skipped"];
 	    }
 	}
-	export class GetPostsMetadataOptions {
-	    private: boolean;
-	    topic: string;
-	    sortBy: string;
-	    limit: number;
-	    skip: number;
+	export class RestoreOptions {
+	    mode: string;
+	    dryRun: boolean;
+	    images: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new GetPostsMetadataOptions(source);
+	        return new RestoreOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.private = source["private"];
-	        this.topic = source["topic"];
-	        this.sortBy = source["sortBy"];
-	        this.limit = source["limit"];
-	        this.skip = source["skip"];
+	        this.mode = source["mode"];
+	        this.dryRun = source["dryRun"];
+	        this.images = source["images"];
+	    }
+	}
+	export class RestoreReport {
+	    dryRun: boolean;
+	    mode: string;
+	    posts: RestoreCount;
+	    topics: RestoreCount;
+	    images: RestoreCount;
+	    errors: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.mode = source["mode"];
+	        this.posts = this.convertValues(source["posts"], RestoreCount);
+	        this.topics = this.convertValues(source["topics"], RestoreCount);
+	        this.images = this.convertValues(source["images"], RestoreCount);
+	        this.errors = source["errors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SlugRename {
+	    postId: string;
+	    title: string;
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SlugRename(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.postId = source["postId"];
+	        this.title = source["title"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class SlugMigrationReport {
+	    dryRun: boolean;
+	    renamed: SlugRename[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SlugMigrationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.renamed = this.convertValues(source["renamed"], SlugRename);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
+	
 	export class Topic {
 	    name: string;
 	    thumbId: string;
 	    thumbPath: string;
 	    public: boolean;
+	    order: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Topic(source);
@@ -315,6 +1495,7 @@ export namespace types {
 	        this.thumbId = source["thumbId"];
 	        this.thumbPath = source["thumbPath"];
 	        this.public = source["public"];
+	        this.order = source["order"];
 	    }
 	}
 	export class UpdatePostPayload {
@@ -353,6 +1534,20 @@ export namespace types {
 	        this.relatedPosts = source["relatedPosts"];
 	    }
 	}
+	export class UpdateTopicPayload {
+	    name: string;
+	    public: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateTopicPayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.public = source["public"];
+	    }
+	}
 	export class UploadedImageFile {
 	    publicId: string;
 	    assetId: string;
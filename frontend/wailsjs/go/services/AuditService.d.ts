@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {types} from '../models';
+import {models} from '../models';
+import {primitive} from '../models';
+
+export function GetAuditLog(arg1:types.GetAuditLogOptions):Promise<Array<models.AuditLogDocument>>;
+
+export function Record(arg1:string,arg2:string,arg3:string,arg4:primitive.M,arg5:primitive.M,arg6:Error):Promise<void>;
@@ -0,0 +1,11 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function GetAuditLog(arg1) {
+  return window['go']['services']['AuditService']['GetAuditLog'](arg1);
+}
+
+export function Record(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['services']['AuditService']['Record'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
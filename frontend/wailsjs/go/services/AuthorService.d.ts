@@ -0,0 +1,18 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {types} from '../models';
+import {models} from '../models';
+
+export function CreateAuthor(arg1:types.AuthorPayload):Promise<string>;
+
+export function DeleteAuthor(arg1:string):Promise<void>;
+
+export function GetAllAuthors():Promise<Array<models.AuthorDocument>>;
+
+export function GetAuthorById(arg1:string):Promise<models.AuthorDocument>;
+
+export function GetAuthorNameById(arg1:string):Promise<string>;
+
+export function UpdateAuthor(arg1:string,arg2:types.AuthorPayload):Promise<void>;
+
+export function UploadAuthorAvatar(arg1:string,arg2:Array<number>):Promise<types.UploadedImageFile>;
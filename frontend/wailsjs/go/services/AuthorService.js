@@ -0,0 +1,31 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function CreateAuthor(arg1) {
+  return window['go']['services']['AuthorService']['CreateAuthor'](arg1);
+}
+
+export function DeleteAuthor(arg1) {
+  return window['go']['services']['AuthorService']['DeleteAuthor'](arg1);
+}
+
+export function GetAllAuthors() {
+  return window['go']['services']['AuthorService']['GetAllAuthors']();
+}
+
+export function GetAuthorById(arg1) {
+  return window['go']['services']['AuthorService']['GetAuthorById'](arg1);
+}
+
+export function GetAuthorNameById(arg1) {
+  return window['go']['services']['AuthorService']['GetAuthorNameById'](arg1);
+}
+
+export function UpdateAuthor(arg1, arg2) {
+  return window['go']['services']['AuthorService']['UpdateAuthor'](arg1, arg2);
+}
+
+export function UploadAuthorAvatar(arg1, arg2) {
+  return window['go']['services']['AuthorService']['UploadAuthorAvatar'](arg1, arg2);
+}
@@ -0,0 +1,9 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {types} from '../models';
+
+export function CreateBackup(arg1:string,arg2:boolean):Promise<types.BackupResult>;
+
+export function GetBackupManifest(arg1:string):Promise<types.BackupManifest>;
+
+export function RestoreBackup(arg1:string,arg2:types.RestoreOptions):Promise<types.RestoreReport>;
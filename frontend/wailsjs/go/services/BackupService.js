@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function CreateBackup(arg1, arg2) {
+  return window['go']['services']['BackupService']['CreateBackup'](arg1, arg2);
+}
+
+export function GetBackupManifest(arg1) {
+  return window['go']['services']['BackupService']['GetBackupManifest'](arg1);
+}
+
+export function RestoreBackup(arg1, arg2) {
+  return window['go']['services']['BackupService']['RestoreBackup'](arg1, arg2);
+}
@@ -0,0 +1,17 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+import {types} from '../models';
+
+export function DeleteDraft(arg1:string):Promise<void>;
+
+export function GetDraft(arg1:string):Promise<types.Draft>;
+
+export function GetDrafts():Promise<Array<types.Draft>>;
+
+export function GetPostDraft(arg1:string):Promise<types.Draft>;
+
+export function PushDraft(arg1:string):Promise<string>;
+
+export function PushQueuedDrafts():Promise<number>;
+
+export function SaveDraft(arg1:types.Draft):Promise<types.Draft>;
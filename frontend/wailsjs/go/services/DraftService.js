@@ -0,0 +1,31 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function DeleteDraft(arg1) {
+  return window['go']['services']['DraftService']['DeleteDraft'](arg1);
+}
+
+export function GetDraft(arg1) {
+  return window['go']['services']['DraftService']['GetDraft'](arg1);
+}
+
+export function GetDrafts() {
+  return window['go']['services']['DraftService']['GetDrafts']();
+}
+
+export function GetPostDraft(arg1) {
+  return window['go']['services']['DraftService']['GetPostDraft'](arg1);
+}
+
+export function PushDraft(arg1) {
+  return window['go']['services']['DraftService']['PushDraft'](arg1);
+}
+
+export function PushQueuedDrafts() {
+  return window['go']['services']['DraftService']['PushQueuedDrafts']();
+}
+
+export function SaveDraft(arg1) {
+  return window['go']['services']['DraftService']['SaveDraft'](arg1);
+}
@@ -1,25 +1,73 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {models} from '../models';
 import {types} from '../models';
 import {mongo} from '../models';
-import {models} from '../models';
+
+export function AddSlugRedirect(arg1:string,arg2:string):Promise<models.SlugRedirectDocument>;
+
+export function ClearPostSchedule(arg1:string):Promise<void>;
+
+export function ConvertPostFormat(arg1:string,arg2:string):Promise<void>;
 
 export function CreatePost(arg1:types.CreatePostPayload):Promise<mongo.InsertOneResult>;
 
+export function CreatePostFromMarkdown(arg1:string):Promise<mongo.InsertOneResult>;
+
 export function DeletePostImage(arg1:string):Promise<void>;
 
+export function DeleteSlugRedirect(arg1:string):Promise<void>;
+
+export function DiffPostRevision(arg1:string):Promise<types.PostDiff>;
+
+export function DiffPostSnapshot(arg1:string,arg2:string):Promise<types.PostDiff>;
+
+export function DiffPostUpdate(arg1:string,arg2:types.UpdatePostPayload):Promise<types.PostDiff>;
+
+export function ExportPostMarkdown(arg1:string):Promise<string>;
+
+export function GenerateSlug(arg1:string):Promise<string>;
+
+export function GetIndexOutbox(arg1:boolean):Promise<Array<models.IndexOutboxDocument>>;
+
 export function GetPostById(arg1:string):Promise<models.PostObjectView>;
 
 export function GetPostCount(arg1:string,arg2:boolean):Promise<number>;
 
+export function GetPostImages(arg1:string):Promise<Array<types.MediaFile>>;
+
 export function GetPostMetadataById(arg1:string,arg2:boolean):Promise<models.PostMetadataDocument>;
 
+export function GetPostRevision(arg1:string):Promise<models.PostRevisionDocument>;
+
+export function GetPostRevisions(arg1:string):Promise<Array<models.PostRevisionSummary>>;
+
 export function GetPostsMetadata(arg1:types.GetPostsMetadataOptions):Promise<Array<models.PostMetadataDocument>>;
 
 export function GetPublicPostCountByTopic(arg1:string):Promise<number>;
 
+export function GetScheduledPosts():Promise<Array<types.PostSchedule>>;
+
+export function GetSlugRedirects(arg1:string):Promise<Array<models.SlugRedirectDocument>>;
+
+export function ImportPosts(arg1:Array<number>,arg2:types.ImportOptions):Promise<types.ImportReport>;
+
+export function MigrateDuplicateSlugs(arg1:boolean):Promise<types.SlugMigrationReport>;
+
+export function ReconcileIndex(arg1:boolean):Promise<types.IndexReconcileReport>;
+
+export function RenderPostHTML(arg1:string):Promise<string>;
+
+export function RestorePostRevision(arg1:string):Promise<mongo.UpdateResult>;
+
+export function RetryIndexOutbox():Promise<number>;
+
+export function SearchPosts(arg1:string,arg2:number):Promise<Array<models.PostIndex>>;
+
 export function SetPostDeleteFlag(arg1:string,arg2:boolean):Promise<void>;
 
+export function SetPostSchedule(arg1:string,arg2:types.PostSchedulePayload):Promise<void>;
+
 export function UpdatePostById(arg1:string,arg2:types.UpdatePostPayload):Promise<mongo.UpdateResult>;
 
 export function UpdatePostScope(arg1:string,arg2:string):Promise<void>;
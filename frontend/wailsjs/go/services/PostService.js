@@ -2,14 +2,58 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddSlugRedirect(arg1, arg2) {
+  return window['go']['services']['PostService']['AddSlugRedirect'](arg1, arg2);
+}
+
+export function ClearPostSchedule(arg1) {
+  return window['go']['services']['PostService']['ClearPostSchedule'](arg1);
+}
+
+export function ConvertPostFormat(arg1, arg2) {
+  return window['go']['services']['PostService']['ConvertPostFormat'](arg1, arg2);
+}
+
 export function CreatePost(arg1) {
   return window['go']['services']['PostService']['CreatePost'](arg1);
 }
 
+export function CreatePostFromMarkdown(arg1) {
+  return window['go']['services']['PostService']['CreatePostFromMarkdown'](arg1);
+}
+
 export function DeletePostImage(arg1) {
   return window['go']['services']['PostService']['DeletePostImage'](arg1);
 }
 
+export function DeleteSlugRedirect(arg1) {
+  return window['go']['services']['PostService']['DeleteSlugRedirect'](arg1);
+}
+
+export function DiffPostRevision(arg1) {
+  return window['go']['services']['PostService']['DiffPostRevision'](arg1);
+}
+
+export function DiffPostSnapshot(arg1, arg2) {
+  return window['go']['services']['PostService']['DiffPostSnapshot'](arg1, arg2);
+}
+
+export function DiffPostUpdate(arg1, arg2) {
+  return window['go']['services']['PostService']['DiffPostUpdate'](arg1, arg2);
+}
+
+export function ExportPostMarkdown(arg1) {
+  return window['go']['services']['PostService']['ExportPostMarkdown'](arg1);
+}
+
+export function GenerateSlug(arg1) {
+  return window['go']['services']['PostService']['GenerateSlug'](arg1);
+}
+
+export function GetIndexOutbox(arg1) {
+  return window['go']['services']['PostService']['GetIndexOutbox'](arg1);
+}
+
 export function GetPostById(arg1) {
   return window['go']['services']['PostService']['GetPostById'](arg1);
 }
@@ -18,10 +62,22 @@ export function GetPostCount(arg1, arg2) {
   return window['go']['services']['PostService']['GetPostCount'](arg1, arg2);
 }
 
+export function GetPostImages(arg1) {
+  return window['go']['services']['PostService']['GetPostImages'](arg1);
+}
+
 export function GetPostMetadataById(arg1, arg2) {
   return window['go']['services']['PostService']['GetPostMetadataById'](arg1, arg2);
 }
 
+export function GetPostRevision(arg1) {
+  return window['go']['services']['PostService']['GetPostRevision'](arg1);
+}
+
+export function GetPostRevisions(arg1) {
+  return window['go']['services']['PostService']['GetPostRevisions'](arg1);
+}
+
 export function GetPostsMetadata(arg1) {
   return window['go']['services']['PostService']['GetPostsMetadata'](arg1);
 }
@@ -30,10 +86,50 @@ export function GetPublicPostCountByTopic(arg1) {
   return window['go']['services']['PostService']['GetPublicPostCountByTopic'](arg1);
 }
 
+export function GetScheduledPosts() {
+  return window['go']['services']['PostService']['GetScheduledPosts']();
+}
+
+export function GetSlugRedirects(arg1) {
+  return window['go']['services']['PostService']['GetSlugRedirects'](arg1);
+}
+
+export function ImportPosts(arg1, arg2) {
+  return window['go']['services']['PostService']['ImportPosts'](arg1, arg2);
+}
+
+export function MigrateDuplicateSlugs(arg1) {
+  return window['go']['services']['PostService']['MigrateDuplicateSlugs'](arg1);
+}
+
+export function ReconcileIndex(arg1) {
+  return window['go']['services']['PostService']['ReconcileIndex'](arg1);
+}
+
+export function RenderPostHTML(arg1) {
+  return window['go']['services']['PostService']['RenderPostHTML'](arg1);
+}
+
+export function RestorePostRevision(arg1) {
+  return window['go']['services']['PostService']['RestorePostRevision'](arg1);
+}
+
+export function RetryIndexOutbox() {
+  return window['go']['services']['PostService']['RetryIndexOutbox']();
+}
+
+export function SearchPosts(arg1, arg2) {
+  return window['go']['services']['PostService']['SearchPosts'](arg1, arg2);
+}
+
 export function SetPostDeleteFlag(arg1, arg2) {
   return window['go']['services']['PostService']['SetPostDeleteFlag'](arg1, arg2);
 }
 
+export function SetPostSchedule(arg1, arg2) {
+  return window['go']['services']['PostService']['SetPostSchedule'](arg1, arg2);
+}
+
 export function UpdatePostById(arg1, arg2) {
   return window['go']['services']['PostService']['UpdatePostById'](arg1, arg2);
 }
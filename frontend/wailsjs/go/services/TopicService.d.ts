@@ -1,9 +1,16 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {types} from '../models';
+import {models} from '../models';
+
+export function ArchiveTopic(arg1:string,arg2:boolean):Promise<void>;
+
+export function CreateTopic(arg1:types.CreateTopicPayload):Promise<void>;
 
 export function GetAllTopics():Promise<any>;
 
+export function GetArchivedTopics():Promise<Array<models.TopicDocument>>;
+
 export function GetPrivateTopics():Promise<{[key: string]: types.Topic}>;
 
 export function GetPublicTopics():Promise<{[key: string]: types.Topic}>;
@@ -11,3 +18,9 @@ export function GetPublicTopics():Promise<{[key: string]: types.Topic}>;
 export function GetTopicById(arg1:string):Promise<types.Topic>;
 
 export function GetTopicNameById(arg1:string):Promise<string>;
+
+export function ReorderTopics(arg1:Array<string>):Promise<void>;
+
+export function UpdateTopic(arg1:string,arg2:types.UpdateTopicPayload):Promise<void>;
+
+export function UploadTopicThumb(arg1:string,arg2:Array<number>):Promise<types.UploadedImageFile>;
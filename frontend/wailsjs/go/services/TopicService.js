@@ -2,10 +2,22 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ArchiveTopic(arg1, arg2) {
+  return window['go']['services']['TopicService']['ArchiveTopic'](arg1, arg2);
+}
+
+export function CreateTopic(arg1) {
+  return window['go']['services']['TopicService']['CreateTopic'](arg1);
+}
+
 export function GetAllTopics() {
   return window['go']['services']['TopicService']['GetAllTopics']();
 }
 
+export function GetArchivedTopics() {
+  return window['go']['services']['TopicService']['GetArchivedTopics']();
+}
+
 export function GetPrivateTopics() {
   return window['go']['services']['TopicService']['GetPrivateTopics']();
 }
@@ -21,3 +33,15 @@ export function GetTopicById(arg1) {
 export function GetTopicNameById(arg1) {
   return window['go']['services']['TopicService']['GetTopicNameById'](arg1);
 }
+
+export function ReorderTopics(arg1) {
+  return window['go']['services']['TopicService']['ReorderTopics'](arg1);
+}
+
+export function UpdateTopic(arg1, arg2) {
+  return window['go']['services']['TopicService']['UpdateTopic'](arg1, arg2);
+}
+
+export function UploadTopicThumb(arg1, arg2) {
+  return window['go']['services']['TopicService']['UploadTopicThumb'](arg1, arg2);
+}
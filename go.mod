@@ -7,6 +7,8 @@ require (
 	github.com/cloudinary/cloudinary-go/v2 v2.5.1
 	github.com/wailsapp/wails/v2 v2.6.0
 	go.mongodb.org/mongo-driver v1.12.1
+	golang.org/x/crypto v0.9.0
+	golang.org/x/term v0.10.0
 )
 
 require (
@@ -39,11 +41,10 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20181117223130-1be2e3e5546d // indirect
-	golang.org/x/crypto v0.9.0 // indirect
 	golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1 // indirect
 	golang.org/x/net v0.10.0 // indirect
 	golang.org/x/sync v0.0.0-20220722155255-886fb9371eb4 // indirect
-	golang.org/x/sys v0.8.0 // indirect
+	golang.org/x/sys v0.10.0 // indirect
 	golang.org/x/text v0.9.0 // indirect
 )
 
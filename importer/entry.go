@@ -0,0 +1,89 @@
+// Package importer reads posts exported by other blogging platforms
+//
+// Supported exports are WordPress eXtended RSS (WXR) files and Ghost JSON
+// exports. Both are read into platform neutral entries whose HTML content
+// is converted into Editor.js documents by HTMLToDocument.
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/slug"
+)
+
+// export sources
+const (
+	SourceWordPress = "wordpress"
+	SourceGhost     = "ghost"
+)
+
+// entry types
+const (
+	EntryPost = "post"
+	EntryPage = "page"
+)
+
+// Entry is single post or page read from an export
+type Entry struct {
+	// SourceId is id of entry in exporting platform
+	SourceId string
+	Type     string
+	Title    string
+	Slug     string
+	Excerpt  string
+	HTML     string
+
+	// Published is false for drafts, pending and private entries
+	Published   bool
+	Status      string
+	PublishedAt time.Time
+
+	// Categories are candidates for topic, most specific first
+	Categories []string
+	Tags       []string
+
+	// FeatureImage is absolute or site relative url of cover image
+	FeatureImage string
+}
+
+// Detect returns source of export by its content
+func Detect(data []byte) (string, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+
+	switch {
+	case bytes.HasPrefix(data, []byte("<")):
+		return SourceWordPress, nil
+	case bytes.HasPrefix(data, []byte("{")):
+		return SourceGhost, nil
+	default:
+		return "", errors.New("unrecognized export, expected WordPress WXR or Ghost JSON")
+	}
+}
+
+// Parse reads entries of export from given source
+func Parse(source string, data []byte) ([]Entry, error) {
+	switch source {
+	case SourceWordPress:
+		return ParseWXR(data)
+	case SourceGhost:
+		return ParseGhost(data)
+	default:
+		return nil, errors.New("unknown export source '" + source + "'")
+	}
+}
+
+// entrySlug returns slug of exported post name, percent encoded names of
+// WordPress are decoded and transliterated, title is used when name is empty
+func entrySlug(name string, title string) string {
+	if decoded, err := url.PathUnescape(name); err == nil {
+		name = decoded
+	}
+
+	if s := slug.Make(name); s != "" {
+		return s
+	}
+	return slug.Make(title)
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ghostPost struct {
+	Id            string     `json:"id"`
+	Title         string     `json:"title"`
+	Slug          string     `json:"slug"`
+	HTML          *string    `json:"html"`
+	FeatureImage  *string    `json:"feature_image"`
+	Status        string     `json:"status"`
+	Type          string     `json:"type"`
+	Page          bool       `json:"page"`
+	CustomExcerpt *string    `json:"custom_excerpt"`
+	PublishedAt   *time.Time `json:"published_at"`
+	CreatedAt     *time.Time `json:"created_at"`
+}
+
+type ghostTag struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ghostPostTag struct {
+	PostId    string `json:"post_id"`
+	TagId     string `json:"tag_id"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type ghostPostMeta struct {
+	PostId          string  `json:"post_id"`
+	MetaDescription *string `json:"meta_description"`
+}
+
+type ghostData struct {
+	Posts     []ghostPost     `json:"posts"`
+	Tags      []ghostTag      `json:"tags"`
+	PostsTags []ghostPostTag  `json:"posts_tags"`
+	PostsMeta []ghostPostMeta `json:"posts_meta"`
+}
+
+type ghostFile struct {
+	Db   []struct{ Data ghostData } `json:"db"`
+	Data *ghostData                 `json:"data"`
+}
+
+// str returns value of optional string
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
+// ParseGhost reads posts and pages of Ghost JSON export,
+// internal tags starting with # are ignored and the primary tag
+// is the topic candidate
+func ParseGhost(data []byte) ([]Entry, error) {
+	var (
+		file     ghostFile
+		gd       *ghostData
+		tags     = make(map[string]ghostTag)
+		postTags = make(map[string][]ghostPostTag)
+		excerpts = make(map[string]string)
+		entries  []Entry
+	)
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid Ghost export: %w", err)
+	}
+
+	switch {
+	case len(file.Db) > 0:
+		gd = &file.Db[0].Data
+	case file.Data != nil:
+		gd = file.Data
+	default:
+		return nil, fmt.Errorf("invalid Ghost export: missing db data")
+	}
+
+	for _, tag := range gd.Tags {
+		tags[tag.Id] = tag
+	}
+
+	for _, pt := range gd.PostsTags {
+		postTags[pt.PostId] = append(postTags[pt.PostId], pt)
+	}
+
+	for _, meta := range gd.PostsMeta {
+		excerpts[meta.PostId] = str(meta.MetaDescription)
+	}
+
+	for _, post := range gd.Posts {
+		entry := Entry{
+			SourceId:     post.Id,
+			Type:         EntryPost,
+			Title:        strings.TrimSpace(post.Title),
+			Excerpt:      str(post.CustomExcerpt),
+			HTML:         str(post.HTML),
+			Status:       post.Status,
+			Published:    post.Status == "published",
+			FeatureImage: str(post.FeatureImage),
+		}
+		entry.Slug = entrySlug(post.Slug, entry.Title)
+
+		if post.Page || post.Type == EntryPage {
+			entry.Type = EntryPage
+		}
+
+		if entry.Excerpt == "" {
+			entry.Excerpt = excerpts[post.Id]
+		}
+
+		if post.PublishedAt != nil {
+			entry.PublishedAt = *post.PublishedAt
+		} else if post.CreatedAt != nil {
+			entry.PublishedAt = *post.CreatedAt
+		}
+
+		refs := postTags[post.Id]
+		sort.SliceStable(refs, func(i, j int) bool { return refs[i].SortOrder < refs[j].SortOrder })
+
+		for _, ref := range refs {
+			tag, ok := tags[ref.TagId]
+			if !ok || strings.HasPrefix(tag.Name, "#") {
+				continue
+			}
+
+			if len(entry.Categories) == 0 {
+				entry.Categories = []string{tag.Slug, tag.Name}
+			}
+			entry.Tags = append(entry.Tags, tag.Name)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testGhost = `{
+	"db": [{
+		"meta": {"version": "5.0.0"},
+		"data": {
+			"posts": [
+				{
+					"id": "p1",
+					"title": " Hello Ghost ",
+					"slug": "hello-ghost",
+					"html": "<h2>Intro</h2><p>Text</p><figure><img src=\"/content/images/a.png\"><figcaption>A</figcaption></figure>",
+					"feature_image": "/content/images/cover.png",
+					"status": "published",
+					"type": "post",
+					"custom_excerpt": null,
+					"published_at": "2024-02-01T10:00:00.000Z",
+					"created_at": "2024-01-31T10:00:00.000Z"
+				},
+				{
+					"id": "p2",
+					"title": "About",
+					"slug": "",
+					"html": null,
+					"feature_image": null,
+					"status": "draft",
+					"page": true,
+					"custom_excerpt": "About us",
+					"published_at": null,
+					"created_at": "2024-01-15T08:00:00.000Z"
+				}
+			],
+			"tags": [
+				{"id": "t1", "name": "Go", "slug": "golang"},
+				{"id": "t2", "name": "#internal", "slug": "hash-internal"},
+				{"id": "t3", "name": "Tips", "slug": "tips"}
+			],
+			"posts_tags": [
+				{"post_id": "p1", "tag_id": "t3", "sort_order": 2},
+				{"post_id": "p1", "tag_id": "t2", "sort_order": 0},
+				{"post_id": "p1", "tag_id": "t1", "sort_order": 1}
+			],
+			"posts_meta": [
+				{"post_id": "p1", "meta_description": "Meta description"},
+				{"post_id": "p2", "meta_description": "Ignored"}
+			]
+		}
+	}]
+}`
+
+func TestParseGhost(t *testing.T) {
+	if source, err := Detect([]byte("\n" + testGhost)); err != nil || source != SourceGhost {
+		t.Fatalf("Detect() = %q, %v, want %q", source, err, SourceGhost)
+	}
+
+	entries, err := Parse(SourceGhost, []byte(testGhost))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Entry{
+		{
+			SourceId:     "p1",
+			Type:         EntryPost,
+			Title:        "Hello Ghost",
+			Slug:         "hello-ghost",
+			Excerpt:      "Meta description",
+			HTML:         `<h2>Intro</h2><p>Text</p><figure><img src="/content/images/a.png"><figcaption>A</figcaption></figure>`,
+			Published:    true,
+			Status:       "published",
+			PublishedAt:  time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC),
+			Categories:   []string{"golang", "Go"},
+			Tags:         []string{"Go", "Tips"},
+			FeatureImage: "/content/images/cover.png",
+		},
+		{
+			SourceId:    "p2",
+			Type:        EntryPage,
+			Title:       "About",
+			Slug:        "about",
+			Excerpt:     "About us",
+			Status:      "draft",
+			PublishedAt: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("Parse() returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+
+	for i := range want {
+		if !entries[i].PublishedAt.Equal(want[i].PublishedAt) {
+			t.Errorf("entry %d published at %v, want %v", i, entries[i].PublishedAt, want[i].PublishedAt)
+		}
+		entries[i].PublishedAt = want[i].PublishedAt
+
+		if !reflect.DeepEqual(entries[i], want[i]) {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	var blockTypes []string
+	for _, block := range HTMLToDocument(entries[0].HTML, false).Blocks {
+		blockTypes = append(blockTypes, block.Type)
+	}
+
+	if want := []string{"header", "paragraph", "image"}; !reflect.DeepEqual(blockTypes, want) {
+		t.Errorf("block types = %v, want %v", blockTypes, want)
+	}
+}
+
+func TestParseGhostLayouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{"content api layout", `{"data": {"posts": [{"id": "p1", "title": "Post"}]}}`, 1, false},
+		{"missing data", `{"meta": {}}`, 0, true},
+		{"malformed json", `{"db": [`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseGhost([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGhost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(entries) != tt.want {
+				t.Errorf("ParseGhost() returned %d entries, want %d", len(entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectUnknown(t *testing.T) {
+	if _, err := Detect([]byte("title,slug\n")); err == nil {
+		t.Error("Detect() of CSV returned no error")
+	}
+}
@@ -0,0 +1,649 @@
+package importer
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/render"
+)
+
+var (
+	attrPattern      = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+	blankLinePattern = regexp.MustCompile(`\n[ \t]*\n`)
+	lineBreakPattern = regexp.MustCompile(`[ \t]*\n[ \t]*`)
+	tagNamePattern   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*`)
+)
+
+// header levels supported by the post editor
+const (
+	minHeaderLevel = 2
+	maxHeaderLevel = 5
+)
+
+// voidTags never have content or closing tag
+var voidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// droppedTags are skipped together with their content
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "iframe": true,
+	"object": true, "video": true, "audio": true, "svg": true, "form": true, "button": true,
+}
+
+// blockTags start a new block when found inside a paragraph
+var blockTags = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true, "header": true,
+	"footer": true, "aside": true, "nav": true, "figure": true, "figcaption": true, "blockquote": true,
+	"pre": true, "ul": true, "ol": true, "li": true, "table": true, "hr": true, "center": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "details": true,
+	"summary": true, "dl": true, "dt": true, "dd": true,
+}
+
+// inlineTags maps inline markup to markup produced by the post editor
+var inlineTags = map[string]string{
+	"b":      "<b>",
+	"strong": "<b>",
+	"i":      "<i>",
+	"em":     "<i>",
+	"cite":   "<i>",
+	"u":      "<u>",
+	"ins":    "<u>",
+	"mark":   `<mark class="cdx-marker">`,
+	"code":   `<code class="inline-code">`,
+	"kbd":    `<code class="inline-code">`,
+	"tt":     `<code class="inline-code">`,
+	"samp":   `<code class="inline-code">`,
+}
+
+// node is an element or text of parsed HTML
+type node struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*node
+	parent   *node
+}
+
+// isText reports whether node is a text node
+func (n *node) isText() bool {
+	return n.tag == ""
+}
+
+// parseAttrs returns attributes of raw tag content
+func parseAttrs(raw string) map[string]string {
+	attrs := make(map[string]string)
+
+	for _, m := range attrPattern.FindAllStringSubmatch(raw, -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+	}
+	return attrs
+}
+
+// tagEnd returns index of closing bracket of tag starting at i,
+// quoted attribute values may contain brackets
+func tagEnd(src string, i int) int {
+	var quote byte
+
+	for j := i + 1; j < len(src); j++ {
+		switch c := src[j]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return j
+		}
+	}
+	return -1
+}
+
+// treeBuilder builds lenient element tree from tag soup
+type treeBuilder struct {
+	root *node
+	cur  *node
+}
+
+// closeTo closes open elements up to and including nearest element of given tag,
+// stopping at elements in stop set
+func (tb *treeBuilder) closeTo(tag string, stop map[string]bool) bool {
+	for n := tb.cur; n != tb.root; n = n.parent {
+		if n.tag == tag {
+			tb.cur = n.parent
+			return true
+		}
+		if stop[n.tag] {
+			return false
+		}
+	}
+	return false
+}
+
+// open starts new element, implicitly closing elements it cannot be nested in
+func (tb *treeBuilder) open(tag string, attrs map[string]string) {
+	switch {
+	case tag == "li":
+		tb.closeTo("li", map[string]bool{"ul": true, "ol": true})
+	case tag == "td" || tag == "th":
+		tb.closeTo("td", map[string]bool{"tr": true, "table": true})
+		tb.closeTo("th", map[string]bool{"tr": true, "table": true})
+	case tag == "tr":
+		tb.closeTo("tr", map[string]bool{"table": true})
+	}
+
+	if blockTags[tag] || tag == "table" {
+		tb.closeTo("p", map[string]bool{"div": true, "blockquote": true, "li": true, "td": true, "th": true, "figure": true})
+	}
+
+	n := &node{tag: tag, attrs: attrs, parent: tb.cur}
+	tb.cur.children = append(tb.cur.children, n)
+
+	if !voidTags[tag] {
+		tb.cur = n
+	}
+}
+
+// text appends text node
+func (tb *treeBuilder) text(text string) {
+	if text != "" {
+		tb.cur.children = append(tb.cur.children, &node{text: text, parent: tb.cur})
+	}
+}
+
+// parseHTML returns root of lenient element tree of given HTML,
+// comments, processing instructions and dropped tags are removed
+func parseHTML(src string) *node {
+	root := &node{tag: "#root"}
+	tb := &treeBuilder{root: root, cur: root}
+
+	for i := 0; i < len(src); {
+		lt := strings.IndexByte(src[i:], '<')
+		if lt < 0 {
+			tb.text(src[i:])
+			break
+		}
+
+		tb.text(src[i : i+lt])
+		i += lt
+
+		rest := src[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			if end := strings.Index(rest, "-->"); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(src)
+			}
+			continue
+		case strings.HasPrefix(rest, "<![CDATA["):
+			end := strings.Index(rest, "]]>")
+			if end < 0 {
+				end = len(rest)
+			}
+			tb.text(html.EscapeString(rest[9:end]))
+			i += end + 3
+			continue
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			if end := strings.IndexByte(rest, '>'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(src)
+			}
+			continue
+		}
+
+		closing := strings.HasPrefix(rest, "</")
+		name := tagNamePattern.FindString(strings.TrimPrefix(rest[1:], "/"))
+		end := tagEnd(src, i)
+
+		if name == "" || end < 0 {
+			// stray bracket is text
+			tb.text("&lt;")
+			i++
+			continue
+		}
+
+		tag := strings.ToLower(name)
+		raw := src[i+1 : end]
+		i = end + 1
+
+		if closing {
+			if tag == "p" && !tb.closeTo("p", blockTags) {
+				// stray closing paragraph is an empty paragraph
+				continue
+			}
+			tb.closeTo(tag, nil)
+			continue
+		}
+
+		if droppedTags[tag] {
+			if !strings.HasSuffix(raw, "/") {
+				closeTag := "</" + tag
+				if end := strings.Index(strings.ToLower(src[i:]), closeTag); end >= 0 {
+					i += end
+					if gt := strings.IndexByte(src[i:], '>'); gt >= 0 {
+						i += gt + 1
+					}
+				} else {
+					i = len(src)
+				}
+			}
+			continue
+		}
+
+		tb.open(tag, parseAttrs(raw[len(name):]))
+	}
+
+	return root
+}
+
+// converter turns element tree into Editor.js blocks
+type converter struct {
+	blocks []editorjs.Block
+	images []*node
+
+	// autop treats blank lines of loose text as paragraph breaks
+	// and single line breaks as <br>, as WordPress does
+	autop bool
+}
+
+// add appends block of given type
+func (c *converter) add(blockType string, data map[string]interface{}) {
+	c.blocks = append(c.blocks, editorjs.Block{
+		Id:   editorjs.NewBlockId(),
+		Type: blockType,
+		Data: data,
+	})
+}
+
+// textContent returns unescaped text of node
+func textContent(n *node) string {
+	if n.isText() {
+		return html.UnescapeString(n.text)
+	}
+
+	var sb strings.Builder
+	for _, child := range n.children {
+		if child.tag == "br" {
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteString(textContent(child))
+		}
+	}
+	return sb.String()
+}
+
+// writeInline writes Editor.js inline HTML of node, images are collected
+// to be added as separate blocks
+func (c *converter) writeInline(out *strings.Builder, n *node, breaks bool) {
+	if n.isText() {
+		text := html.EscapeString(html.UnescapeString(n.text))
+		if breaks {
+			text = lineBreakPattern.ReplaceAllString(text, "<br>")
+		}
+		out.WriteString(spacePattern.ReplaceAllString(text, " "))
+		return
+	}
+
+	switch n.tag {
+	case "br":
+		out.WriteString("<br>")
+		return
+	case "img":
+		c.images = append(c.images, n)
+		return
+	case "a":
+		if href := render.SafeUrl(n.attrs["href"]); href != "" {
+			var inner strings.Builder
+			c.writeInlineChildren(&inner, n, breaks)
+
+			if strings.TrimSpace(inner.String()) != "" {
+				out.WriteString(`<a href="` + html.EscapeString(href) + `">` + inner.String() + "</a>")
+			}
+			return
+		}
+	}
+
+	if open, ok := inlineTags[n.tag]; ok {
+		var inner strings.Builder
+		c.writeInlineChildren(&inner, n, breaks)
+
+		if text := inner.String(); strings.TrimSpace(text) != "" {
+			closeTag := "</" + strings.Trim(strings.Fields(open)[0], "<>") + ">"
+			out.WriteString(open + text + closeTag)
+		} else {
+			out.WriteString(text)
+		}
+		return
+	}
+
+	c.writeInlineChildren(out, n, breaks)
+}
+
+// writeInlineChildren writes inline HTML of every child of node,
+// block children are separated by line breaks
+func (c *converter) writeInlineChildren(out *strings.Builder, n *node, breaks bool) {
+	for i, child := range n.children {
+		if i > 0 && blockTags[child.tag] && out.Len() > 0 {
+			out.WriteString("<br>")
+		}
+		c.writeInline(out, child, breaks)
+	}
+}
+
+// cleanInline trims whitespace and leading or trailing line breaks
+func cleanInline(text string) string {
+	for {
+		trimmed := strings.TrimSpace(text)
+		trimmed = strings.TrimPrefix(strings.TrimSuffix(trimmed, "<br>"), "<br>")
+
+		if trimmed == text {
+			return text
+		}
+		text = trimmed
+	}
+}
+
+// inlineOf returns cleaned inline HTML of node children
+func (c *converter) inlineOf(n *node) string {
+	var sb strings.Builder
+	c.writeInlineChildren(&sb, n, false)
+	return cleanInline(sb.String())
+}
+
+// flushImages adds image blocks of images collected from inline content
+func (c *converter) flushImages(caption string) {
+	images := c.images
+	c.images = nil
+
+	for _, img := range images {
+		src := strings.TrimSpace(img.attrs["src"])
+		if src == "" {
+			continue
+		}
+
+		text := caption
+		if text == "" {
+			text = html.EscapeString(img.attrs["alt"])
+		}
+
+		c.add("image", map[string]interface{}{
+			"file":           map[string]interface{}{"url": src},
+			"caption":        text,
+			"withBorder":     false,
+			"withBackground": false,
+			"stretched":      false,
+		})
+	}
+}
+
+// paragraph adds paragraph block of inline HTML followed by its images
+func (c *converter) paragraph(text string) {
+	if text = cleanInline(text); strings.TrimSpace(editorjs.StripTags(text)) != "" {
+		c.add("paragraph", map[string]interface{}{"text": text})
+	}
+	c.flushImages("")
+}
+
+// header adds header block, clamping level to levels of the editor
+func (c *converter) header(n *node) {
+	level := int(n.tag[1] - '0')
+
+	if level < minHeaderLevel {
+		level = minHeaderLevel
+	} else if level > maxHeaderLevel {
+		level = maxHeaderLevel
+	}
+
+	if text := c.inlineOf(n); text != "" {
+		c.add("header", map[string]interface{}{"text": text, "level": level})
+	}
+	c.flushImages("")
+}
+
+// listItems returns inline HTML of list items, nested lists are flattened
+// since the post editor keeps flat lists
+func (c *converter) listItems(list *node) (items []string) {
+	for _, li := range list.children {
+		if li.tag != "li" {
+			continue
+		}
+
+		var (
+			sb     strings.Builder
+			nested []string
+		)
+
+		for _, child := range li.children {
+			if child.tag == "ul" || child.tag == "ol" {
+				nested = append(nested, c.listItems(child)...)
+			} else {
+				c.writeInline(&sb, child, false)
+			}
+		}
+
+		if text := cleanInline(sb.String()); text != "" {
+			items = append(items, text)
+		}
+		items = append(items, nested...)
+	}
+
+	return items
+}
+
+// list adds list block
+func (c *converter) list(n *node) {
+	style := "unordered"
+	if n.tag == "ol" {
+		style = "ordered"
+	}
+
+	if items := c.listItems(n); len(items) > 0 {
+		c.add("list", map[string]interface{}{"style": style, "items": items})
+	}
+	c.flushImages("")
+}
+
+// tableRows returns rows of table and whether first row is heading
+func (c *converter) tableRows(n *node, rows *[][]string, heading *bool) {
+	for _, child := range n.children {
+		switch child.tag {
+		case "thead", "tbody", "tfoot":
+			if child.tag == "thead" && len(*rows) == 0 {
+				*heading = true
+			}
+			c.tableRows(child, rows, heading)
+		case "tr":
+			var (
+				row     []string
+				allHead = true
+			)
+
+			for _, cell := range child.children {
+				if cell.tag == "td" || cell.tag == "th" {
+					row = append(row, c.inlineOf(cell))
+					allHead = allHead && cell.tag == "th"
+				}
+			}
+
+			if len(row) > 0 {
+				if len(*rows) == 0 && allHead {
+					*heading = true
+				}
+				*rows = append(*rows, row)
+			}
+		}
+	}
+}
+
+// table adds table block, short rows are padded to widest row
+func (c *converter) table(n *node) {
+	var (
+		rows    [][]string
+		heading bool
+		width   int
+	)
+
+	c.tableRows(n, &rows, &heading)
+
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	content := make([]interface{}, len(rows))
+	for i, row := range rows {
+		cells := make([]interface{}, width)
+		for j := range cells {
+			cells[j] = ""
+			if j < len(row) {
+				cells[j] = row[j]
+			}
+		}
+		content[i] = cells
+	}
+
+	if len(rows) > 0 {
+		c.add("table", map[string]interface{}{"withHeadings": heading, "content": content})
+	}
+	c.flushImages("")
+}
+
+// findChild returns first descendant element of given tag
+func findChild(n *node, tag string) *node {
+	for _, child := range n.children {
+		if child.tag == tag {
+			return child
+		}
+		if found := findChild(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// figure adds image of figure with its caption, other figures such as
+// wrapped tables and quotes are converted as containers
+func (c *converter) figure(n *node) {
+	img := findChild(n, "img")
+	if img == nil {
+		c.container(n, false)
+		return
+	}
+
+	caption := ""
+	if fc := findChild(n, "figcaption"); fc != nil {
+		caption = c.inlineOf(fc)
+		c.images = nil
+	}
+
+	c.images = append(c.images, img)
+	c.flushImages(caption)
+}
+
+// quote adds quote block, cite or footer becomes its caption
+func (c *converter) quote(n *node) {
+	var (
+		sb      strings.Builder
+		caption string
+	)
+
+	for _, child := range n.children {
+		if child.tag == "cite" || child.tag == "footer" {
+			caption = c.inlineOf(child)
+			continue
+		}
+
+		if blockTags[child.tag] && sb.Len() > 0 {
+			sb.WriteString("<br>")
+		}
+		c.writeInline(&sb, child, false)
+	}
+
+	if text := cleanInline(sb.String()); text != "" {
+		c.add("quote", map[string]interface{}{"text": text, "caption": caption, "alignment": "left"})
+	}
+	c.flushImages("")
+}
+
+// block converts block element
+func (c *converter) block(n *node) {
+	switch n.tag {
+	case "p", "dt", "dd", "summary", "figcaption":
+		c.paragraph(c.inlineOf(n))
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.header(n)
+	case "ul", "ol":
+		c.list(n)
+	case "pre":
+		if code := strings.Trim(textContent(n), "\n"); strings.TrimSpace(code) != "" {
+			c.add("code", map[string]interface{}{"code": code})
+		}
+	case "blockquote":
+		c.quote(n)
+	case "figure":
+		c.figure(n)
+	case "table":
+		c.table(n)
+	case "hr":
+		c.add("delimiter", map[string]interface{}{})
+	default:
+		c.container(n, false)
+	}
+}
+
+// container converts children of element, runs of loose inline content
+// become paragraphs
+func (c *converter) container(n *node, root bool) {
+	var (
+		run   strings.Builder
+		autop = c.autop && root
+	)
+
+	flush := func() {
+		c.paragraph(run.String())
+		run.Reset()
+	}
+
+	for _, child := range n.children {
+		switch {
+		case child.tag == "img":
+			flush()
+			c.images = append(c.images, child)
+			c.flushImages("")
+		case child.tag == "table" || blockTags[child.tag]:
+			flush()
+			c.block(child)
+		case child.isText() && autop:
+			parts := blankLinePattern.Split(child.text, -1)
+			for i, part := range parts {
+				if i > 0 {
+					flush()
+				}
+				c.writeInline(&run, &node{text: part}, true)
+			}
+		default:
+			c.writeInline(&run, child, autop)
+		}
+	}
+
+	flush()
+}
+
+// HTMLToDocument converts HTML post content into Editor.js document,
+// unsupported elements such as embeds and scripts are dropped
+// With autop loose text is split into paragraphs on blank lines as
+// WordPress does for content written in the classic editor
+func HTMLToDocument(src string, autop bool) *editorjs.Document {
+	c := &converter{autop: autop}
+	c.container(parseHTML(strings.ReplaceAll(src, "\r\n", "\n")), true)
+
+	return &editorjs.Document{Blocks: append([]editorjs.Block{}, c.blocks...)}
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// wxrTimeLayout is layout of post dates in WXR files
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+type wxrCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Encoded       []wxrEncoded  `xml:"encoded"`
+	PostId        string        `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostDateGmt   string        `xml:"post_date_gmt"`
+	PostName      string        `xml:"post_name"`
+	Status        string        `xml:"status"`
+	PostType      string        `xml:"post_type"`
+	AttachmentUrl string        `xml:"attachment_url"`
+	Categories    []wxrCategory `xml:"category"`
+	Meta          []wxrMeta     `xml:"postmeta"`
+}
+
+type wxrFile struct {
+	Items []wxrItem `xml:"channel>item"`
+}
+
+// encoded returns content of encoded element whose namespace contains given module name
+func (item *wxrItem) encoded(module string) string {
+	for _, enc := range item.Encoded {
+		if strings.Contains(enc.XMLName.Space, module) {
+			return enc.Value
+		}
+	}
+	return ""
+}
+
+// meta returns value of post meta by given key
+func (item *wxrItem) meta(key string) string {
+	for _, m := range item.Meta {
+		if m.Key == key {
+			return strings.TrimSpace(m.Value)
+		}
+	}
+	return ""
+}
+
+// publishedAt returns post date, GMT date is preferred when set
+func (item *wxrItem) publishedAt() time.Time {
+	if t, err := time.Parse(wxrTimeLayout, strings.TrimSpace(item.PostDateGmt)); err == nil {
+		return t
+	}
+	if t, err := time.ParseInLocation(wxrTimeLayout, strings.TrimSpace(item.PostDate), time.Local); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// ParseWXR reads posts and pages of WordPress WXR export,
+// attachments are only used to resolve featured images
+func ParseWXR(data []byte) ([]Entry, error) {
+	var (
+		file        wxrFile
+		attachments = make(map[string]string)
+		entries     []Entry
+	)
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid WXR file: %w", err)
+	}
+
+	for _, item := range file.Items {
+		if item.PostType == "attachment" {
+			attachments[strings.TrimSpace(item.PostId)] = strings.TrimSpace(item.AttachmentUrl)
+		}
+	}
+
+	for i := range file.Items {
+		item := &file.Items[i]
+
+		if item.PostType != EntryPost && item.PostType != EntryPage {
+			continue
+		}
+
+		entry := Entry{
+			SourceId:     strings.TrimSpace(item.PostId),
+			Type:         item.PostType,
+			Title:        strings.TrimSpace(html.UnescapeString(item.Title)),
+			Excerpt:      strings.TrimSpace(item.encoded("excerpt")),
+			HTML:         item.encoded("content"),
+			Status:       item.Status,
+			Published:    item.Status == "publish",
+			PublishedAt:  item.publishedAt(),
+			FeatureImage: attachments[item.meta("_thumbnail_id")],
+		}
+		entry.Slug = entrySlug(item.PostName, entry.Title)
+
+		for _, cat := range item.Categories {
+			name := strings.TrimSpace(cat.Name)
+
+			switch cat.Domain {
+			case "category":
+				if cat.Nicename != "uncategorized" {
+					entry.Categories = append(entry.Categories, cat.Nicename, name)
+				}
+			case "post_tag":
+				entry.Tags = append(entry.Tags, name)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
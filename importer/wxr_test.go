@@ -0,0 +1,142 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testWXR = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Blog</title>
+	<item>
+		<title>Hello &amp; welcome</title>
+		<content:encoded><![CDATA[<h2>Intro</h2>
+<p>First <strong>post</strong>.</p>
+<ul><li>one</li><li>two</li></ul>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short intro]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date>2024-01-02 12:00:00</wp:post_date>
+		<wp:post_date_gmt>2024-01-02 10:00:00</wp:post_date_gmt>
+		<wp:post_name>hello-welcome</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="golang"><![CDATA[Go]]></category>
+		<category domain="category" nicename="uncategorized"><![CDATA[Uncategorized]]></category>
+		<category domain="post_tag" nicename="tips"><![CDATA[Tips]]></category>
+		<wp:postmeta>
+			<wp:meta_key>_thumbnail_id</wp:meta_key>
+			<wp:meta_value>11</wp:meta_value>
+		</wp:postmeta>
+	</item>
+	<item>
+		<title>cover.png</title>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_type>attachment</wp:post_type>
+		<wp:attachment_url>https://example.com/cover.png</wp:attachment_url>
+	</item>
+	<item>
+		<title>Caf&#233; notes</title>
+		<content:encoded><![CDATA[Loose text
+
+Second paragraph]]></content:encoded>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_date>2024-01-03 09:30:00</wp:post_date>
+		<wp:post_date_gmt>0000-00-00 00:00:00</wp:post_date_gmt>
+		<wp:post_name>%c3%bcber-uns</wp:post_name>
+		<wp:status>draft</wp:status>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>Menu</title>
+		<wp:post_id>13</wp:post_id>
+		<wp:post_type>nav_menu_item</wp:post_type>
+	</item>
+</channel>
+</rss>
+`
+
+func TestParseWXR(t *testing.T) {
+	if source, err := Detect([]byte("\xef\xbb\xbf" + testWXR)); err != nil || source != SourceWordPress {
+		t.Fatalf("Detect() = %q, %v, want %q", source, err, SourceWordPress)
+	}
+
+	entries, err := Parse(SourceWordPress, []byte(testWXR))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Entry{
+		{
+			SourceId:     "10",
+			Type:         EntryPost,
+			Title:        "Hello & welcome",
+			Slug:         "hello-welcome",
+			Excerpt:      "Short intro",
+			HTML:         "<h2>Intro</h2>\n<p>First <strong>post</strong>.</p>\n<ul><li>one</li><li>two</li></ul>",
+			Published:    true,
+			Status:       "publish",
+			PublishedAt:  time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+			Categories:   []string{"golang", "Go"},
+			Tags:         []string{"Tips"},
+			FeatureImage: "https://example.com/cover.png",
+		},
+		{
+			SourceId:    "12",
+			Type:        EntryPage,
+			Title:       "Café notes",
+			Slug:        "uber-uns",
+			HTML:        "Loose text\n\nSecond paragraph",
+			Status:      "draft",
+			PublishedAt: time.Date(2024, 1, 3, 9, 30, 0, 0, time.Local),
+		},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("Parse() returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+
+	for i := range want {
+		if !entries[i].PublishedAt.Equal(want[i].PublishedAt) {
+			t.Errorf("entry %d published at %v, want %v", i, entries[i].PublishedAt, want[i].PublishedAt)
+		}
+		entries[i].PublishedAt = want[i].PublishedAt
+
+		if !reflect.DeepEqual(entries[i], want[i]) {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	tests := []struct {
+		name  string
+		html  string
+		autop bool
+		want  []string
+	}{
+		{"block elements", want[0].HTML, false, []string{"header", "paragraph", "list"}},
+		{"classic editor text", want[1].HTML, true, []string{"paragraph", "paragraph"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, block := range HTMLToDocument(tt.html, tt.autop).Blocks {
+				got = append(got, block.Type)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("block types = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWXRInvalid(t *testing.T) {
+	if _, err := ParseWXR([]byte("<rss><channel><item>")); err == nil {
+		t.Error("ParseWXR() of truncated file returned no error")
+	}
+}
@@ -0,0 +1,108 @@
+package indexer
+
+import (
+	"errors"
+	"io"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/util"
+)
+
+// AlgoliaIndexer writes records to Algolia "posts" index
+type AlgoliaIndexer struct{}
+
+// NewAlgoliaIndexer creates new instance of AlgoliaIndexer
+// util.InitAlgolia must be called before first use
+func NewAlgoliaIndexer() *AlgoliaIndexer {
+	return &AlgoliaIndexer{}
+}
+
+// SaveObject adds or replaces given record
+func (ai *AlgoliaIndexer) SaveObject(record *models.PostIndex) error {
+	_, err := util.PostIndex().SaveObject(record)
+	return err
+}
+
+// DeleteObject removes record by given object id
+func (ai *AlgoliaIndexer) DeleteObject(objectId string) error {
+	_, err := util.PostIndex().DeleteObject(objectId)
+	return err
+}
+
+// Batch applies list of save and delete operations
+func (ai *AlgoliaIndexer) Batch(ops []BatchOperation) error {
+	var operations []search.BatchOperation
+
+	if err := validateBatch(ops); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		switch op.Action {
+		case ActionSave:
+			operations = append(operations, search.BatchOperation{
+				Action: search.UpdateObject,
+				Body:   op.Record,
+			})
+
+		case ActionDelete:
+			operations = append(operations, search.BatchOperation{
+				Action: search.DeleteObject,
+				Body:   map[string]string{"objectID": op.ObjectId},
+			})
+
+		}
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	_, err := util.PostIndex().Batch(operations)
+	return err
+}
+
+// Search returns records matching given query
+func (ai *AlgoliaIndexer) Search(query string, limit int) (records []models.PostIndex, err error) {
+	var (
+		res  search.QueryRes
+		opts []interface{}
+	)
+
+	if limit > 0 {
+		opts = append(opts, opt.HitsPerPage(limit))
+	}
+
+	if res, err = util.PostIndex().Search(query, opts...); err != nil {
+		return nil, err
+	}
+
+	if err = res.UnmarshalHits(&records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Browse returns every record of index, following browse cursor across pages
+func (ai *AlgoliaIndexer) Browse() (records []models.PostIndex, err error) {
+	var it *search.ObjectIterator
+
+	if it, err = util.PostIndex().BrowseObjects(); err != nil {
+		return nil, err
+	}
+
+	for {
+		var record models.PostIndex
+
+		if _, err = it.Next(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
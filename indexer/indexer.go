@@ -0,0 +1,58 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/rajatxs/go-fconsole/models"
+)
+
+// Batch operation actions
+const (
+	ActionSave   = "save"
+	ActionDelete = "delete"
+)
+
+// BatchOperation describes single write within a batch
+// Record is required for save, ObjectId for delete
+type BatchOperation struct {
+	Action   string            `json:"action"`
+	ObjectId string            `json:"objectID"`
+	Record   *models.PostIndex `json:"record"`
+}
+
+// validateBatch checks every operation before any of them is applied
+func validateBatch(ops []BatchOperation) error {
+	for i, op := range ops {
+		switch op.Action {
+		case ActionSave:
+			if op.Record == nil || op.Record.ObjectId == "" {
+				return fmt.Errorf("batch operation %d: save requires record with object id", i)
+			}
+		case ActionDelete:
+			if op.ObjectId == "" {
+				return fmt.Errorf("batch operation %d: delete requires object id", i)
+			}
+		default:
+			return fmt.Errorf("batch operation %d: unknown action '%s'", i, op.Action)
+		}
+	}
+	return nil
+}
+
+// SearchIndexer describes post search index backend
+type SearchIndexer interface {
+	// SaveObject adds or replaces given record
+	SaveObject(record *models.PostIndex) error
+
+	// DeleteObject removes record by given object id
+	DeleteObject(objectId string) error
+
+	// Batch applies list of save and delete operations
+	Batch(ops []BatchOperation) error
+
+	// Search returns records matching given query, limit 0 means backend default
+	Search(query string, limit int) ([]models.PostIndex, error)
+
+	// Browse returns every record of index
+	Browse() ([]models.PostIndex, error)
+}
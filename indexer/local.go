@@ -0,0 +1,262 @@
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/rajatxs/go-fconsole/models"
+)
+
+// default number of hits returned by local search
+const localHitsPerPage = 20
+
+// field weights used to rank local search results
+var localFieldWeights = map[string]int{
+	"name":     4,
+	"tags":     3,
+	"topic":    2,
+	"author":   2,
+	"headings": 2,
+	"desc":     1,
+	"excerpt":  1,
+}
+
+// LocalIndexer keeps post records in a JSON file on disk and
+// serves full-text queries from an in-process inverted index
+type LocalIndexer struct {
+	mu      sync.RWMutex
+	file    string
+	records map[string]*models.PostIndex
+	terms   map[string]map[string]int
+}
+
+// NewLocalIndexer creates new instance of LocalIndexer stored under given directory
+func NewLocalIndexer(dir string) (*LocalIndexer, error) {
+	li := &LocalIndexer{
+		file:    filepath.Join(dir, "posts.json"),
+		records: make(map[string]*models.PostIndex),
+		terms:   make(map[string]map[string]int),
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := li.load(); err != nil {
+		return nil, err
+	}
+
+	return li, nil
+}
+
+// tokenize splits text into lowercase words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// recordTerms returns weighted term frequencies of given record
+func recordTerms(record *models.PostIndex) map[string]int {
+	terms := make(map[string]int)
+
+	add := func(text string, weight int) {
+		for _, token := range tokenize(text) {
+			terms[token] += weight
+		}
+	}
+
+	add(record.Name, localFieldWeights["name"])
+	add(strings.Join(record.Tags, " "), localFieldWeights["tags"])
+	add(record.Topic, localFieldWeights["topic"])
+	add(record.Author, localFieldWeights["author"])
+	add(strings.Join(record.Headings, " "), localFieldWeights["headings"])
+	add(record.Desc, localFieldWeights["desc"])
+	add(record.Excerpt, localFieldWeights["excerpt"])
+	return terms
+}
+
+// load reads records from index file, missing file means empty index
+func (li *LocalIndexer) load() error {
+	var records []*models.PostIndex
+
+	data, err := os.ReadFile(li.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("corrupted local index '%s': %w", li.file, err)
+	}
+
+	for _, record := range records {
+		li.put(record)
+	}
+	return nil
+}
+
+// persist writes all records to index file
+func (li *LocalIndexer) persist() error {
+	records := make([]*models.PostIndex, 0, len(li.records))
+
+	for _, record := range li.records {
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ObjectId < records[j].ObjectId
+	})
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to temporary file first so a crash never leaves half written index
+	tmp := li.file + ".tmp"
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, li.file)
+}
+
+// put adds record to in-memory index
+func (li *LocalIndexer) put(record *models.PostIndex) {
+	li.remove(record.ObjectId)
+	li.records[record.ObjectId] = record
+	li.terms[record.ObjectId] = recordTerms(record)
+}
+
+// remove drops record from in-memory index
+func (li *LocalIndexer) remove(objectId string) {
+	delete(li.records, objectId)
+	delete(li.terms, objectId)
+}
+
+// SaveObject adds or replaces given record
+func (li *LocalIndexer) SaveObject(record *models.PostIndex) error {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+
+	li.put(record)
+	return li.persist()
+}
+
+// DeleteObject removes record by given object id
+func (li *LocalIndexer) DeleteObject(objectId string) error {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+
+	li.remove(objectId)
+	return li.persist()
+}
+
+// Batch applies list of save and delete operations
+func (li *LocalIndexer) Batch(ops []BatchOperation) error {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+
+	if err := validateBatch(ops); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if op.Action == ActionSave {
+			li.put(op.Record)
+		} else {
+			li.remove(op.ObjectId)
+		}
+	}
+
+	return li.persist()
+}
+
+// Browse returns every record ordered by object id
+func (li *LocalIndexer) Browse() ([]models.PostIndex, error) {
+	li.mu.RLock()
+	defer li.mu.RUnlock()
+
+	records := make([]models.PostIndex, 0, len(li.records))
+	for _, record := range li.records {
+		records = append(records, *record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ObjectId < records[j].ObjectId
+	})
+	return records, nil
+}
+
+// Search returns records matching all words of given query,
+// the last word is matched as prefix to support search-as-you-type
+func (li *LocalIndexer) Search(query string, limit int) ([]models.PostIndex, error) {
+	type hit struct {
+		record *models.PostIndex
+		score  int
+	}
+
+	var (
+		hits   []hit
+		tokens = tokenize(query)
+	)
+
+	if limit <= 0 {
+		limit = localHitsPerPage
+	}
+
+	li.mu.RLock()
+	for id, terms := range li.terms {
+		score := 0
+
+		for i, token := range tokens {
+			matched := terms[token]
+
+			if matched == 0 && i == len(tokens)-1 {
+				for term, weight := range terms {
+					if strings.HasPrefix(term, token) {
+						matched += weight
+					}
+				}
+			}
+
+			if matched == 0 {
+				score = -1
+				break
+			}
+			score += matched
+		}
+
+		if score >= 0 {
+			hits = append(hits, hit{record: li.records[id], score: score})
+		}
+	}
+	li.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].record.UpdatedAt.After(hits[j].record.UpdatedAt)
+	})
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	records := make([]models.PostIndex, len(hits))
+	for i, h := range hits {
+		records[i] = *h.record
+	}
+
+	return records, nil
+}
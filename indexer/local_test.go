@@ -0,0 +1,215 @@
+package indexer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+)
+
+// newTestIndexer returns local indexer stored in temporary directory
+func newTestIndexer(t *testing.T) (*LocalIndexer, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	li, err := NewLocalIndexer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return li, dir
+}
+
+// objectIds returns object ids of given records in order
+func objectIds(records []models.PostIndex) []string {
+	ids := []string{}
+	for _, record := range records {
+		ids = append(ids, record.ObjectId)
+	}
+	return ids
+}
+
+func TestLocalSaveDelete(t *testing.T) {
+	li, _ := newTestIndexer(t)
+
+	if err := li.SaveObject(&models.PostIndex{ObjectId: "a", Name: "Go generics"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := li.SaveObject(&models.PostIndex{ObjectId: "b", Name: "Rust traits"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// saving same object again replaces its record and terms
+	if err := li.SaveObject(&models.PostIndex{ObjectId: "a", Name: "Go channels"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if hits, _ := li.Search("generics", 0); len(hits) != 0 {
+		t.Errorf("replaced record still matches old name: %v", objectIds(hits))
+	}
+
+	if err := li.DeleteObject("b"); err != nil {
+		t.Fatal(err)
+	}
+
+	records, _ := li.Browse()
+	if got := objectIds(records); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Browse() = %v, want [a]", got)
+	}
+	if records[0].Name != "Go channels" {
+		t.Errorf("record name = %q, want %q", records[0].Name, "Go channels")
+	}
+}
+
+func TestLocalBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     []BatchOperation
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "save and delete",
+			ops: []BatchOperation{
+				{Action: ActionSave, Record: &models.PostIndex{ObjectId: "c", Name: "New"}},
+				{Action: ActionDelete, ObjectId: "a"},
+			},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "save without record",
+			ops: []BatchOperation{
+				{Action: ActionDelete, ObjectId: "a"},
+				{Action: ActionSave, ObjectId: "c"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "save without object id",
+			ops:     []BatchOperation{{Action: ActionSave, Record: &models.PostIndex{Name: "New"}}},
+			wantErr: true,
+		},
+		{
+			name:    "delete without object id",
+			ops:     []BatchOperation{{Action: ActionDelete}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			ops:     []BatchOperation{{Action: "update", ObjectId: "a"}},
+			wantErr: true,
+		},
+		{
+			name: "empty batch",
+			want: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			li, _ := newTestIndexer(t)
+			li.SaveObject(&models.PostIndex{ObjectId: "a", Name: "First"})
+			li.SaveObject(&models.PostIndex{ObjectId: "b", Name: "Second"})
+
+			err := li.Batch(tt.ops)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Batch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// rejected batch leaves index untouched
+			want := tt.want
+			if tt.wantErr {
+				want = []string{"a", "b"}
+			}
+
+			records, _ := li.Browse()
+			if got := objectIds(records); !reflect.DeepEqual(got, want) {
+				t.Errorf("Browse() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLocalSearch(t *testing.T) {
+	li, _ := newTestIndexer(t)
+	now := time.Now()
+
+	records := []*models.PostIndex{
+		{ObjectId: "title", Name: "Concurrency patterns", Excerpt: "threads", UpdatedAt: now},
+		{ObjectId: "excerpt", Name: "Threads", Excerpt: "about concurrency", UpdatedAt: now},
+		{ObjectId: "tagged", Name: "Tips", Tags: []string{"golang"}, Topic: "go", UpdatedAt: now.Add(-time.Hour)},
+		{ObjectId: "newer", Name: "Tips", Tags: []string{"golang"}, Topic: "go", UpdatedAt: now},
+		{ObjectId: "heading", Name: "Guide", Headings: []string{"Installing toolchain"}, UpdatedAt: now},
+	}
+	for _, record := range records {
+		if err := li.SaveObject(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{"name outranks excerpt", "concurrency", 0, []string{"title", "excerpt"}},
+		{"case insensitive", "CONCURRENCY", 0, []string{"title", "excerpt"}},
+		{"last word is prefix", "concur", 0, []string{"title", "excerpt"}},
+		{"every word must match", "concurrency patterns", 0, []string{"title"}},
+		{"only last word is prefix", "concur patterns", 0, []string{}},
+		{"newer record first on same score", "golang", 0, []string{"newer", "tagged"}},
+		{"headings", "toolchain", 0, []string{"heading"}},
+		{"limit", "golang", 1, []string{"newer"}},
+		{"no match", "python", 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := li.Search(tt.query, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := objectIds(hits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalPersist(t *testing.T) {
+	li, dir := newTestIndexer(t)
+	saved := &models.PostIndex{
+		ObjectId:  "a",
+		Schema:    2,
+		Name:      "Persisted post",
+		Tags:      []string{"disk"},
+		Headings:  []string{"Intro"},
+		WordCount: 120,
+		License:   "CC-BY-4.0",
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	if err := li.Batch([]BatchOperation{
+		{Action: ActionSave, Record: saved},
+		{Action: ActionSave, Record: &models.PostIndex{ObjectId: "b", Name: "Removed"}},
+		{Action: ActionDelete, ObjectId: "b"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewLocalIndexer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, _ := reloaded.Browse()
+	if len(records) != 1 || !reflect.DeepEqual(records[0], *saved) {
+		t.Fatalf("reloaded records = %+v, want [%+v]", records, *saved)
+	}
+
+	if hits, _ := reloaded.Search("persisted", 0); len(hits) != 1 {
+		t.Errorf("reloaded index does not serve search, got %v", objectIds(hits))
+	}
+}
@@ -0,0 +1,58 @@
+package indexer
+
+import (
+	"sync"
+
+	"github.com/rajatxs/go-fconsole/models"
+)
+
+// SwitchIndexer forwards calls to an indexer which can be replaced while in use,
+// calls running meanwhile finish on the previous one
+type SwitchIndexer struct {
+	mu      sync.RWMutex
+	current SearchIndexer
+}
+
+// NewSwitchIndexer creates new instance of SwitchIndexer using given indexer
+func NewSwitchIndexer(idx SearchIndexer) *SwitchIndexer {
+	return &SwitchIndexer{current: idx}
+}
+
+// Use replaces indexer receiving later calls
+func (si *SwitchIndexer) Use(idx SearchIndexer) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.current = idx
+}
+
+// active returns indexer receiving calls
+func (si *SwitchIndexer) active() SearchIndexer {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.current
+}
+
+// SaveObject adds or replaces given record in active indexer
+func (si *SwitchIndexer) SaveObject(record *models.PostIndex) error {
+	return si.active().SaveObject(record)
+}
+
+// DeleteObject removes record by given object id from active indexer
+func (si *SwitchIndexer) DeleteObject(objectId string) error {
+	return si.active().DeleteObject(objectId)
+}
+
+// Batch applies list of save and delete operations to active indexer
+func (si *SwitchIndexer) Batch(ops []BatchOperation) error {
+	return si.active().Batch(ops)
+}
+
+// Search returns records of active indexer matching given query
+func (si *SwitchIndexer) Search(query string, limit int) ([]models.PostIndex, error) {
+	return si.active().Search(query, limit)
+}
+
+// Browse returns every record of active indexer
+func (si *SwitchIndexer) Browse() ([]models.PostIndex, error) {
+	return si.active().Browse()
+}
@@ -3,11 +3,10 @@ package main
 import (
 	"context"
 	"embed"
-	"log"
-	"os"
+	"flag"
+	"net/http"
 
-	"github.com/rajatxs/go-fconsole/config"
-	"github.com/rajatxs/go-fconsole/services"
+	"github.com/rajatxs/go-fconsole/bootstrap"
 	"github.com/rajatxs/go-fconsole/util"
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -18,38 +17,27 @@ import (
 //go:embed all:frontend/dist
 var assets embed.FS
 
-func preconfig() error {
-	var err error
-
-	// parse default config
-	if err = config.Parse(); err != nil {
-		log.Fatal(err)
+func runApp() error {
+	// Create service instances
+	svc, err := bootstrap.NewServices()
+	if err != nil {
+		return err
 	}
 
-	// create the root configuration directory if it does not exist
-	configDir := config.RootDir()
-	_, err = os.Stat(configDir)
+	// Create an instance of the app structure
+	app := NewApp(svc)
 
-	if os.IsNotExist(err) {
-		if err = os.Mkdir(configDir, 0755); err != nil {
-			log.Fatal(err)
-		}
+	// Serve locally stored images through asset server
+	assetServer := &assetserver.Options{
+		Assets: assets,
 	}
 
-	util.InitLogger()
-	return err
-}
-
-func runApp() error {
-	// Create an instance of the app structure
-	app := NewApp()
-
-	// Create service instances
-	postService := services.NewPostService()
-	topicService := services.NewTopicService()
+	if handler, ok := svc.Media.(http.Handler); ok {
+		assetServer.Handler = handler
+	}
 
 	// Create application with options
-	err := wails.Run(&options.App{
+	err = wails.Run(&options.App{
 		Title:         "Console",
 		Width:         1300,
 		Height:        800,
@@ -59,19 +47,21 @@ func runApp() error {
 		Fullscreen:    false,
 		Frameless:     false,
 		StartHidden:   false,
-		AssetServer: &assetserver.Options{
-			Assets: assets,
-		},
+		AssetServer:   assetServer,
 		OnStartup: func(ctx context.Context) {
 			app.startup(ctx)
-			postService.Ctx = ctx
-			postService.TopicServiceRef = topicService
 		},
-		OnShutdown: app.terminate,
+		OnShutdown: func(ctx context.Context) {
+			app.terminate(ctx)
+		},
 		Bind: []interface{}{
 			app,
-			postService,
-			topicService,
+			svc.Post,
+			svc.Topic,
+			svc.Author,
+			svc.Draft,
+			svc.Backup,
+			svc.Audit,
 		},
 		Windows: &windows.Options{
 			WebviewIsTransparent: false,
@@ -84,6 +74,10 @@ func runApp() error {
 }
 
 func main() {
-	util.Attempt(preconfig())
+	profile := flag.String("profile", "", "config profile name")
+	flag.Parse()
+
+	// vault passphrase is asked by the window
+	util.Attempt(bootstrap.Preconfig(*profile, nil))
 	util.Attempt(runApp())
 }
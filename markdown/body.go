@@ -0,0 +1,58 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SourceKey is the body property holding Markdown source of markdown posts
+const SourceKey = "source"
+
+// Body returns stored post body of Markdown source
+func Body(source string) bson.M {
+	return bson.M{SourceKey: source}
+}
+
+// Source returns Markdown source of stored post body
+func Source(body bson.M) (string, error) {
+	source, ok := body[SourceKey].(string)
+	if !ok {
+		return "", fmt.Errorf("markdown body must hold '%s' string", SourceKey)
+	}
+	return source, nil
+}
+
+// Validate checks that Markdown source can be stored as post body
+func Validate(source string) error {
+	if !utf8.ValidString(source) {
+		return errors.New("markdown source is not valid UTF-8")
+	}
+
+	if strings.ContainsRune(source, 0) {
+		return errors.New("markdown source contains NUL character")
+	}
+
+	// an unclosed fence swallows the rest of the post
+	var fence string
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence == "" {
+			if m := fencePattern.FindStringSubmatch(line); m != nil {
+				fence = m[1]
+			}
+		} else if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			fence = ""
+		}
+	}
+
+	if fence != "" {
+		return errors.New("markdown source has unclosed code fence")
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+)
+
+// blockStartPattern matches text that would otherwise start a Markdown block
+var blockStartPattern = regexp.MustCompile(`^(#|>|[-+=|]|\d+[.)])`)
+
+// escapeLineStart escapes leading character of paragraph that has block meaning
+func escapeLineStart(text string) string {
+	if m := blockStartPattern.FindStringIndex(text); m != nil {
+		return text[:m[1]-1] + `\` + text[m[1]-1:]
+	}
+	return text
+}
+
+// writeListItems writes Markdown list with nested items indented to content column
+func writeListItems(out *strings.Builder, items []editorjs.ListItem, ordered bool, indent string) {
+	for i, item := range items {
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(i+1) + ". "
+		}
+
+		out.WriteString(indent + marker + escapeLineStart(inlineToMarkdown(item.Content, false)) + "\n")
+
+		if len(item.Items) > 0 {
+			writeListItems(out, item.Items, ordered, indent+strings.Repeat(" ", len(marker)))
+		}
+	}
+}
+
+// writeTableRow writes single pipe table row
+func writeTableRow(out *strings.Builder, cells []string) {
+	out.WriteString("|")
+	for _, cell := range cells {
+		out.WriteString(" " + cell + " |")
+	}
+	out.WriteString("\n")
+}
+
+// writeBlock writes Markdown of single block, returns false for blocks
+// without Markdown form
+func writeBlock(out *strings.Builder, block *editorjs.Block) bool {
+	switch block.Type {
+	case "header":
+		level := block.Int("level")
+		if level < 1 || level > 6 {
+			level = 2
+		}
+		out.WriteString(strings.Repeat("#", level) + " " + inlineToMarkdown(block.String("text"), false) + "\n")
+
+	case "paragraph":
+		text := strings.TrimSpace(inlineToMarkdown(block.String("text"), false))
+		if text == "" {
+			return false
+		}
+		out.WriteString(escapeLineStart(text) + "\n")
+
+	case "list":
+		items := block.ListItems()
+		if len(items) == 0 {
+			return false
+		}
+		writeListItems(out, items, block.String("style") == "ordered", "")
+
+	case "code":
+		code := strings.TrimRight(block.String("code"), "\n")
+		fence := codeFence(code, 3)
+		out.WriteString(fence + "\n" + code + "\n" + fence + "\n")
+
+	case "image":
+		url := block.ImageUrl()
+		if url == "" {
+			return false
+		}
+		alt := inlineToMarkdown(block.String("caption"), false)
+		out.WriteString("![" + alt + "](" + strings.ReplaceAll(url, " ", "%20") + ")\n")
+
+	case "table":
+		rows := block.TableContent()
+		if len(rows) == 0 {
+			return false
+		}
+
+		cells := func(row []string) []string {
+			res := make([]string, len(row))
+			for i, cell := range row {
+				res[i] = inlineToMarkdown(cell, true)
+			}
+			return res
+		}
+
+		// pipe tables always need a header row, it stays empty for tables without headings
+		header := make([]string, len(rows[0]))
+		if block.Bool("withHeadings") {
+			header = cells(rows[0])
+			rows = rows[1:]
+		}
+
+		delim := make([]string, len(header))
+		for i := range delim {
+			delim[i] = "---"
+		}
+
+		writeTableRow(out, header)
+		writeTableRow(out, delim)
+		for _, row := range rows {
+			writeTableRow(out, cells(row))
+		}
+
+	case "warning":
+		out.WriteString("> [!WARNING]\n")
+		if title := inlineToMarkdown(block.String("title"), false); strings.TrimSpace(title) != "" {
+			out.WriteString("> **" + strings.TrimSpace(title) + "**\n")
+		}
+		if message := inlineToMarkdown(block.String("message"), false); strings.TrimSpace(message) != "" {
+			out.WriteString("> " + strings.TrimSpace(message) + "\n")
+		}
+
+	case "quote":
+		out.WriteString("> " + inlineToMarkdown(block.String("text"), false) + "\n")
+
+	case "delimiter":
+		out.WriteString("---\n")
+
+	default:
+		text := strings.TrimSpace(inlineToMarkdown(block.String("text"), false))
+		if text == "" {
+			return false
+		}
+		out.WriteString(escapeLineStart(text) + "\n")
+	}
+
+	return true
+}
+
+// FromDocument returns CommonMark source of Editor.js document
+// Tables and warnings use GitHub pipe table and alert syntax
+func FromDocument(doc *editorjs.Document) string {
+	var out strings.Builder
+
+	for i := range doc.Blocks {
+		var block strings.Builder
+
+		if !writeBlock(&block, &doc.Blocks[i]) {
+			continue
+		}
+
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(block.String())
+	}
+
+	return out.String()
+}
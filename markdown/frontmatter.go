@@ -0,0 +1,204 @@
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// frontMatterDelim opens and closes YAML front matter
+const frontMatterDelim = "---"
+
+// FrontMatter holds post fields kept in YAML front matter of Markdown file
+type FrontMatter struct {
+	Title   string
+	Slug    string
+	Desc    string
+	Topic   string
+	License string
+	Tags    []string
+}
+
+// quote returns YAML double-quoted scalar
+func quote(value string) string {
+	raw, _ := json.Marshal(value)
+	return string(raw)
+}
+
+// String returns front matter as YAML block including delimiters
+func (fm *FrontMatter) String() string {
+	var out strings.Builder
+
+	tags := make([]string, len(fm.Tags))
+	for i, tag := range fm.Tags {
+		tags[i] = quote(tag)
+	}
+
+	out.WriteString(frontMatterDelim + "\n")
+	out.WriteString("title: " + quote(fm.Title) + "\n")
+	out.WriteString("slug: " + quote(fm.Slug) + "\n")
+	out.WriteString("desc: " + quote(fm.Desc) + "\n")
+	out.WriteString("topic: " + quote(fm.Topic) + "\n")
+	out.WriteString("tags: [" + strings.Join(tags, ", ") + "]\n")
+	out.WriteString("license: " + quote(fm.License) + "\n")
+	out.WriteString(frontMatterDelim + "\n")
+
+	return out.String()
+}
+
+// unquote returns value of plain, single-quoted or double-quoted YAML scalar
+func unquote(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		var value string
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return "", fmt.Errorf("invalid quoted value %s", raw)
+		}
+		return value, nil
+
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", fmt.Errorf("invalid quoted value %s", raw)
+		}
+		return strings.ReplaceAll(raw[1:len(raw)-1], "''", "'"), nil
+
+	default:
+		// strip trailing comment of plain scalar
+		if i := strings.Index(raw, " #"); i >= 0 {
+			raw = strings.TrimSpace(raw[:i])
+		}
+		return raw, nil
+	}
+}
+
+// splitFlowList splits items of YAML flow sequence, respecting quotes
+func splitFlowList(raw string) (items []string) {
+	var (
+		quoteChar rune
+		start     int
+	)
+
+	for i, c := range raw {
+		switch {
+		case quoteChar != 0:
+			if c == quoteChar {
+				quoteChar = 0
+			}
+		case c == '"' || c == '\'':
+			quoteChar = c
+		case c == ',':
+			items = append(items, raw[start:i])
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(raw[start:]); last != "" || len(items) > 0 {
+		items = append(items, raw[start:])
+	}
+
+	return items
+}
+
+// parseList returns values of flow or block YAML sequence
+func parseList(inline string, block []string) (values []string, err error) {
+	var items []string
+
+	if inline = strings.TrimSpace(inline); inline != "" {
+		if !strings.HasPrefix(inline, "[") || !strings.HasSuffix(inline, "]") {
+			// single plain value
+			items = []string{inline}
+		} else {
+			items = splitFlowList(inline[1 : len(inline)-1])
+		}
+	} else {
+		items = block
+	}
+
+	for _, item := range items {
+		value, err := unquote(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// ParseFrontMatter splits leading YAML front matter from Markdown source
+// Only flat keys and string lists used by posts are supported, unknown keys are ignored
+func ParseFrontMatter(source string) (fm FrontMatter, body string, err error) {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelim {
+		return fm, source, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if t := strings.TrimSpace(lines[i]); t == frontMatterDelim || t == "..." {
+			end = i
+			break
+		}
+	}
+
+	if end < 0 {
+		return fm, "", fmt.Errorf("front matter is not closed")
+	}
+
+	fields := lines[1:end]
+	for i := 0; i < len(fields); i++ {
+		line := fields[i]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		sep := strings.Index(line, ":")
+		if sep < 0 || strings.HasPrefix(line, " ") {
+			return fm, "", fmt.Errorf("invalid front matter line %d: %s", i+2, trimmed)
+		}
+
+		key, raw := strings.TrimSpace(line[:sep]), strings.TrimSpace(line[sep+1:])
+
+		// collect block sequence items
+		var block []string
+		for i+1 < len(fields) && strings.HasPrefix(strings.TrimSpace(fields[i+1]), "- ") {
+			i++
+			block = append(block, strings.TrimPrefix(strings.TrimSpace(fields[i]), "- "))
+		}
+
+		if key == "tags" {
+			if fm.Tags, err = parseList(raw, block); err != nil {
+				return fm, "", err
+			}
+			continue
+		}
+
+		value, err := unquote(raw)
+		if err != nil {
+			return fm, "", err
+		}
+
+		switch key {
+		case "title":
+			fm.Title = value
+		case "slug":
+			fm.Slug = value
+		case "desc", "description":
+			fm.Desc = value
+		case "topic":
+			fm.Topic = value
+		case "license":
+			fm.License = value
+		}
+	}
+
+	return fm, strings.Join(lines[end+1:], "\n"), nil
+}
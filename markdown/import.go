@@ -0,0 +1,459 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+)
+
+var (
+	fencePattern      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+	atxPattern        = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))??(?:[ \t]+#+)?[ \t]*$`)
+	thematicPattern   = regexp.MustCompile(`^ {0,3}((?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	setextPattern     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	quotePattern      = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	alertPattern      = regexp.MustCompile(`(?i)^\[!(warning|caution|important|note|tip)\]\s*$`)
+	boldLinePattern   = regexp.MustCompile(`^\*\*(.+)\*\*$|^__(.+)__$`)
+	listItemPattern   = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])(?:[ \t]+(.*))?$`)
+	tableDelimPattern = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+	imageLinePattern  = regexp.MustCompile(`^!\[((?:\\.|[^\]])*)\]\(<?([^)\s>]+)>?(?:\s+"[^"]*")?\)$`)
+)
+
+// header levels supported by the post editor
+const (
+	minHeaderLevel = 2
+	maxHeaderLevel = 5
+)
+
+// parser turns Markdown lines into Editor.js blocks
+type parser struct {
+	lines  []string
+	pos    int
+	blocks []editorjs.Block
+}
+
+// indentOf returns number of leading spaces of line
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// isBlank reports whether line holds only whitespace
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// add appends block of given type
+func (p *parser) add(blockType string, data map[string]interface{}) {
+	p.blocks = append(p.blocks, editorjs.Block{
+		Id:   editorjs.NewBlockId(),
+		Type: blockType,
+		Data: data,
+	})
+}
+
+// addHeader appends header block, clamping level to levels of the editor
+func (p *parser) addHeader(text string, level int) {
+	if level < minHeaderLevel {
+		level = minHeaderLevel
+	} else if level > maxHeaderLevel {
+		level = maxHeaderLevel
+	}
+
+	p.add("header", map[string]interface{}{
+		"text":  inlineToHTML(strings.TrimSpace(text)),
+		"level": level,
+	})
+}
+
+// startsBlock reports whether line interrupts a paragraph
+func startsBlock(line string) bool {
+	if fencePattern.MatchString(line) ||
+		atxPattern.MatchString(line) ||
+		thematicPattern.MatchString(line) ||
+		quotePattern.MatchString(line) {
+		return true
+	}
+
+	if m := listItemPattern.FindStringSubmatch(line); m != nil && len(m[1]) < 4 && m[3] != "" {
+		// only ordered lists starting at one interrupt a paragraph
+		return !strings.ContainsAny(m[2][len(m[2])-1:], ".)") || m[2][:len(m[2])-1] == "1"
+	}
+
+	return false
+}
+
+// parseFence reads fenced code block
+func (p *parser) parseFence(m []string) {
+	var (
+		fence  = m[1]
+		indent = indentOf(p.lines[p.pos])
+		code   []string
+	)
+
+	for p.pos++; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, fence[:1]) &&
+			strings.Trim(trimmed, fence[:1]) == "" &&
+			len(trimmed) >= len(fence) &&
+			indentOf(line) < 4 {
+			p.pos++
+			break
+		}
+
+		// remove indentation of opening fence from content lines
+		if n := indentOf(line); n < indent {
+			line = line[n:]
+		} else {
+			line = line[indent:]
+		}
+		code = append(code, line)
+	}
+
+	p.add("code", map[string]interface{}{"code": strings.Join(code, "\n")})
+}
+
+// parseIndentedCode reads code block indented by four spaces
+func (p *parser) parseIndentedCode() {
+	var code []string
+
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+
+		if isBlank(line) {
+			code = append(code, "")
+			continue
+		}
+
+		if indentOf(line) < 4 {
+			break
+		}
+		code = append(code, line[4:])
+	}
+
+	p.add("code", map[string]interface{}{
+		"code": strings.TrimRight(strings.Join(code, "\n"), "\n"),
+	})
+}
+
+// parseQuote reads block quote, GitHub alerts become warning blocks
+// and other quotes are parsed as regular content
+func (p *parser) parseQuote() {
+	var inner []string
+
+	for ; p.pos < len(p.lines); p.pos++ {
+		m := quotePattern.FindStringSubmatch(p.lines[p.pos])
+		if m == nil {
+			break
+		}
+		inner = append(inner, m[1])
+	}
+
+	if len(inner) > 0 && alertPattern.MatchString(strings.TrimSpace(inner[0])) {
+		var (
+			title   string
+			message []string
+		)
+
+		inner = inner[1:]
+		if len(inner) > 0 {
+			if m := boldLinePattern.FindStringSubmatch(strings.TrimSpace(inner[0])); m != nil {
+				title = m[1] + m[2]
+				inner = inner[1:]
+			}
+		}
+
+		for _, line := range inner {
+			if line = strings.TrimSpace(line); line != "" {
+				message = append(message, line)
+			}
+		}
+
+		p.add("warning", map[string]interface{}{
+			"title":   inlineToHTML(title),
+			"message": inlineToHTML(strings.Join(message, " ")),
+		})
+		return
+	}
+
+	nested := &parser{lines: inner}
+	nested.parse()
+	p.blocks = append(p.blocks, nested.blocks...)
+}
+
+// splitTableRow returns cells of pipe table row
+func splitTableRow(line string) (cells []string) {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseTable reads pipe table, empty header row marks table without headings
+func (p *parser) parseTable() {
+	var (
+		header  = splitTableRow(p.lines[p.pos])
+		content [][]interface{}
+		heading = false
+	)
+
+	row := func(cells []string) []interface{} {
+		res := make([]interface{}, len(header))
+		for i := range res {
+			res[i] = ""
+			if i < len(cells) {
+				res[i] = inlineToHTML(cells[i])
+			}
+		}
+		return res
+	}
+
+	for _, cell := range header {
+		if cell != "" {
+			heading = true
+		}
+	}
+
+	if heading {
+		content = append(content, row(header))
+	}
+
+	for p.pos += 2; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if isBlank(line) || !strings.Contains(line, "|") {
+			break
+		}
+		content = append(content, row(splitTableRow(line)))
+	}
+
+	rows := make([]interface{}, len(content))
+	for i := range content {
+		rows[i] = content[i]
+	}
+
+	p.add("table", map[string]interface{}{
+		"withHeadings": heading,
+		"content":      rows,
+	})
+}
+
+// parseList reads list starting at current line
+// Nested items are flattened since the post editor keeps flat lists
+func (p *parser) parseList() {
+	var (
+		first   = listItemPattern.FindStringSubmatch(p.lines[p.pos])
+		ordered = !strings.ContainsAny(first[2], "-*+")
+		bullet  = first[2][len(first[2])-1:]
+		items   []interface{}
+		current *strings.Builder
+	)
+
+	flush := func() {
+		if current != nil {
+			items = append(items, inlineToHTML(strings.TrimSpace(current.String())))
+			current = nil
+		}
+	}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+
+		if isBlank(line) {
+			// list continues when next content belongs to it
+			next := p.pos + 1
+			for next < len(p.lines) && isBlank(p.lines[next]) {
+				next++
+			}
+			if next >= len(p.lines) {
+				p.pos = next
+				break
+			}
+			if m := listItemPattern.FindStringSubmatch(p.lines[next]); m == nil && indentOf(p.lines[next]) < 2 {
+				break
+			} else if m != nil && len(m[1]) < 2 && m[2][len(m[2])-1:] != bullet {
+				break
+			}
+			p.pos = next
+			continue
+		}
+
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			if len(m[1]) < 2 && m[2][len(m[2])-1:] != bullet {
+				// another marker starts a new list
+				break
+			}
+
+			flush()
+			current = &strings.Builder{}
+			current.WriteString(m[3])
+			p.pos++
+			continue
+		}
+
+		if current == nil || (indentOf(line) < 2 && startsBlock(line)) {
+			break
+		}
+
+		// continuation line of current item
+		current.WriteString(" " + strings.TrimSpace(line))
+		p.pos++
+	}
+
+	flush()
+
+	style := "unordered"
+	if ordered {
+		style = "ordered"
+	}
+
+	p.add("list", map[string]interface{}{
+		"style": style,
+		"items": items,
+	})
+}
+
+// parseParagraph reads paragraph, setext header or standalone image
+func (p *parser) parseParagraph() {
+	var lines []string
+
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+
+		if isBlank(line) {
+			break
+		}
+
+		if len(lines) > 0 {
+			if m := setextPattern.FindStringSubmatch(line); m != nil {
+				p.pos++
+				level := 1
+				if m[1][0] == '-' {
+					level = 2
+				}
+				p.addHeader(strings.Join(lines, " "), level)
+				return
+			}
+
+			if startsBlock(line) ||
+				(strings.Contains(line, "|") && p.pos+1 < len(p.lines) && tableDelimPattern.MatchString(p.lines[p.pos+1])) {
+				break
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	var text strings.Builder
+	for i, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`)
+		line = strings.TrimSpace(line)
+
+		if hardBreak {
+			line = strings.TrimSuffix(line, `\`)
+		}
+
+		text.WriteString(inlineToHTML(line))
+
+		if i < len(lines)-1 {
+			if hardBreak {
+				text.WriteString("<br>")
+			} else {
+				text.WriteString(" ")
+			}
+		}
+	}
+
+	source := strings.TrimSpace(strings.Join(lines, " "))
+	if m := imageLinePattern.FindStringSubmatch(source); m != nil {
+		p.add("image", map[string]interface{}{
+			"file":           map[string]interface{}{"url": m[2]},
+			"caption":        inlineToHTML(m[1]),
+			"withBorder":     false,
+			"withBackground": false,
+			"stretched":      false,
+		})
+		return
+	}
+
+	p.add("paragraph", map[string]interface{}{"text": text.String()})
+}
+
+// parse reads every block of input lines
+func (p *parser) parse() {
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+
+		switch {
+		case isBlank(line):
+			p.pos++
+
+		case indentOf(line) >= 4:
+			p.parseIndentedCode()
+
+		case fencePattern.MatchString(line):
+			p.parseFence(fencePattern.FindStringSubmatch(line))
+
+		case atxPattern.MatchString(line):
+			m := atxPattern.FindStringSubmatch(line)
+			p.addHeader(m[2], len(m[1]))
+			p.pos++
+
+		case thematicPattern.MatchString(line):
+			// the post editor has no delimiter block
+			p.pos++
+
+		case quotePattern.MatchString(line):
+			p.parseQuote()
+
+		case listItemPattern.MatchString(line):
+			p.parseList()
+
+		case strings.Contains(line, "|") && p.pos+1 < len(p.lines) && tableDelimPattern.MatchString(p.lines[p.pos+1]):
+			p.parseTable()
+
+		default:
+			p.parseParagraph()
+		}
+	}
+}
+
+// ToDocument converts CommonMark source into Editor.js document
+// using block types of the post editor
+func ToDocument(source string) *editorjs.Document {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\t", "    ")
+
+	p := &parser{lines: strings.Split(source, "\n")}
+	p.parse()
+
+	return &editorjs.Document{Blocks: p.blocks}
+}
+
+// Parse splits Markdown post into front matter and Editor.js document
+func Parse(source string) (*FrontMatter, *editorjs.Document, error) {
+	fm, body, err := ParseFrontMatter(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &fm, ToDocument(body), nil
+}
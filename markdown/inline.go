@@ -0,0 +1,200 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTagPattern  = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)\b([^<>]*)>`)
+	htmlHrefPattern = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+	codeSpanPattern    = regexp.MustCompile("(`+)([^`](?:.*?[^`])?)(`+)")
+	escapePattern      = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+	autolinkPattern    = regexp.MustCompile(`<((?:https?://|mailto:)[^<>\s]+)>`)
+	rawTagPattern      = regexp.MustCompile(`(?i)</?(?:u|mark|s|sub|sup|br)\s*/?>`)
+	inlineImgPattern   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+[^)]*)?\)`)
+	linkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+[^)]*)?\)`)
+	strongPattern      = regexp.MustCompile(`\*\*([^\s*](?:.*?[^\s*])?)\*\*|__([^\s_](?:.*?[^\s_])?)__`)
+	emStarPattern      = regexp.MustCompile(`\*([^\s*](?:[^*]*?[^\s*])?)\*`)
+	emUnderPattern     = regexp.MustCompile(`(^|[^\w])_([^\s_](?:[^_]*?[^\s_])?)_([^\w]|$)`)
+	placeholderFormat  = "\x00%d\x00"
+	placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// markdownEscaper escapes characters having inline meaning in Markdown
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+)
+
+// codeFence returns backtick run longer than any run inside text
+func codeFence(text string, min int) string {
+	longest, run := 0, 0
+
+	for _, c := range text {
+		if c == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	if longest+1 > min {
+		min = longest + 1
+	}
+	return strings.Repeat("`", min)
+}
+
+// inlineToMarkdown converts inline HTML of Editor.js block text into Markdown
+// Markup without Markdown equivalent is kept as raw HTML
+func inlineToMarkdown(text string, inTable bool) string {
+	var (
+		out   strings.Builder
+		hrefs []string
+		last  int
+	)
+
+	writeText := func(s string) {
+		s = markdownEscaper.Replace(html.UnescapeString(s))
+		s = strings.ReplaceAll(s, "\n", " ")
+		if inTable {
+			s = strings.ReplaceAll(s, "|", `\|`)
+		}
+		out.WriteString(s)
+	}
+
+	matches := htmlTagPattern.FindAllStringSubmatchIndex(text, -1)
+	for i := 0; i < len(matches); i++ {
+		m := matches[i]
+		writeText(text[last:m[0]])
+		last = m[1]
+
+		closing := m[3] > m[2]
+		name := strings.ToLower(text[m[4]:m[5]])
+
+		switch name {
+		case "b", "strong":
+			out.WriteString("**")
+
+		case "i", "em":
+			out.WriteString("*")
+
+		case "br":
+			out.WriteString("<br>")
+
+		case "u", "mark", "s", "sub", "sup":
+			out.WriteString(text[m[0]:m[1]])
+
+		case "a":
+			if closing {
+				if len(hrefs) == 0 {
+					continue
+				}
+				out.WriteString("](" + hrefs[len(hrefs)-1] + ")")
+				hrefs = hrefs[:len(hrefs)-1]
+			} else {
+				href := ""
+				if h := htmlHrefPattern.FindStringSubmatch(text[m[6]:m[7]]); h != nil {
+					href = html.UnescapeString(h[1] + h[2] + h[3])
+				}
+				hrefs = append(hrefs, strings.ReplaceAll(href, " ", "%20"))
+				out.WriteString("[")
+			}
+
+		case "code":
+			if closing {
+				continue
+			}
+
+			// code content is taken verbatim up to the closing tag
+			end := len(text)
+			next := len(matches)
+			for j := i + 1; j < len(matches); j++ {
+				if matches[j][3] > matches[j][2] && strings.EqualFold(text[matches[j][4]:matches[j][5]], "code") {
+					end, next = matches[j][0], j
+					break
+				}
+			}
+
+			code := html.UnescapeString(htmlTagPattern.ReplaceAllString(text[m[1]:end], ""))
+			fence := codeFence(code, 1)
+			if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+				code = " " + code + " "
+			}
+			out.WriteString(fence + code + fence)
+
+			if next < len(matches) {
+				last = matches[next][1]
+			} else {
+				last = len(text)
+			}
+			i = next
+		}
+	}
+
+	writeText(text[last:])
+	return out.String()
+}
+
+// inlineToHTML converts Markdown inline content into Editor.js inline HTML
+func inlineToHTML(text string) string {
+	var protected []string
+
+	protect := func(s string) string {
+		protected = append(protected, s)
+		return fmt.Sprintf(placeholderFormat, len(protected)-1)
+	}
+
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(s string) string {
+		m := codeSpanPattern.FindStringSubmatch(s)
+		if len(m[1]) != len(m[3]) {
+			return s
+		}
+
+		code := m[2]
+		if len(code) > 2 && strings.HasPrefix(code, " ") && strings.HasSuffix(code, " ") {
+			code = code[1 : len(code)-1]
+		}
+		return protect(`<code class="inline-code">` + html.EscapeString(code) + `</code>`)
+	})
+
+	text = escapePattern.ReplaceAllStringFunc(text, func(s string) string {
+		return protect(html.EscapeString(s[1:]))
+	})
+
+	text = autolinkPattern.ReplaceAllStringFunc(text, func(s string) string {
+		url := html.EscapeString(s[1 : len(s)-1])
+		return protect(`<a href="` + url + `">` + url + `</a>`)
+	})
+
+	text = rawTagPattern.ReplaceAllStringFunc(text, func(s string) string {
+		return protect(strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "/>", ">")))
+	})
+
+	text = html.EscapeString(html.UnescapeString(text))
+
+	text = inlineImgPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = linkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = strongPattern.ReplaceAllString(text, "<b>$1$2</b>")
+	text = emStarPattern.ReplaceAllString(text, "<i>$1</i>")
+	text = emUnderPattern.ReplaceAllString(text, "$1<i>$2</i>$3")
+
+	// restore protected content, placeholders never nest
+	return placeholderPattern.ReplaceAllStringFunc(text, func(s string) string {
+		var i int
+		fmt.Sscanf(s[1:len(s)-1], "%d", &i)
+		return protected[i]
+	})
+}
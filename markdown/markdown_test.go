@@ -0,0 +1,127 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"header", "## Title\n"},
+		{"sub header", "### Section\n"},
+		{"inline marks", "Some **bold** and *italic* text with `code` and [link](https://example.com).\n"},
+		{"unordered list", "- one\n- two\n"},
+		{"ordered list", "1. first\n2. second\n"},
+		{"code fence", "```\nfmt.Println(1)\n```\n"},
+		{"table", "| a | b |\n| --- | --- |\n| 1 | 2 |\n"},
+		{"image", "![alt](https://example.com/a.png)\n"},
+		{"warning", "> [!WARNING]\n> **Careful**\n> Mind the gap\n"},
+		{"mixed", "## Title\n\nIntro\n\n- one\n- two\n\n```\nx := 1\n```\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromDocument(ToDocument(tt.source)); got != tt.source {
+				t.Errorf("round trip = %q, want %q", got, tt.source)
+			}
+		})
+	}
+}
+
+func TestToDocumentEditorBlocks(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"top level header is clamped", "# Title\n", "## Title\n"},
+		{"deep header is clamped", "###### Title\n", "##### Title\n"},
+		{"quote becomes paragraph", "> quoted text\n", "quoted text\n"},
+		{"fence language is dropped", "```go\nx := 1\n```\n", "```\nx := 1\n```\n"},
+		{"thematic break is dropped", "one\n\n---\n\ntwo\n", "one\n\ntwo\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromDocument(ToDocument(tt.source)); got != tt.want {
+				t.Errorf("FromDocument(ToDocument(%q)) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrontMatter(t *testing.T) {
+	tests := []struct {
+		name string
+		fm   FrontMatter
+	}{
+		{"full", FrontMatter{Title: "Hello", Slug: "hello", Desc: "A post", Topic: "go", License: "MIT", Tags: []string{"go", "tips"}}},
+		{"quotes and colons", FrontMatter{Title: `Say "hi": now`, Slug: "say-hi", Tags: []string{"a, b"}}},
+		{"no tags", FrontMatter{Title: "Empty", Tags: []string{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := tt.fm.String() + "Body\n"
+
+			fm, body, err := ParseFrontMatter(source)
+			if err != nil {
+				t.Fatalf("ParseFrontMatter() error = %v", err)
+			}
+
+			if len(fm.Tags) == 0 && len(tt.fm.Tags) == 0 {
+				fm.Tags = tt.fm.Tags
+			}
+
+			if !reflect.DeepEqual(fm, tt.fm) {
+				t.Errorf("front matter = %+v, want %+v", fm, tt.fm)
+			}
+
+			if body != "Body\n" {
+				t.Errorf("body = %q, want %q", body, "Body\n")
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	const source = "## Title\n\nText\n"
+
+	got, err := Source(Body(source))
+	if err != nil || got != source {
+		t.Errorf("Source(Body()) = %q, %v, want %q", got, err, source)
+	}
+
+	if _, err := Source(Body("")); err != nil {
+		t.Errorf("Source() of empty body error = %v", err)
+	}
+
+	if _, err := Source(nil); err == nil {
+		t.Error("Source() of missing body returned no error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{"plain", "## Title\n\nText\n", false},
+		{"closed fence", "```\ncode\n```\n", false},
+		{"unclosed fence", "```\ncode\n", true},
+		{"invalid utf-8", "bad \xff byte", true},
+		{"nul", "a\x00b", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.source); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
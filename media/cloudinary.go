@@ -0,0 +1,137 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+)
+
+// maximum number of assets Cloudinary returns per page
+const cloudinaryMaxResults = 500
+
+// CloudinaryStore keeps images in Cloudinary
+type CloudinaryStore struct{}
+
+// NewCloudinaryStore creates new instance of CloudinaryStore
+// util.InitCloudinary must be called before first use
+func NewCloudinaryStore() *CloudinaryStore {
+	return &CloudinaryStore{}
+}
+
+// Upload stores raw image under given folder
+func (cs *CloudinaryStore) Upload(ctx context.Context, folder string, data []byte) (*types.UploadedImageFile, error) {
+	return util.UploadImage(ctx, folder, data)
+}
+
+// Put stores raw image under exact public id, replacing existing image
+func (cs *CloudinaryStore) Put(ctx context.Context, publicId string, data []byte) (*types.UploadedImageFile, error) {
+	return util.PutImage(ctx, publicId, data)
+}
+
+// Download returns original image by given public id
+func (cs *CloudinaryStore) Download(ctx context.Context, publicId string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cs.Url(publicId, nil), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cloudinary: download of '%s' failed with status %d", publicId, res.StatusCode)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// Delete removes image by given public id
+func (cs *CloudinaryStore) Delete(ctx context.Context, publicId string) error {
+	return util.DeleteImage(ctx, publicId)
+}
+
+// List returns images stored under given folder
+func (cs *CloudinaryStore) List(ctx context.Context, folder string) (files []types.MediaFile, err error) {
+	var (
+		res    *admin.AssetsResult
+		params = admin.AssetsParams{
+			AssetType:    api.Image,
+			DeliveryType: "upload",
+			Prefix:       strings.TrimSuffix(folder, "/") + "/",
+			MaxResults:   cloudinaryMaxResults,
+		}
+	)
+
+	for {
+		if res, err = util.CloudinaryInstance().Admin.Assets(ctx, params); err != nil {
+			return nil, err
+		}
+
+		if res.Error.Message != "" {
+			return nil, fmt.Errorf("cloudinary: %s", res.Error.Message)
+		}
+
+		for _, asset := range res.Assets {
+			files = append(files, types.MediaFile{
+				PublicId:  asset.PublicID,
+				AssetId:   asset.AssetID,
+				Format:    asset.Format,
+				Bytes:     asset.Bytes,
+				Width:     asset.Width,
+				Height:    asset.Height,
+				Url:       asset.SecureURL,
+				CreatedAt: asset.CreatedAt,
+			})
+		}
+
+		if res.NextCursor == "" {
+			return files, nil
+		}
+		params.NextCursor = res.NextCursor
+	}
+}
+
+// Url returns Cloudinary delivery url of image by given public id
+func (cs *CloudinaryStore) Url(publicId string, t *Transform) string {
+	var (
+		steps []string
+		ext   string
+	)
+
+	if t != nil {
+		if t.Crop != "" {
+			steps = append(steps, "c_"+t.Crop)
+		}
+		if t.Width > 0 {
+			steps = append(steps, fmt.Sprintf("w_%d", t.Width))
+		}
+		if t.Height > 0 {
+			steps = append(steps, fmt.Sprintf("h_%d", t.Height))
+		}
+		if t.Format != "" {
+			ext = "." + t.Format
+		}
+	}
+
+	if len(steps) == 0 {
+		return fmt.Sprintf("https://res.cloudinary.com/%s/image/upload/%s%s", config.CloudinaryId(), publicId, ext)
+	}
+
+	return fmt.Sprintf(
+		"https://res.cloudinary.com/%s/image/upload/%s/%s%s",
+		config.CloudinaryId(),
+		strings.Join(steps, ","),
+		publicId,
+		ext)
+}
@@ -0,0 +1,287 @@
+package media
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+)
+
+// LocalUrlPrefix is the asset server path local images are served from
+const LocalUrlPrefix = "/media/"
+
+// content types accepted by local store mapped to file extension
+var localImageFormats = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpg",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// LocalStore keeps images in a directory on the local filesystem
+// and serves them through the Wails asset server, transforms are not applied
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates new instance of LocalStore rooted at given directory
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+// randomName returns random lowercase alphanumeric file name
+func randomName() (string, error) {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	buf := make([]byte, 20)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	for i, b := range buf {
+		buf[i] = alphabet[int(b)%len(alphabet)]
+	}
+	return string(buf), nil
+}
+
+// cleanPublicId normalizes public id and rejects paths escaping the store
+func cleanPublicId(publicId string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+publicId), "/")
+
+	if cleaned == "" || cleaned == "." {
+		return "", fmt.Errorf("invalid public id '%s'", publicId)
+	}
+	return cleaned, nil
+}
+
+// find returns absolute file path of image by given public id
+func (ls *LocalStore) find(publicId string) (string, error) {
+	id, err := cleanPublicId(publicId)
+	if err != nil {
+		return "", err
+	}
+
+	dir, name := path.Split(id)
+	entries, err := os.ReadDir(filepath.Join(ls.dir, filepath.FromSlash(dir)))
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		base := entry.Name()
+		if !entry.IsDir() && strings.TrimSuffix(base, filepath.Ext(base)) == name {
+			return filepath.Join(ls.dir, filepath.FromSlash(dir), base), nil
+		}
+	}
+
+	return "", fs.ErrNotExist
+}
+
+// Upload stores raw image under given folder
+func (ls *LocalStore) Upload(ctx context.Context, folder string, data []byte) (*types.UploadedImageFile, error) {
+	var (
+		name     string
+		folderId string
+		format   string
+		ok       bool
+		err      error
+	)
+
+	util.Log.Info(fmt.Sprintf("[LocalStore.Upload] Uploading image (folder='%s')", folder))
+
+	if format, ok = localImageFormats[http.DetectContentType(data)]; !ok {
+		return nil, errors.New("unsupported image format")
+	}
+
+	if folderId, err = cleanPublicId(folder); err != nil {
+		return nil, err
+	}
+
+	if name, err = randomName(); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(ls.dir, filepath.FromSlash(folderId))
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, name+"."+format), data, 0644); err != nil {
+		util.Log.Error(fmt.Sprintf("[LocalStore.Upload] %s", err.Error()))
+		return nil, err
+	}
+
+	sum := md5.Sum(data)
+	res := &types.UploadedImageFile{
+		PublicId: folderId + "/" + name,
+		AssetId:  hex.EncodeToString(sum[:]),
+		Format:   format,
+	}
+
+	util.Log.Info(fmt.Sprintf("[LocalStore.Upload] Image uploaded (format='%s', publicId='%s')", res.Format, res.PublicId))
+	return res, nil
+}
+
+// Put stores raw image under exact public id, replacing existing image
+func (ls *LocalStore) Put(ctx context.Context, publicId string, data []byte) (*types.UploadedImageFile, error) {
+	var (
+		id     string
+		format string
+		ok     bool
+		err    error
+	)
+
+	if format, ok = localImageFormats[http.DetectContentType(data)]; !ok {
+		return nil, errors.New("unsupported image format")
+	}
+
+	if id, err = cleanPublicId(publicId); err != nil {
+		return nil, err
+	}
+
+	// existing image may use another extension
+	if file, err := ls.find(id); err == nil {
+		if err = os.Remove(file); err != nil {
+			return nil, err
+		}
+	}
+
+	file := filepath.Join(ls.dir, filepath.FromSlash(id)+"."+format)
+	if err = os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return nil, err
+	}
+
+	if err = os.WriteFile(file, data, 0644); err != nil {
+		util.Log.Error(fmt.Sprintf("[LocalStore.Put] %s", err.Error()))
+		return nil, err
+	}
+
+	sum := md5.Sum(data)
+	util.Log.Info(fmt.Sprintf("[LocalStore.Put] Image stored (format='%s', publicId='%s')", format, id))
+
+	return &types.UploadedImageFile{
+		PublicId: id,
+		AssetId:  hex.EncodeToString(sum[:]),
+		Format:   format,
+	}, nil
+}
+
+// Download returns original image by given public id
+func (ls *LocalStore) Download(ctx context.Context, publicId string) ([]byte, error) {
+	file, err := ls.find(publicId)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(file)
+}
+
+// Delete removes image by given public id
+func (ls *LocalStore) Delete(ctx context.Context, publicId string) error {
+	file, err := ls.find(publicId)
+
+	if err == nil {
+		err = os.Remove(file)
+	}
+
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[LocalStore.Delete] %s", err.Error()))
+	} else {
+		util.Log.Info(fmt.Sprintf("[LocalStore.Delete] Image deleted (publicId='%s')", publicId))
+	}
+	return err
+}
+
+// List returns images stored under given folder
+func (ls *LocalStore) List(ctx context.Context, folder string) (files []types.MediaFile, err error) {
+	var folderId string
+
+	if folderId, err = cleanPublicId(folder); err != nil {
+		return nil, err
+	}
+
+	root := filepath.Join(ls.dir, filepath.FromSlash(folderId))
+	err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(ls.dir, file)
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		ext := filepath.Ext(rel)
+		publicId := filepath.ToSlash(strings.TrimSuffix(rel, ext))
+		mf := types.MediaFile{
+			PublicId:  publicId,
+			Format:    strings.TrimPrefix(ext, "."),
+			Bytes:     int(info.Size()),
+			Url:       ls.Url(publicId, nil),
+			CreatedAt: info.ModTime(),
+		}
+
+		// read dimensions from header, formats without std decoder are left as zero
+		if fd, err := os.Open(file); err == nil {
+			if cfg, _, err := image.DecodeConfig(fd); err == nil {
+				mf.Width, mf.Height = cfg.Width, cfg.Height
+			}
+			fd.Close()
+		}
+
+		files = append(files, mf)
+		return nil
+	})
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return files, err
+}
+
+// Url returns asset server url of image by given public id
+func (ls *LocalStore) Url(publicId string, t *Transform) string {
+	return LocalUrlPrefix + publicId
+}
+
+// ServeHTTP serves stored images under LocalUrlPrefix,
+// requested extension is ignored and original file is returned
+func (ls *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, LocalUrlPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+
+	publicId := strings.TrimPrefix(r.URL.Path, LocalUrlPrefix)
+	publicId = strings.TrimSuffix(publicId, path.Ext(publicId))
+
+	file, err := ls.find(publicId)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeFile(w, r, file)
+}
@@ -0,0 +1,36 @@
+package media
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// Transform describes optional image delivery transformation
+type Transform struct {
+	Crop   string
+	Width  int
+	Height int
+	Format string
+}
+
+// MediaStore describes image storage backend
+type MediaStore interface {
+	// Upload stores raw image under given folder
+	Upload(ctx context.Context, folder string, data []byte) (*types.UploadedImageFile, error)
+
+	// Put stores raw image under exact public id, replacing existing image
+	Put(ctx context.Context, publicId string, data []byte) (*types.UploadedImageFile, error)
+
+	// Download returns original image by given public id
+	Download(ctx context.Context, publicId string) ([]byte, error)
+
+	// Delete removes image by given public id
+	Delete(ctx context.Context, publicId string) error
+
+	// List returns images stored under given folder
+	List(ctx context.Context, folder string) ([]types.MediaFile, error)
+
+	// Url returns delivery url of image by given public id
+	Url(publicId string, t *Transform) string
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditLogDocument struct {
+	Id        primitive.ObjectID `bson:"_id" json:"_id"`
+	Actor     string             `bson:"actor" json:"actor"`
+	Operation string             `bson:"operation" json:"operation"`
+	TargetId  string             `bson:"targetId" json:"targetId"`
+	Before    bson.M             `bson:"before,omitempty" json:"before,omitempty"`
+	After     bson.M             `bson:"after,omitempty" json:"after,omitempty"`
+	Outcome   string             `bson:"outcome" json:"outcome"`
+	Error     string             `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuthorLink struct {
+	Name string `bson:"name" json:"name"`
+	Url  string `bson:"url" json:"url"`
+}
+
+type AuthorDocument struct {
+	Id         primitive.ObjectID `bson:"_id" json:"_id"`
+	Name       string             `bson:"name" json:"name"`
+	Bio        string             `bson:"bio" json:"bio"`
+	AvatarId   string             `bson:"avatarId" json:"avatarId"`
+	AvatarPath string             `bson:"avatarPath" json:"avatarPath"`
+	Links      []AuthorLink       `bson:"links" json:"links"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
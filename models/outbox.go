@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IndexOutboxDocument is pending search index update of single post
+type IndexOutboxDocument struct {
+	Id            primitive.ObjectID `bson:"_id" json:"_id"`
+	PostId        primitive.ObjectID `bson:"postId" json:"postId"`
+	Action        string             `bson:"action" json:"action"`
+	Version       int64              `bson:"version" json:"version"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	LastError     string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	NextAttemptAt time.Time          `bson:"nextAttemptAt" json:"nextAttemptAt"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -7,6 +7,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// post body formats
+const (
+	PostFormatBlock    = "block"
+	PostFormatMarkdown = "markdown"
+)
+
+// PostLicenses lists license identifiers offered by the post editor
+var PostLicenses = []string{
+	"CC-BY-4.0",
+	"CC-BY-NC-4.0",
+	"CC-BY-SA-4.0",
+	"CC-BY-ND-4.0",
+}
+
 type PostCoverImage struct {
 	Id      string `bson:"id" json:"id"`
 	Path    string `bson:"path" json:"path"`
@@ -31,23 +45,25 @@ type PostMetadataDocument struct {
 }
 
 type PostDocument struct {
-	Id         primitive.ObjectID `bson:"_id" json:"_id"`
-	Title      string             `bson:"title" json:"title"`
-	Slug       string             `bson:"slug" json:"slug"`
-	Desc       string             `bson:"desc" json:"desc"`
-	Tags       []string           `bson:"tags" json:"tags"`
-	Topic      string             `bson:"topic" json:"topic"`
-	Body       bson.M             `bson:"body" json:"body"`
-	Format     string             `bson:"format" json:"format"`
-	Stars      int64              `bson:"stars" json:"stars"`
-	Public     bool               `bson:"public" json:"public"`
-	Deleted    bool               `bson:"deleted" json:"deleted"`
-	CoverImage *PostCoverImage    `bson:"coverImage" json:"coverImage"`
-	AuthorId   primitive.ObjectID `bson:"authorId" json:"authorId"`
-	License    string             `bson:"license" json:"license"`
-	Related    []string           `bson:"related" json:"related"`
-	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
-	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
+	Id          primitive.ObjectID   `bson:"_id" json:"_id"`
+	Title       string               `bson:"title" json:"title"`
+	Slug        string               `bson:"slug" json:"slug"`
+	Desc        string               `bson:"desc" json:"desc"`
+	Tags        []string             `bson:"tags" json:"tags"`
+	Topic       string               `bson:"topic" json:"topic"`
+	Body        bson.M               `bson:"body" json:"body"`
+	Format      string               `bson:"format" json:"format"`
+	Stars       int64                `bson:"stars" json:"stars"`
+	Public      bool                 `bson:"public" json:"public"`
+	Deleted     bool                 `bson:"deleted" json:"deleted"`
+	CoverImage  *PostCoverImage      `bson:"coverImage" json:"coverImage"`
+	AuthorId    primitive.ObjectID   `bson:"authorId" json:"authorId"`
+	License     string               `bson:"license" json:"license"`
+	Related     []primitive.ObjectID `bson:"related" json:"related" ts_type:"string[]"`
+	PublishAt   *time.Time           `bson:"publishAt,omitempty" json:"publishAt"`
+	UnpublishAt *time.Time           `bson:"unpublishAt,omitempty" json:"unpublishAt"`
+	CreatedAt   time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time            `bson:"updatedAt" json:"updatedAt"`
 }
 
 type PostObjectView struct {
@@ -84,14 +100,24 @@ type PostRelatedView struct {
 	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
 
+// PostIndexSchema is version of PostIndex layout, records of older layouts are reindexed
+const PostIndexSchema = 2
+
 type PostIndex struct {
-	ObjectId  string    `json:"objectID"`
-	Name      string    `json:"name"`
-	Topic     string    `json:"topic"`
-	Desc      string    `json:"description"`
-	Tags      []string  `json:"tags"`
-	Url       string    `json:"url"`
-	Image     string    `json:"image"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ObjectId    string    `json:"objectID"`
+	Schema      int       `json:"schema"`
+	Name        string    `json:"name"`
+	Topic       string    `json:"topic"`
+	Author      string    `json:"author"`
+	Desc        string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	Url         string    `json:"url"`
+	Image       string    `json:"image"`
+	Excerpt     string    `json:"excerpt"`
+	Headings    []string  `json:"headings"`
+	WordCount   int       `json:"wordCount"`
+	ReadingTime int       `json:"readingTime"`
+	License     string    `json:"license"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SlugRedirectDocument struct {
+	Id        primitive.ObjectID `bson:"_id" json:"_id"`
+	PostId    primitive.ObjectID `bson:"postId" json:"postId"`
+	From      string             `bson:"from" json:"from"`
+	To        string             `bson:"to" json:"to"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PostRevisionDocument struct {
+	Id        primitive.ObjectID `bson:"_id" json:"_id"`
+	PostId    primitive.ObjectID `bson:"postId" json:"postId"`
+	Reason    string             `bson:"reason" json:"reason"`
+	Title     string             `bson:"title" json:"title"`
+	Snapshot  *PostDocument      `bson:"snapshot" json:"snapshot"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+type PostRevisionSummary struct {
+	Id        primitive.ObjectID `bson:"_id" json:"_id"`
+	PostId    primitive.ObjectID `bson:"postId" json:"postId"`
+	Reason    string             `bson:"reason" json:"reason"`
+	Title     string             `bson:"title" json:"title"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
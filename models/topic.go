@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type TopicDocument struct {
+	Id        string    `bson:"_id" json:"id"`
+	Name      string    `bson:"name" json:"name"`
+	ThumbId   string    `bson:"thumbId" json:"thumbId"`
+	ThumbPath string    `bson:"thumbPath" json:"thumbPath"`
+	Public    bool      `bson:"public" json:"public"`
+	Order     int       `bson:"order" json:"order"`
+	Archived  bool      `bson:"archived" json:"archived"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
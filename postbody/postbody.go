@@ -0,0 +1,193 @@
+// Package postbody handles post bodies independently of their format
+package postbody
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/markdown"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Normalize returns known format name, empty format of older posts means block
+func Normalize(format string) (string, error) {
+	switch format {
+	case "", models.PostFormatBlock:
+		return models.PostFormatBlock, nil
+	case models.PostFormatMarkdown:
+		return models.PostFormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("unsupported post format '%s'", format)
+	}
+}
+
+// Parse returns Editor.js document of post body in given format
+// Markdown is converted into blocks, so every consumer of blocks works for both formats
+func Parse(format string, body bson.M) (*editorjs.Document, error) {
+	var err error
+
+	if format, err = Normalize(format); err != nil {
+		return nil, err
+	}
+
+	if format == models.PostFormatBlock {
+		return editorjs.Parse(body)
+	}
+
+	source, err := markdown.Source(body)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := markdown.ToDocument(source)
+
+	// ids of derived blocks change on every parse, blocks are matched by content instead
+	for i := range doc.Blocks {
+		doc.Blocks[i].Id = ""
+	}
+
+	return doc, nil
+}
+
+// Validate checks format and body of post before save
+func Validate(format string, body bson.M) error {
+	var err error
+
+	if format, err = Normalize(format); err != nil {
+		return err
+	}
+
+	if format == models.PostFormatBlock {
+		if body == nil {
+			return errors.New("block body is required")
+		}
+
+		_, err = editorjs.Parse(body)
+		return err
+	}
+
+	source, err := markdown.Source(body)
+	if err != nil {
+		return err
+	}
+
+	return markdown.Validate(source)
+}
+
+// Convert returns body converted from one format to another
+func Convert(body bson.M, from string, to string) (bson.M, error) {
+	var err error
+
+	if from, err = Normalize(from); err != nil {
+		return nil, err
+	}
+
+	if to, err = Normalize(to); err != nil {
+		return nil, err
+	}
+
+	if from == to {
+		return body, nil
+	}
+
+	if to == models.PostFormatMarkdown {
+		doc, err := editorjs.Parse(body)
+		if err != nil {
+			return nil, err
+		}
+		return markdown.Body(markdown.FromDocument(doc)), nil
+	}
+
+	source, err := markdown.Source(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return markdown.ToDocument(source).Body()
+}
+
+// PlainText returns readable text of post body, blocks are separated by blank lines
+func PlainText(format string, body bson.M) (string, error) {
+	doc, err := Parse(format, body)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(doc.Blocks))
+	for i := range doc.Blocks {
+		if text := strings.TrimSpace(doc.Blocks[i].PlainText()); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// WordCount returns number of words in text
+func WordCount(text string) (count int) {
+	for _, field := range strings.Fields(text) {
+		if strings.IndexFunc(field, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// readable block types, other blocks such as embeds and delimiters hold no text
+var textBlocks = map[string]bool{
+	"paragraph": true,
+	"header":    true,
+	"quote":     true,
+	"list":      true,
+	"code":      true,
+	"warning":   true,
+	"image":     true,
+	"table":     true,
+}
+
+// Digest is searchable summary of post body
+type Digest struct {
+	Text      string
+	Headings  []string
+	WordCount int
+}
+
+// Summarize returns readable text, heading texts and word count of post body,
+// blocks without readable text are skipped
+func Summarize(format string, body bson.M) (*Digest, error) {
+	doc, err := Parse(format, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		digest = &Digest{Headings: []string{}}
+		parts  = make([]string, 0, len(doc.Blocks))
+	)
+
+	for i := range doc.Blocks {
+		if !textBlocks[doc.Blocks[i].Type] {
+			continue
+		}
+
+		text := strings.TrimSpace(doc.Blocks[i].PlainText())
+		if text == "" {
+			continue
+		}
+
+		if doc.Blocks[i].Type == "header" {
+			digest.Headings = append(digest.Headings, text)
+		}
+		parts = append(parts, text)
+	}
+
+	digest.Text = strings.Join(parts, "\n\n")
+	digest.WordCount = WordCount(digest.Text)
+	return digest, nil
+}
@@ -0,0 +1,194 @@
+package postdiff
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// Block change kinds
+const (
+	BlockAdded    = "added"
+	BlockRemoved  = "removed"
+	BlockMoved    = "moved"
+	BlockModified = "modified"
+)
+
+// indexedBlock keeps block with its position and comparison key
+type indexedBlock struct {
+	editorjs.Block
+	index int
+	key   string
+}
+
+// blockPair links old and new version of the same block
+type blockPair struct {
+	old *indexedBlock
+	new *indexedBlock
+}
+
+// indexBlocks computes comparison keys of given blocks
+func indexBlocks(blocks []editorjs.Block) []*indexedBlock {
+	indexed := make([]*indexedBlock, len(blocks))
+
+	for i, block := range blocks {
+		// encoding/json sorts map keys, so equal data produces equal keys
+		data, _ := json.Marshal(block.Data)
+		indexed[i] = &indexedBlock{Block: block, index: i, key: block.Type + ":" + string(data)}
+	}
+
+	return indexed
+}
+
+// matchBlocks pairs old and new blocks by id, then identical content,
+// then same type in document order; leftovers are removed or added
+func matchBlocks(old, new []*indexedBlock) (pairs []blockPair, removed, added []*indexedBlock) {
+	var (
+		usedOld = make(map[int]bool)
+		usedNew = make(map[int]bool)
+	)
+
+	match := func(accept func(o, n *indexedBlock) bool) {
+		for _, n := range new {
+			if usedNew[n.index] {
+				continue
+			}
+
+			for _, o := range old {
+				if !usedOld[o.index] && accept(o, n) {
+					usedOld[o.index], usedNew[n.index] = true, true
+					pairs = append(pairs, blockPair{old: o, new: n})
+					break
+				}
+			}
+		}
+	}
+
+	match(func(o, n *indexedBlock) bool {
+		return o.Id != "" && o.Id == n.Id
+	})
+	match(func(o, n *indexedBlock) bool {
+		return (o.Id == "" || n.Id == "") && o.key == n.key
+	})
+	match(func(o, n *indexedBlock) bool {
+		return o.Id == "" && n.Id == "" && o.Type == n.Type
+	})
+
+	for _, o := range old {
+		if !usedOld[o.index] {
+			removed = append(removed, o)
+		}
+	}
+
+	for _, n := range new {
+		if !usedNew[n.index] {
+			added = append(added, n)
+		}
+	}
+
+	return pairs, removed, added
+}
+
+// stablePairs marks pairs keeping relative order using longest increasing subsequence
+func stablePairs(pairs []blockPair) map[int]bool {
+	var (
+		n      = len(pairs)
+		length = make([]int, n)
+		prev   = make([]int, n)
+		best   = -1
+		stable = make(map[int]bool)
+	)
+
+	for i := range pairs {
+		length[i], prev[i] = 1, -1
+
+		for j := 0; j < i; j++ {
+			if pairs[j].old.index < pairs[i].old.index && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+
+		if best == -1 || length[i] > length[best] {
+			best = i
+		}
+	}
+
+	for i := best; i != -1; i = prev[i] {
+		stable[pairs[i].new.index] = true
+	}
+
+	return stable
+}
+
+// DiffBlocks returns block level changes turning old blocks into new blocks
+func DiffBlocks(old, new []editorjs.Block) (changes []types.BlockChange) {
+	pairs, removed, added := matchBlocks(indexBlocks(old), indexBlocks(new))
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].new.index < pairs[j].new.index
+	})
+
+	stable := stablePairs(pairs)
+
+	for _, pair := range pairs {
+		o, n := pair.old, pair.new
+		moved := !stable[n.index]
+		modified := o.key != n.key
+
+		if !moved && !modified {
+			continue
+		}
+
+		// a moved block with edited content is reported as both
+		if modified {
+			oldText, newText := o.PlainText(), n.PlainText()
+
+			changes = append(changes, types.BlockChange{
+				Kind:     BlockModified,
+				BlockId:  n.Id,
+				Type:     n.Type,
+				OldIndex: o.index,
+				NewIndex: n.index,
+				OldText:  oldText,
+				NewText:  newText,
+				Text:     DiffText(oldText, newText),
+			})
+		}
+
+		if moved {
+			changes = append(changes, types.BlockChange{
+				Kind:     BlockMoved,
+				BlockId:  n.Id,
+				Type:     n.Type,
+				OldIndex: o.index,
+				NewIndex: n.index,
+			})
+		}
+	}
+
+	for _, o := range removed {
+		changes = append(changes, types.BlockChange{
+			Kind:     BlockRemoved,
+			BlockId:  o.Id,
+			Type:     o.Type,
+			OldIndex: o.index,
+			NewIndex: -1,
+			OldText:  o.PlainText(),
+		})
+	}
+
+	for _, n := range added {
+		changes = append(changes, types.BlockChange{
+			Kind:     BlockAdded,
+			BlockId:  n.Id,
+			Type:     n.Type,
+			OldIndex: -1,
+			NewIndex: n.index,
+			NewText:  n.PlainText(),
+		})
+	}
+
+	return changes
+}
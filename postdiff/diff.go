@@ -0,0 +1,235 @@
+package postdiff
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postbody"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Post holds the post fields compared by Compare
+type Post struct {
+	Title      string
+	Slug       string
+	Desc       string
+	Tags       []string
+	Topic      string
+	CoverImage *models.PostCoverImage
+	Related    []string
+	License    string
+	Format     string
+	Body       bson.M
+}
+
+// snapshot is the exported JSON shape of post document or post object view
+type snapshot struct {
+	Title        string                 `json:"title"`
+	Slug         string                 `json:"slug"`
+	Desc         string                 `json:"desc"`
+	Tags         []string               `json:"tags"`
+	Topic        string                 `json:"topic"`
+	CoverImage   *models.PostCoverImage `json:"coverImage"`
+	Related      []primitive.ObjectID   `json:"related"`
+	RelatedPosts []struct {
+		Id primitive.ObjectID `json:"_id"`
+	} `json:"relatedPosts"`
+	License string `json:"license"`
+	Format  string `json:"format"`
+	Body    bson.M `json:"body"`
+}
+
+// hexIds returns hex form of given object ids
+func hexIds(ids []primitive.ObjectID) []string {
+	hex := make([]string, len(ids))
+
+	for i, id := range ids {
+		hex[i] = id.Hex()
+	}
+	return hex
+}
+
+// FromDocument returns comparable fields of stored post document
+func FromDocument(doc *models.PostDocument) *Post {
+	return &Post{
+		Title:      doc.Title,
+		Slug:       doc.Slug,
+		Desc:       doc.Desc,
+		Tags:       doc.Tags,
+		Topic:      doc.Topic,
+		CoverImage: doc.CoverImage,
+		Related:    hexIds(doc.Related),
+		License:    doc.License,
+		Format:     doc.Format,
+		Body:       doc.Body,
+	}
+}
+
+// FromUpdatePayload returns comparable fields of update payload
+func FromUpdatePayload(payload *types.UpdatePostPayload) (*Post, error) {
+	related, err := util.ParsePostIds(payload.RelatedPosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Post{
+		Title: payload.Title,
+		Slug:  payload.Slug,
+		Desc:  payload.Desc,
+		Tags:  payload.Tags,
+		Topic: payload.Topic,
+		CoverImage: &models.PostCoverImage{
+			Id:      payload.CoverImageId,
+			Path:    payload.CoverImagePath,
+			RefName: payload.CoverImageRefName,
+			RefUrl:  payload.CoverImageRefUrl,
+		},
+		Related: hexIds(related),
+		License: payload.License,
+		Body:    payload.Body,
+	}, nil
+}
+
+// FromSnapshot returns comparable fields of exported post JSON,
+// both post document and post object view shapes are accepted
+func FromSnapshot(data []byte) (*Post, error) {
+	var snap snapshot
+
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid post snapshot: %w", err)
+	}
+
+	related := snap.Related
+	for _, rp := range snap.RelatedPosts {
+		related = append(related, rp.Id)
+	}
+
+	return &Post{
+		Title:      snap.Title,
+		Slug:       snap.Slug,
+		Desc:       snap.Desc,
+		Tags:       snap.Tags,
+		Topic:      snap.Topic,
+		CoverImage: snap.CoverImage,
+		Related:    hexIds(related),
+		License:    snap.License,
+		Format:     snap.Format,
+		Body:       snap.Body,
+	}, nil
+}
+
+// diffList returns values present only in a
+func diffList(a, b []string) (only []string) {
+	set := make(map[string]bool, len(b))
+
+	for _, v := range b {
+		set[v] = true
+	}
+
+	for _, v := range a {
+		if !set[v] {
+			only = append(only, v)
+		}
+	}
+	return only
+}
+
+// equalList reports whether lists hold same values in same order
+func equalList(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalCoverImage treats missing cover image as empty one
+func equalCoverImage(a, b *models.PostCoverImage) bool {
+	if a == nil {
+		a = &models.PostCoverImage{}
+	}
+
+	if b == nil {
+		b = &models.PostCoverImage{}
+	}
+	return *a == *b
+}
+
+// Compare returns field and block level changes turning old post into new post
+func Compare(old, new *Post) (*types.PostDiff, error) {
+	var (
+		diff    = &types.PostDiff{Fields: []types.FieldChange{}, Blocks: []types.BlockChange{}}
+		oldBody *editorjs.Document
+		newBody *editorjs.Document
+		err     error
+	)
+
+	text := func(field, a, b string) {
+		if a != b {
+			diff.Fields = append(diff.Fields, types.FieldChange{Field: field, Old: a, New: b})
+		}
+	}
+
+	list := func(field string, a, b []string) {
+		if !equalList(a, b) {
+			diff.Fields = append(diff.Fields, types.FieldChange{
+				Field:   field,
+				Old:     a,
+				New:     b,
+				Added:   diffList(b, a),
+				Removed: diffList(a, b),
+			})
+		}
+	}
+
+	text("title", old.Title, new.Title)
+	text("slug", old.Slug, new.Slug)
+	text("desc", old.Desc, new.Desc)
+	list("tags", old.Tags, new.Tags)
+	text("topic", old.Topic, new.Topic)
+
+	if !equalCoverImage(old.CoverImage, new.CoverImage) {
+		diff.Fields = append(diff.Fields, types.FieldChange{Field: "coverImage", Old: old.CoverImage, New: new.CoverImage})
+	}
+
+	list("related", old.Related, new.Related)
+	text("license", old.License, new.License)
+
+	// update payloads carry no format, their body keeps stored format
+	newFormat := new.Format
+	if newFormat == "" {
+		newFormat = old.Format
+	}
+
+	oldFormat, _ := postbody.Normalize(old.Format)
+	if newFormat, err = postbody.Normalize(newFormat); err != nil {
+		return nil, err
+	}
+
+	text("format", oldFormat, newFormat)
+
+	if oldBody, err = postbody.Parse(old.Format, old.Body); err != nil {
+		return nil, err
+	}
+
+	if newBody, err = postbody.Parse(newFormat, new.Body); err != nil {
+		return nil, err
+	}
+
+	if blocks := DiffBlocks(oldBody.Blocks, newBody.Blocks); blocks != nil {
+		diff.Blocks = blocks
+	}
+
+	diff.Changed = len(diff.Fields) > 0 || len(diff.Blocks) > 0
+	return diff, nil
+}
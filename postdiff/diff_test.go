@@ -0,0 +1,108 @@
+package postdiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func TestDiffText(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []types.TextChange
+	}{
+		{"equal", "same text", "same text", []types.TextChange{{Op: OpEqual, Text: "same text"}}},
+		{"both empty", "", "", nil},
+		{"insert word", "hello world", "hello big world", []types.TextChange{
+			{Op: OpEqual, Text: "hello "},
+			{Op: OpInsert, Text: "big "},
+			{Op: OpEqual, Text: "world"},
+		}},
+		{"replace word", "the cat sat", "the dog sat", []types.TextChange{
+			{Op: OpEqual, Text: "the "},
+			{Op: OpDelete, Text: "cat"},
+			{Op: OpInsert, Text: "dog"},
+			{Op: OpEqual, Text: " sat"},
+		}},
+		{"from empty", "", "new", []types.TextChange{{Op: OpInsert, Text: "new"}}},
+		{"to empty", "old", "", []types.TextChange{{Op: OpDelete, Text: "old"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffText(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffText(%q, %q) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffBlocks(t *testing.T) {
+	paragraph := func(id string, text string) editorjs.Block {
+		return editorjs.Block{Id: id, Type: "paragraph", Data: map[string]interface{}{"text": text}}
+	}
+
+	var (
+		a = paragraph("a", "first")
+		b = paragraph("b", "second")
+		c = paragraph("c", "third")
+	)
+
+	// change is summarized as kind, block id, old and new index
+	type change struct {
+		kind     string
+		id       string
+		oldIndex int
+		newIndex int
+	}
+
+	tests := []struct {
+		name string
+		old  []editorjs.Block
+		new  []editorjs.Block
+		want []change
+	}{
+		{"unchanged", []editorjs.Block{a, b}, []editorjs.Block{a, b}, nil},
+		{"added", []editorjs.Block{a}, []editorjs.Block{a, b}, []change{{BlockAdded, "b", -1, 1}}},
+		{"removed", []editorjs.Block{a, b}, []editorjs.Block{b}, []change{{BlockRemoved, "a", 0, -1}}},
+		{"modified", []editorjs.Block{a, b}, []editorjs.Block{a, paragraph("b", "second edited")}, []change{{BlockModified, "b", 1, 1}}},
+		{"moved", []editorjs.Block{a, b, c}, []editorjs.Block{c, a, b}, []change{{BlockMoved, "c", 2, 0}}},
+		{"moved and modified", []editorjs.Block{a, b, c}, []editorjs.Block{paragraph("c", "third edited"), a, b}, []change{
+			{BlockModified, "c", 2, 0},
+			{BlockMoved, "c", 2, 0},
+		}},
+		{"matched by content without ids", []editorjs.Block{paragraph("", "x"), paragraph("", "y")}, []editorjs.Block{paragraph("", "y")}, []change{{BlockRemoved, "", 0, -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []change
+			for _, c := range DiffBlocks(tt.old, tt.new) {
+				got = append(got, change{c.Kind, c.BlockId, c.OldIndex, c.NewIndex})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffBlocks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffBlocksText(t *testing.T) {
+	changes := DiffBlocks(
+		[]editorjs.Block{{Id: "a", Type: "paragraph", Data: map[string]interface{}{"text": "hello world"}}},
+		[]editorjs.Block{{Id: "a", Type: "paragraph", Data: map[string]interface{}{"text": "hello <b>big</b> world"}}},
+	)
+
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+
+	if changes[0].OldText != "hello world" || changes[0].NewText != "hello big world" {
+		t.Errorf("texts = %q, %q, want plain text of both versions", changes[0].OldText, changes[0].NewText)
+	}
+}
@@ -0,0 +1,111 @@
+package postdiff
+
+import (
+	"unicode"
+
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// maximum LCS table size before falling back to whole text replacement
+const maxTextCells = 4_000_000
+
+// Text change operations
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// tokenize splits text into alternating runs of word and non-word characters
+func tokenize(text string) (tokens []string) {
+	var (
+		start int
+		prev  = -1
+	)
+
+	for i, r := range text {
+		class := 0
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			class = 1
+		}
+
+		if prev != -1 && class != prev {
+			tokens = append(tokens, text[start:i])
+			start = i
+		}
+		prev = class
+	}
+
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+	return tokens
+}
+
+// appendChange merges consecutive changes of the same operation
+func appendChange(changes []types.TextChange, op string, text string) []types.TextChange {
+	if n := len(changes); n > 0 && changes[n-1].Op == op {
+		changes[n-1].Text += text
+		return changes
+	}
+	return append(changes, types.TextChange{Op: op, Text: text})
+}
+
+// DiffText returns word level changes turning old text into new text
+func DiffText(old, new string) (changes []types.TextChange) {
+	a, b := tokenize(old), tokenize(new)
+
+	if len(a)*len(b) > maxTextCells {
+		if old != "" {
+			changes = append(changes, types.TextChange{Op: OpDelete, Text: old})
+		}
+		if new != "" {
+			changes = append(changes, types.TextChange{Op: OpInsert, Text: new})
+		}
+		return changes
+	}
+
+	// lcs[i][j] holds LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			changes = appendChange(changes, OpEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = appendChange(changes, OpDelete, a[i])
+			i++
+		default:
+			changes = appendChange(changes, OpInsert, b[j])
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		changes = appendChange(changes, OpDelete, a[i])
+	}
+
+	for ; j < len(b); j++ {
+		changes = appendChange(changes, OpInsert, b[j])
+	}
+
+	return changes
+}
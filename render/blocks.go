@@ -0,0 +1,157 @@
+package render
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+)
+
+func renderHeader(out *strings.Builder, block *editorjs.Block) {
+	level := block.Int("level")
+	if level < 1 || level > 6 {
+		level = 2
+	}
+
+	tag := "h" + strconv.Itoa(level)
+	out.WriteString("<" + tag + ">" + Inline(block.String("text")) + "</" + tag + ">")
+}
+
+func renderParagraph(out *strings.Builder, block *editorjs.Block) {
+	text := block.String("text")
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	out.WriteString("<p>" + Inline(text) + "</p>")
+}
+
+// writeListItems writes items of list and their nested lists
+func writeListItems(out *strings.Builder, tag string, items []editorjs.ListItem) {
+	out.WriteString("<" + tag + ">")
+
+	for _, item := range items {
+		out.WriteString("<li>" + Inline(item.Content))
+
+		if len(item.Items) > 0 {
+			writeListItems(out, tag, item.Items)
+		}
+		out.WriteString("</li>")
+	}
+
+	out.WriteString("</" + tag + ">")
+}
+
+func renderList(out *strings.Builder, block *editorjs.Block) {
+	items := block.ListItems()
+	if len(items) == 0 {
+		return
+	}
+
+	tag := "ul"
+	if block.String("style") == "ordered" {
+		tag = "ol"
+	}
+
+	writeListItems(out, tag, items)
+}
+
+func renderCode(out *strings.Builder, block *editorjs.Block) {
+	out.WriteString("<pre><code>" + html.EscapeString(block.String("code")) + "</code></pre>")
+}
+
+func renderImage(out *strings.Builder, block *editorjs.Block) {
+	var (
+		url     = SafeUrl(block.ImageUrl())
+		caption = block.String("caption")
+		classes []string
+	)
+
+	if url == "" {
+		return
+	}
+
+	for _, flag := range []string{"withBorder", "withBackground", "stretched"} {
+		if block.Bool(flag) {
+			classes = append(classes, "image-"+strings.ToLower(strings.TrimPrefix(flag, "with")))
+		}
+	}
+
+	if len(classes) > 0 {
+		out.WriteString(`<figure class="` + strings.Join(classes, " ") + `">`)
+	} else {
+		out.WriteString("<figure>")
+	}
+
+	out.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(editorjs.StripTags(caption)) + `">`)
+
+	if strings.TrimSpace(caption) != "" {
+		out.WriteString("<figcaption>" + Inline(caption) + "</figcaption>")
+	}
+
+	out.WriteString("</figure>")
+}
+
+// writeTableRow writes single table row using given cell tag
+func writeTableRow(out *strings.Builder, cell string, row []string) {
+	out.WriteString("<tr>")
+
+	for _, content := range row {
+		out.WriteString("<" + cell + ">" + Inline(content) + "</" + cell + ">")
+	}
+
+	out.WriteString("</tr>")
+}
+
+func renderTable(out *strings.Builder, block *editorjs.Block) {
+	rows := block.TableContent()
+	if len(rows) == 0 {
+		return
+	}
+
+	out.WriteString("<table>")
+
+	if block.Bool("withHeadings") {
+		out.WriteString("<thead>")
+		writeTableRow(out, "th", rows[0])
+		out.WriteString("</thead>")
+		rows = rows[1:]
+	}
+
+	out.WriteString("<tbody>")
+	for _, row := range rows {
+		writeTableRow(out, "td", row)
+	}
+	out.WriteString("</tbody></table>")
+}
+
+func renderWarning(out *strings.Builder, block *editorjs.Block) {
+	title, message := block.String("title"), block.String("message")
+
+	out.WriteString(`<aside class="warning" role="note">`)
+
+	if strings.TrimSpace(title) != "" {
+		out.WriteString("<p><strong>" + Inline(title) + "</strong></p>")
+	}
+
+	if strings.TrimSpace(message) != "" {
+		out.WriteString("<p>" + Inline(message) + "</p>")
+	}
+
+	out.WriteString("</aside>")
+}
+
+func renderQuote(out *strings.Builder, block *editorjs.Block) {
+	out.WriteString("<blockquote><p>" + Inline(block.String("text")) + "</p>")
+
+	if caption := block.String("caption"); strings.TrimSpace(caption) != "" {
+		out.WriteString("<footer>" + Inline(caption) + "</footer>")
+	}
+
+	out.WriteString("</blockquote>")
+}
+
+func renderDelimiter(out *strings.Builder, block *editorjs.Block) {
+	out.WriteString("<hr>")
+}
@@ -0,0 +1,155 @@
+package render
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	inlineTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)\b([^<>]*)>`)
+	hrefPattern      = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// rawTextTags are dropped together with their content
+var rawTextTags = map[string]bool{"script": true, "style": true}
+
+// inlineTags maps inline markup produced by the editor to its safe output tag
+var inlineTags = map[string]string{
+	"b":      "strong",
+	"strong": "strong",
+	"i":      "em",
+	"em":     "em",
+	"u":      "u",
+	"s":      "s",
+	"mark":   "mark",
+	"code":   "code",
+	"sub":    "sub",
+	"sup":    "sup",
+	"a":      "a",
+}
+
+// escapeText escapes text that may already contain HTML entities
+func escapeText(text string) string {
+	return html.EscapeString(html.UnescapeString(text))
+}
+
+// SafeUrl returns given url when it uses an allowed scheme, empty string otherwise
+func SafeUrl(raw string) string {
+	url := strings.TrimSpace(html.UnescapeString(raw))
+	lower := strings.ToLower(url)
+
+	switch {
+	case url == "":
+		return ""
+	case strings.HasPrefix(lower, "http://"),
+		strings.HasPrefix(lower, "https://"),
+		strings.HasPrefix(lower, "mailto:"),
+		strings.HasPrefix(url, "/"),
+		strings.HasPrefix(url, "#"):
+		return url
+	case strings.ContainsAny(url, ":"):
+		// any other scheme such as javascript: or data:
+		return ""
+	default:
+		return url
+	}
+}
+
+// openTag returns safe opening markup of inline tag
+func openTag(tag string, attrs string) string {
+	switch tag {
+	case "a":
+		var href string
+
+		if m := hrefPattern.FindStringSubmatch(attrs); m != nil {
+			href = SafeUrl(m[1] + m[2] + m[3])
+		}
+
+		if href == "" {
+			return "<a>"
+		}
+		return `<a href="` + html.EscapeString(href) + `" rel="noopener noreferrer">`
+
+	case "code":
+		return `<code class="inline-code">`
+
+	default:
+		return "<" + tag + ">"
+	}
+}
+
+// Inline converts inline markup of block text into safe HTML
+// Formatting tags used by the editor are kept, every other tag is dropped
+// while its text content stays (except scripts and styles), and unclosed
+// tags are closed
+func Inline(text string) string {
+	var (
+		out   strings.Builder
+		stack []string
+		last  int
+		skip  string
+	)
+
+	for _, m := range inlineTagPattern.FindAllStringSubmatchIndex(text, -1) {
+		closing := m[3] > m[2]
+		name := strings.ToLower(text[m[4]:m[5]])
+
+		if skip != "" {
+			if closing && name == skip {
+				skip = ""
+				last = m[1]
+			}
+			continue
+		}
+
+		out.WriteString(escapeText(text[last:m[0]]))
+		last = m[1]
+
+		if rawTextTags[name] {
+			if !closing {
+				skip = name
+			}
+			continue
+		}
+
+		if name == "br" {
+			out.WriteString("<br>")
+			continue
+		}
+
+		tag, ok := inlineTags[name]
+		if !ok {
+			continue
+		}
+
+		if !closing {
+			stack = append(stack, tag)
+			out.WriteString(openTag(tag, text[m[6]:m[7]]))
+			continue
+		}
+
+		// close up to the matching open tag, ignore stray closing tags
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i] != tag {
+				continue
+			}
+
+			for j := len(stack) - 1; j >= i; j-- {
+				out.WriteString("</" + stack[j] + ">")
+			}
+			stack = stack[:i]
+			break
+		}
+	}
+
+	if skip == "" {
+		out.WriteString(escapeText(text[last:]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		out.WriteString("</" + stack[i] + ">")
+	}
+
+	return out.String()
+}
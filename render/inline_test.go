@@ -0,0 +1,33 @@
+package render
+
+import "testing"
+
+func TestInline(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "Hello world", "Hello world"},
+		{"escapes text", "a < b & c", "a &lt; b &amp; c"},
+		{"keeps entities", "Tom &amp; Jerry", "Tom &amp; Jerry"},
+		{"formatting", "<b>bold</b> and <i>italic</i>", "<strong>bold</strong> and <em>italic</em>"},
+		{"inline code", "<code>x := 1</code>", `<code class="inline-code">x := 1</code>`},
+		{"line break", "one<br/>two", "one<br>two"},
+		{"safe link", `<a href="https://example.com">site</a>`, `<a href="https://example.com" rel="noopener noreferrer">site</a>`},
+		{"javascript link", `<a href="javascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"unknown tag keeps text", `<span style="color:red">red</span>`, "red"},
+		{"script dropped", "a<script>alert(1)</script>b", "ab"},
+		{"unclosed tag", "<b>bold", "<strong>bold</strong>"},
+		{"stray closing tag", "text</i>", "text"},
+		{"misnested tags", "<b><i>both</b> rest", "<strong><em>both</em></strong> rest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Inline(tt.text); got != tt.want {
+				t.Errorf("Inline(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
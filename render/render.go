@@ -0,0 +1,103 @@
+// Package render converts Editor.js post bodies into semantic HTML
+package render
+
+import (
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BlockRenderer writes HTML of single block
+type BlockRenderer func(out *strings.Builder, block *editorjs.Block)
+
+// Renderer renders Editor.js documents with a set of block renderers
+type Renderer struct {
+	blocks map[string]BlockRenderer
+
+	// Fallback renders blocks without registered renderer
+	Fallback BlockRenderer
+}
+
+// NewRenderer creates new instance of Renderer supporting every block type
+// of the post editor, unknown blocks are skipped
+func NewRenderer() *Renderer {
+	return &Renderer{
+		blocks: map[string]BlockRenderer{
+			"header":    renderHeader,
+			"paragraph": renderParagraph,
+			"list":      renderList,
+			"code":      renderCode,
+			"image":     renderImage,
+			"table":     renderTable,
+			"warning":   renderWarning,
+			"quote":     renderQuote,
+			"delimiter": renderDelimiter,
+		},
+		Fallback: SkipBlock,
+	}
+}
+
+// Register sets renderer of given block type, replacing the built-in one
+func (r *Renderer) Register(blockType string, fn BlockRenderer) {
+	r.blocks[blockType] = fn
+}
+
+// Render returns HTML of given document, blocks are separated by new lines
+func (r *Renderer) Render(doc *editorjs.Document) string {
+	var out strings.Builder
+
+	for i := range doc.Blocks {
+		block := &doc.Blocks[i]
+		fn, ok := r.blocks[block.Type]
+
+		if !ok {
+			fn = r.Fallback
+		}
+
+		if fn == nil {
+			continue
+		}
+
+		start := out.Len()
+		fn(&out, block)
+
+		if out.Len() > start {
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.String()
+}
+
+// RenderBody returns HTML of stored post body
+func (r *Renderer) RenderBody(body bson.M) (string, error) {
+	doc, err := editorjs.Parse(body)
+	if err != nil {
+		return "", err
+	}
+	return r.Render(doc), nil
+}
+
+// SkipBlock is a fallback that renders nothing
+func SkipBlock(out *strings.Builder, block *editorjs.Block) {}
+
+// TextBlock is a fallback that renders readable text of block as paragraph
+func TextBlock(out *strings.Builder, block *editorjs.Block) {
+	if text := block.String("text"); text != "" {
+		out.WriteString("<p>" + Inline(text) + "</p>")
+	}
+}
+
+// defaultRenderer is shared by HTML and DocumentHTML
+var defaultRenderer = NewRenderer()
+
+// HTML returns HTML of stored post body using built-in block renderers
+func HTML(body bson.M) (string, error) {
+	return defaultRenderer.RenderBody(body)
+}
+
+// DocumentHTML returns HTML of parsed document using built-in block renderers
+func DocumentHTML(doc *editorjs.Document) string {
+	return defaultRenderer.Render(doc)
+}
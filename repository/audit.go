@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// AuditLogRepository describes storage of audit log entries
+type AuditLogRepository interface {
+	// EnsureIndexes creates indexes used by audit log queries
+	EnsureIndexes(ctx context.Context) error
+
+	// Insert writes new audit log entry
+	Insert(ctx context.Context, entry *models.AuditLogDocument) error
+
+	// Find returns entries matching given options, newest first
+	Find(ctx context.Context, params *types.GetAuditLogOptions) ([]models.AuditLogDocument, error)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// MemoryAuditLogRepository keeps audit log entries in process memory
+type MemoryAuditLogRepository struct {
+	mu      sync.RWMutex
+	entries []models.AuditLogDocument
+}
+
+// NewMemoryAuditLogRepository creates new empty instance of MemoryAuditLogRepository
+func NewMemoryAuditLogRepository() *MemoryAuditLogRepository {
+	return &MemoryAuditLogRepository{}
+}
+
+// EnsureIndexes does nothing for in-memory storage
+func (r *MemoryAuditLogRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// Insert writes new audit log entry
+func (r *MemoryAuditLogRepository) Insert(ctx context.Context, entry *models.AuditLogDocument) error {
+	r.mu.Lock()
+	r.entries = append(r.entries, *entry)
+	r.mu.Unlock()
+	return nil
+}
+
+// Find returns entries matching given options, newest first
+func (r *MemoryAuditLogRepository) Find(ctx context.Context, params *types.GetAuditLogOptions) (entries []models.AuditLogDocument, err error) {
+	var skipped int64
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// entries are appended in time order
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := r.entries[i]
+
+		switch {
+		case params.PostId != "" && entry.TargetId != params.PostId,
+			params.Actor != "" && entry.Actor != params.Actor,
+			params.Operation != "" && entry.Operation != params.Operation,
+			params.From != nil && entry.CreatedAt.Before(*params.From),
+			params.To != nil && entry.CreatedAt.After(*params.To):
+			continue
+		}
+
+		if skipped < params.Skip {
+			skipped++
+			continue
+		}
+
+		if params.Limit > 0 && int64(len(entries)) >= params.Limit {
+			break
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoAuditLogRepository stores entries in MongoDB "auditLog" collection
+type MongoAuditLogRepository struct{}
+
+// NewMongoAuditLogRepository creates new instance of MongoAuditLogRepository
+func NewMongoAuditLogRepository() *MongoAuditLogRepository {
+	return &MongoAuditLogRepository{}
+}
+
+// EnsureIndexes creates indexes on time, target, actor and operation of entries
+func (r *MongoAuditLogRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := db.
+		MongoDb().
+		Collection("auditLog").
+		Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "createdAt", Value: -1}}},
+			{Keys: bson.D{{Key: "targetId", Value: 1}, {Key: "createdAt", Value: -1}}},
+			{Keys: bson.D{{Key: "actor", Value: 1}, {Key: "createdAt", Value: -1}}},
+			{Keys: bson.D{{Key: "operation", Value: 1}, {Key: "createdAt", Value: -1}}},
+		})
+	return err
+}
+
+// Insert writes new audit log entry
+func (r *MongoAuditLogRepository) Insert(ctx context.Context, entry *models.AuditLogDocument) error {
+	_, err := db.MongoDb().Collection("auditLog").InsertOne(ctx, entry)
+	return err
+}
+
+// Find returns entries matching given options, newest first
+func (r *MongoAuditLogRepository) Find(ctx context.Context, params *types.GetAuditLogOptions) (entries []models.AuditLogDocument, err error) {
+	var (
+		cur      *mongo.Cursor
+		filter   = bson.D{}
+		created  = bson.D{}
+		findOpts = options.
+				Find().
+				SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+	)
+
+	if params.PostId != "" {
+		filter = append(filter, bson.E{Key: "targetId", Value: params.PostId})
+	}
+
+	if params.Actor != "" {
+		filter = append(filter, bson.E{Key: "actor", Value: params.Actor})
+	}
+
+	if params.Operation != "" {
+		filter = append(filter, bson.E{Key: "operation", Value: params.Operation})
+	}
+
+	if params.From != nil {
+		created = append(created, bson.E{Key: "$gte", Value: *params.From})
+	}
+
+	if params.To != nil {
+		created = append(created, bson.E{Key: "$lte", Value: *params.To})
+	}
+
+	if len(created) > 0 {
+		filter = append(filter, bson.E{Key: "createdAt", Value: created})
+	}
+
+	if params.Limit > 0 {
+		findOpts.SetLimit(params.Limit)
+	}
+
+	if params.Skip > 0 {
+		findOpts.SetSkip(params.Skip)
+	}
+
+	if cur, err = db.
+		MongoDb().
+		Collection("auditLog").
+		Find(ctx, filter, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuthorRepository describes storage operations required by AuthorService
+type AuthorRepository interface {
+	// FindAll returns every author sorted by name
+	FindAll(ctx context.Context) ([]models.AuthorDocument, error)
+
+	// Insert writes new author document
+	Insert(ctx context.Context, author *models.AuthorDocument) error
+
+	// Update sets given fields of author document by id, reports whether author exists
+	Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (bool, error)
+
+	// Delete removes author document by id, reports whether author existed
+	Delete(ctx context.Context, id primitive.ObjectID) (bool, error)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryAuthorRepository keeps authors in process memory
+type MemoryAuthorRepository struct {
+	mu      sync.RWMutex
+	authors map[primitive.ObjectID]*models.AuthorDocument
+}
+
+// NewMemoryAuthorRepository creates new empty instance of MemoryAuthorRepository
+func NewMemoryAuthorRepository() *MemoryAuthorRepository {
+	return &MemoryAuthorRepository{
+		authors: make(map[primitive.ObjectID]*models.AuthorDocument),
+	}
+}
+
+// cloneAuthor returns deep copy of given author document, applying fields over it same as $set does
+func cloneAuthor(author *models.AuthorDocument, fields bson.M) (*models.AuthorDocument, error) {
+	var (
+		raw   []byte
+		doc   bson.M
+		clone *models.AuthorDocument
+		err   error
+	)
+
+	if raw, err = bson.Marshal(author); err != nil {
+		return nil, err
+	}
+
+	if err = bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for key, value := range fields {
+		doc[key] = value
+	}
+
+	if raw, err = bson.Marshal(doc); err != nil {
+		return nil, err
+	}
+
+	if err = bson.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// FindAll returns every author sorted by name
+func (r *MemoryAuthorRepository) FindAll(ctx context.Context) ([]models.AuthorDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := make([]models.AuthorDocument, 0, len(r.authors))
+	for _, author := range r.authors {
+		clone, err := cloneAuthor(author, nil)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, *clone)
+	}
+
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Name != authors[j].Name {
+			return authors[i].Name < authors[j].Name
+		}
+		return authors[i].Id.Hex() < authors[j].Id.Hex()
+	})
+
+	return authors, nil
+}
+
+// Insert writes new author document
+func (r *MemoryAuthorRepository) Insert(ctx context.Context, author *models.AuthorDocument) error {
+	clone, err := cloneAuthor(author, nil)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.authors[clone.Id]; exists {
+		return duplicateKeyError("_id")
+	}
+
+	r.authors[clone.Id] = clone
+	return nil
+}
+
+// Update sets given fields of author document by id, reports whether author exists
+func (r *MemoryAuthorRepository) Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	author, ok := r.authors[id]
+	if !ok {
+		return false, nil
+	}
+
+	updated, err := cloneAuthor(author, fields)
+	if err != nil {
+		return false, err
+	}
+
+	r.authors[id] = updated
+	return true, nil
+}
+
+// Delete removes author document by id, reports whether author existed
+func (r *MemoryAuthorRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.authors[id]; !ok {
+		return false, nil
+	}
+
+	delete(r.authors, id)
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoAuthorRepository stores authors in MongoDB "authors" collection
+type MongoAuthorRepository struct{}
+
+// NewMongoAuthorRepository creates new instance of MongoAuthorRepository
+func NewMongoAuthorRepository() *MongoAuthorRepository {
+	return &MongoAuthorRepository{}
+}
+
+// FindAll returns every author sorted by name
+func (r *MongoAuthorRepository) FindAll(ctx context.Context) (authors []models.AuthorDocument, err error) {
+	var (
+		cur      *mongo.Cursor
+		findOpts = options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	)
+
+	if cur, err = db.MongoDb().Collection("authors").Find(ctx, bson.D{}, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &authors); err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// Insert writes new author document
+func (r *MongoAuthorRepository) Insert(ctx context.Context, author *models.AuthorDocument) error {
+	_, err := db.MongoDb().Collection("authors").InsertOne(ctx, author)
+	return err
+}
+
+// Update sets given fields of author document by id, reports whether author exists
+func (r *MongoAuthorRepository) Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (bool, error) {
+	res, err := db.
+		MongoDb().
+		Collection("authors").
+		UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.M{"$set": fields})
+
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// Delete removes author document by id, reports whether author existed
+func (r *MongoAuthorRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	res, err := db.
+		MongoDb().
+		Collection("authors").
+		DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
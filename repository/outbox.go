@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IndexOutboxRepository describes storage of pending search index updates,
+// holding at most one entry per post
+type IndexOutboxRepository interface {
+	// EnsureIndexes creates unique post id index and index on next attempt time
+	EnsureIndexes(ctx context.Context) error
+
+	// Enqueue adds entry of post or resets existing one with given action,
+	// version is incremented so a concurrent worker keeps the entry
+	Enqueue(ctx context.Context, postId primitive.ObjectID, action string, at time.Time) error
+
+	// FindByPost returns entry of given post
+	FindByPost(ctx context.Context, postId primitive.ObjectID) (*models.IndexOutboxDocument, error)
+
+	// FindDue returns entries due at given time, oldest first, limit 0 means no limit
+	FindDue(ctx context.Context, at time.Time, limit int64) ([]models.IndexOutboxDocument, error)
+
+	// FindFailing returns entries having at least given number of failed attempts, oldest first
+	FindFailing(ctx context.Context, minAttempts int) ([]models.IndexOutboxDocument, error)
+
+	// Fail records failed attempt and time of next attempt unless entry
+	// was enqueued again after given version was read
+	Fail(ctx context.Context, id primitive.ObjectID, version int64, lastError string, next time.Time, at time.Time) error
+
+	// Complete removes entry unless it was enqueued again after given version was read
+	Complete(ctx context.Context, id primitive.ObjectID, version int64) (bool, error)
+}
+
+// Transactor runs storage writes atomically
+type Transactor interface {
+	// Run calls fn with context whose writes are committed together
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+}
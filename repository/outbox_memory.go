@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryIndexOutboxRepository keeps pending index updates in process memory
+type MemoryIndexOutboxRepository struct {
+	mu      sync.RWMutex
+	entries []*models.IndexOutboxDocument
+}
+
+// NewMemoryIndexOutboxRepository creates new empty instance of MemoryIndexOutboxRepository
+func NewMemoryIndexOutboxRepository() *MemoryIndexOutboxRepository {
+	return &MemoryIndexOutboxRepository{}
+}
+
+// EnsureIndexes does nothing, entries are kept one per post by Enqueue
+func (r *MemoryIndexOutboxRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// Enqueue adds entry of post or resets existing one with given action
+func (r *MemoryIndexOutboxRepository) Enqueue(ctx context.Context, postId primitive.ObjectID, action string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.PostId == postId {
+			entry.Action = action
+			entry.Attempts = 0
+			entry.NextAttemptAt = at
+			entry.UpdatedAt = at
+			entry.Version++
+			return nil
+		}
+	}
+
+	r.entries = append(r.entries, &models.IndexOutboxDocument{
+		Id:            primitive.NewObjectID(),
+		PostId:        postId,
+		Action:        action,
+		Version:       1,
+		NextAttemptAt: at,
+		CreatedAt:     at,
+		UpdatedAt:     at,
+	})
+	return nil
+}
+
+// FindByPost returns entry of given post
+func (r *MemoryIndexOutboxRepository) FindByPost(ctx context.Context, postId primitive.ObjectID) (*models.IndexOutboxDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		if entry.PostId == postId {
+			clone := *entry
+			return &clone, nil
+		}
+	}
+
+	return nil, mongo.ErrNoDocuments
+}
+
+// find returns entries accepted by given function, oldest first
+func (r *MemoryIndexOutboxRepository) find(accept func(entry *models.IndexOutboxDocument) bool, limit int64) (entries []models.IndexOutboxDocument) {
+	r.mu.RLock()
+	for _, entry := range r.entries {
+		if accept(entry) {
+			entries = append(entries, *entry)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].Id.Hex() < entries[j].Id.Hex()
+	})
+
+	if limit > 0 && int64(len(entries)) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// FindDue returns entries due at given time, oldest first
+func (r *MemoryIndexOutboxRepository) FindDue(ctx context.Context, at time.Time, limit int64) ([]models.IndexOutboxDocument, error) {
+	return r.find(func(entry *models.IndexOutboxDocument) bool {
+		return !entry.NextAttemptAt.After(at)
+	}, limit), nil
+}
+
+// FindFailing returns entries having at least given number of failed attempts, oldest first
+func (r *MemoryIndexOutboxRepository) FindFailing(ctx context.Context, minAttempts int) ([]models.IndexOutboxDocument, error) {
+	return r.find(func(entry *models.IndexOutboxDocument) bool {
+		return entry.Attempts >= minAttempts
+	}, 0), nil
+}
+
+// Fail records failed attempt and time of next attempt unless entry was enqueued again
+func (r *MemoryIndexOutboxRepository) Fail(ctx context.Context, id primitive.ObjectID, version int64, lastError string, next time.Time, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.Id == id && entry.Version == version {
+			entry.Attempts++
+			entry.LastError = lastError
+			entry.NextAttemptAt = next
+			entry.UpdatedAt = at
+			return nil
+		}
+	}
+	return nil
+}
+
+// Complete removes entry unless it was enqueued again after given version was read
+func (r *MemoryIndexOutboxRepository) Complete(ctx context.Context, id primitive.ObjectID, version int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.entries {
+		if entry.Id == id && entry.Version == version {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MemoryTransactor runs writes of in-memory storage, which has no transactions
+// Writes are applied one by one and are not rolled back when a later one fails,
+// so a failed post change may leave its revision or slug redirect behind
+type MemoryTransactor struct{}
+
+// NewMemoryTransactor creates new instance of MemoryTransactor
+func NewMemoryTransactor() *MemoryTransactor {
+	return &MemoryTransactor{}
+}
+
+// Run calls fn with given context, without rollback on error
+func (t *MemoryTransactor) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
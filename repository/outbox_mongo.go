@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoIndexOutboxRepository stores pending index updates in MongoDB "indexOutbox" collection
+type MongoIndexOutboxRepository struct{}
+
+// NewMongoIndexOutboxRepository creates new instance of MongoIndexOutboxRepository
+func NewMongoIndexOutboxRepository() *MongoIndexOutboxRepository {
+	return &MongoIndexOutboxRepository{}
+}
+
+// EnsureIndexes creates unique post id index and index on next attempt time
+func (r *MongoIndexOutboxRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := db.
+		MongoDb().
+		Collection("indexOutbox").
+		Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "postId", Value: 1}},
+				Options: options.Index().SetName("postId_unique").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "nextAttemptAt", Value: 1}},
+				Options: options.Index().SetName("nextAttemptAt"),
+			},
+		})
+	return err
+}
+
+// Enqueue adds entry of post or resets existing one with given action
+func (r *MongoIndexOutboxRepository) Enqueue(ctx context.Context, postId primitive.ObjectID, action string, at time.Time) error {
+	_, err := db.
+		MongoDb().
+		Collection("indexOutbox").
+		UpdateOne(ctx, bson.D{{Key: "postId", Value: postId}}, bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "action", Value: action},
+				{Key: "attempts", Value: 0},
+				{Key: "nextAttemptAt", Value: at},
+				{Key: "updatedAt", Value: at},
+			}},
+			{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+			{Key: "$setOnInsert", Value: bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "createdAt", Value: at},
+			}},
+		}, options.Update().SetUpsert(true))
+	return err
+}
+
+// FindByPost returns entry of given post
+func (r *MongoIndexOutboxRepository) FindByPost(ctx context.Context, postId primitive.ObjectID) (entry *models.IndexOutboxDocument, err error) {
+	if err = db.
+		MongoDb().
+		Collection("indexOutbox").
+		FindOne(ctx, bson.D{{Key: "postId", Value: postId}}).
+		Decode(&entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// find returns entries matching given filter, oldest first
+func (r *MongoIndexOutboxRepository) find(ctx context.Context, filter bson.D, limit int64) (entries []models.IndexOutboxDocument, err error) {
+	var (
+		cur      *mongo.Cursor
+		findOpts = options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+	)
+
+	if limit > 0 {
+		findOpts.SetLimit(limit)
+	}
+
+	if cur, err = db.
+		MongoDb().
+		Collection("indexOutbox").
+		Find(ctx, filter, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// FindDue returns entries due at given time, oldest first
+func (r *MongoIndexOutboxRepository) FindDue(ctx context.Context, at time.Time, limit int64) ([]models.IndexOutboxDocument, error) {
+	return r.find(ctx, bson.D{{Key: "nextAttemptAt", Value: bson.D{{Key: "$lte", Value: at}}}}, limit)
+}
+
+// FindFailing returns entries having at least given number of failed attempts, oldest first
+func (r *MongoIndexOutboxRepository) FindFailing(ctx context.Context, minAttempts int) ([]models.IndexOutboxDocument, error) {
+	return r.find(ctx, bson.D{{Key: "attempts", Value: bson.D{{Key: "$gte", Value: minAttempts}}}}, 0)
+}
+
+// Fail records failed attempt and time of next attempt unless entry was enqueued again
+func (r *MongoIndexOutboxRepository) Fail(ctx context.Context, id primitive.ObjectID, version int64, lastError string, next time.Time, at time.Time) error {
+	_, err := db.
+		MongoDb().
+		Collection("indexOutbox").
+		UpdateOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "version", Value: version}}, bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "lastError", Value: lastError},
+				{Key: "nextAttemptAt", Value: next},
+				{Key: "updatedAt", Value: at},
+			}},
+			{Key: "$inc", Value: bson.D{{Key: "attempts", Value: 1}}},
+		})
+	return err
+}
+
+// Complete removes entry unless it was enqueued again after given version was read
+func (r *MongoIndexOutboxRepository) Complete(ctx context.Context, id primitive.ObjectID, version int64) (bool, error) {
+	res, err := db.
+		MongoDb().
+		Collection("indexOutbox").
+		DeleteOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "version", Value: version}})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
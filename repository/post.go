@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PostRepository describes storage operations required by PostService
+type PostRepository interface {
+	// Ping checks whether storage is reachable
+	Ping(ctx context.Context) error
+
+	// EnsureIndexes creates indexes required by posts, including unique slug index
+	EnsureIndexes(ctx context.Context) error
+
+	// FindById returns raw post document by given id
+	FindById(ctx context.Context, id primitive.ObjectID) (*models.PostDocument, error)
+
+	// FindMetadataById returns public or private post metadata by given id
+	FindMetadataById(ctx context.Context, id primitive.ObjectID, private bool) (*models.PostMetadataDocument, error)
+
+	// FindObjectById returns post object view by given id
+	FindObjectById(ctx context.Context, id primitive.ObjectID) (*models.PostObjectView, error)
+
+	// FindMetadata returns list of post metadata matching given options
+	FindMetadata(ctx context.Context, params *types.GetPostsMetadataOptions) ([]models.PostMetadataDocument, error)
+
+	// FindAll returns every post document including private and deleted ones
+	FindAll(ctx context.Context) ([]models.PostDocument, error)
+
+	// FindScheduled returns non-deleted posts having publish or unpublish time set
+	FindScheduled(ctx context.Context) ([]models.PostDocument, error)
+
+	// Count returns number of posts with given public and deleted flags
+	Count(ctx context.Context, public bool, deleted bool) (int64, error)
+
+	// CountPublicByTopic returns number of public posts of given topic
+	CountPublicByTopic(ctx context.Context, topic string) (int64, error)
+
+	// CountByAuthor returns number of posts written by given author, including deleted ones
+	CountByAuthor(ctx context.Context, authorId primitive.ObjectID) (int64, error)
+
+	// CountBySlug returns number of posts having given slug, except post by given id
+	CountBySlug(ctx context.Context, slug string, except primitive.ObjectID) (int64, error)
+
+	// Insert writes new post document
+	Insert(ctx context.Context, post *models.PostDocument) (*mongo.InsertOneResult, error)
+
+	// Update sets given fields of post document by id
+	Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (*mongo.UpdateResult, error)
+
+	// Replace overwrites whole post document having same id
+	Replace(ctx context.Context, post *models.PostDocument) (*mongo.UpdateResult, error)
+}
+
+// metadataAuthor returns author id of given options, zero when posts of every author are listed
+func metadataAuthor(params *types.GetPostsMetadataOptions) (primitive.ObjectID, error) {
+	if params.AuthorId == "" {
+		return primitive.NilObjectID, nil
+	}
+	return primitive.ObjectIDFromHex(params.AuthorId)
+}
+
+// metadataSortSpec returns sort property and order for given sortBy option
+func metadataSortSpec(sortBy string) (prop string, order int) {
+	switch sortBy {
+	case "title":
+		return "title", 1
+	case "topic":
+		return "topic", 1
+	case "newest":
+		return "createdAt", -1
+	case "oldest":
+		return "createdAt", 1
+	case "updated":
+		return "updatedAt", -1
+	default:
+		return "", 0
+	}
+}
@@ -0,0 +1,460 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryPostRepository keeps posts in process memory,
+// useful for tests and throwaway sessions
+type MemoryPostRepository struct {
+	mu    sync.RWMutex
+	posts map[primitive.ObjectID]*models.PostDocument
+}
+
+// NewMemoryPostRepository creates new empty instance of MemoryPostRepository
+func NewMemoryPostRepository() *MemoryPostRepository {
+	return &MemoryPostRepository{
+		posts: make(map[primitive.ObjectID]*models.PostDocument),
+	}
+}
+
+// clonePost returns deep copy of given post document
+func clonePost(post *models.PostDocument) (*models.PostDocument, error) {
+	var (
+		raw   []byte
+		clone *models.PostDocument
+		err   error
+	)
+
+	if raw, err = bson.Marshal(post); err != nil {
+		return nil, err
+	}
+
+	if err = bson.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// duplicateKeyError returns write error reported by MongoDB on unique index violation
+func duplicateKeyError(key string) error {
+	return mongo.WriteException{
+		WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key error, key: " + key}},
+	}
+}
+
+// slugTaken reports whether slug belongs to post other than given id, caller must hold the lock
+func (r *MemoryPostRepository) slugTaken(slug string, except primitive.ObjectID) bool {
+	for _, post := range r.posts {
+		if post.Slug == slug && post.Id != except {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneCover returns copy of given cover image, nil stays nil
+func cloneCover(cover *models.PostCoverImage) *models.PostCoverImage {
+	if cover == nil {
+		return nil
+	}
+	clone := *cover
+	return &clone
+}
+
+// postMetadata returns metadata projection of given post document,
+// sharing no memory with the stored document
+func postMetadata(post *models.PostDocument) models.PostMetadataDocument {
+	var tags []string
+	if post.Tags != nil {
+		tags = append([]string{}, post.Tags...)
+	}
+
+	return models.PostMetadataDocument{
+		Id:         post.Id,
+		Title:      post.Title,
+		Slug:       post.Slug,
+		Desc:       post.Desc,
+		Tags:       tags,
+		Topic:      post.Topic,
+		Stars:      post.Stars,
+		Format:     post.Format,
+		AuthorId:   post.AuthorId,
+		License:    post.License,
+		CreatedAt:  post.CreatedAt,
+		UpdatedAt:  post.UpdatedAt,
+		CoverImage: cloneCover(post.CoverImage),
+	}
+}
+
+// compareTime returns -1, 0 or 1 by comparing given times
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// inScope reports whether post belongs to public or private metadata view
+func inScope(post *models.PostDocument, private bool) bool {
+	return !post.Deleted && post.Public != private
+}
+
+// Ping always succeeds for in-memory storage
+func (r *MemoryPostRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// EnsureIndexes does nothing, slug uniqueness is checked on every write
+func (r *MemoryPostRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// FindById returns raw post document by given id
+func (r *MemoryPostRepository) FindById(ctx context.Context, id primitive.ObjectID) (*models.PostDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if post, ok := r.posts[id]; ok {
+		return clonePost(post)
+	} else {
+		return nil, mongo.ErrNoDocuments
+	}
+}
+
+// FindMetadataById returns public or private post metadata by given id
+func (r *MemoryPostRepository) FindMetadataById(ctx context.Context, id primitive.ObjectID, private bool) (*models.PostMetadataDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if post, ok := r.posts[id]; ok && inScope(post, private) {
+		doc := postMetadata(post)
+		return &doc, nil
+	} else {
+		return nil, mongo.ErrNoDocuments
+	}
+}
+
+// FindObjectById returns post object view by given id
+func (r *MemoryPostRepository) FindObjectById(ctx context.Context, id primitive.ObjectID) (*models.PostObjectView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.posts[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	// view must not share tags, body or cover image with stored document
+	post, err := clonePost(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &models.PostObjectView{
+		Id:           post.Id,
+		Title:        post.Title,
+		Slug:         post.Slug,
+		Desc:         post.Desc,
+		Tags:         post.Tags,
+		Topic:        post.Topic,
+		Body:         post.Body,
+		Format:       post.Format,
+		Stars:        post.Stars,
+		Public:       post.Public,
+		CoverImage:   post.CoverImage,
+		AuthorId:     post.AuthorId,
+		License:      post.License,
+		RelatedPosts: []models.PostRelatedView{},
+		CreatedAt:    post.CreatedAt,
+		UpdatedAt:    post.UpdatedAt,
+	}
+
+	for _, rid := range post.Related {
+		if rp, ok := r.posts[rid]; ok {
+			view.RelatedPosts = append(view.RelatedPosts, models.PostRelatedView{
+				Id:         rp.Id,
+				Title:      rp.Title,
+				Slug:       rp.Slug,
+				Desc:       rp.Desc,
+				Format:     rp.Format,
+				Stars:      rp.Stars,
+				Public:     rp.Public,
+				CoverImage: cloneCover(rp.CoverImage),
+				AuthorId:   rp.AuthorId,
+				License:    rp.License,
+				CreatedAt:  rp.CreatedAt,
+				UpdatedAt:  rp.UpdatedAt,
+			})
+		}
+	}
+
+	return view, nil
+}
+
+// FindMetadata returns list of post metadata matching given options
+func (r *MemoryPostRepository) FindMetadata(ctx context.Context, params *types.GetPostsMetadataOptions) ([]models.PostMetadataDocument, error) {
+	var posts []models.PostMetadataDocument
+
+	authorId, err := metadataAuthor(params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	for _, post := range r.posts {
+		if !inScope(post, params.Private) {
+			continue
+		}
+
+		if params.Topic != "all" && post.Topic != params.Topic {
+			continue
+		}
+
+		if !authorId.IsZero() && post.AuthorId != authorId {
+			continue
+		}
+
+		posts = append(posts, postMetadata(post))
+	}
+	r.mu.RUnlock()
+
+	sortProp, sortOrder := metadataSortSpec(params.SortBy)
+	sort.SliceStable(posts, func(i, j int) bool {
+		var cmp int
+
+		switch sortProp {
+		case "title":
+			cmp = strings.Compare(posts[i].Title, posts[j].Title)
+		case "topic":
+			cmp = strings.Compare(posts[i].Topic, posts[j].Topic)
+		case "createdAt":
+			cmp = compareTime(posts[i].CreatedAt, posts[j].CreatedAt)
+		case "updatedAt":
+			cmp = compareTime(posts[i].UpdatedAt, posts[j].UpdatedAt)
+		}
+
+		// fallback to natural insertion order of object ids
+		if cmp == 0 {
+			return posts[i].Id.Hex() < posts[j].Id.Hex()
+		}
+		return cmp*sortOrder < 0
+	})
+
+	if params.Skip > 0 {
+		if params.Skip >= int64(len(posts)) {
+			return nil, nil
+		}
+		posts = posts[params.Skip:]
+	}
+
+	if params.Limit > 0 && params.Limit < int64(len(posts)) {
+		posts = posts[:params.Limit]
+	}
+
+	return posts, nil
+}
+
+// FindAll returns every post document including private and deleted ones
+func (r *MemoryPostRepository) FindAll(ctx context.Context) (posts []models.PostDocument, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		clone, err := clonePost(post)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, *clone)
+	}
+
+	// keep stable order like natural order of a collection
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Id.Hex() < posts[j].Id.Hex()
+	})
+
+	return posts, nil
+}
+
+// FindScheduled returns non-deleted posts having publish or unpublish time set
+func (r *MemoryPostRepository) FindScheduled(ctx context.Context) (posts []models.PostDocument, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		if !post.Deleted && (post.PublishAt != nil || post.UnpublishAt != nil) {
+			clone, err := clonePost(post)
+			if err != nil {
+				return nil, err
+			}
+			posts = append(posts, *clone)
+		}
+	}
+
+	return posts, nil
+}
+
+// Count returns number of posts with given public and deleted flags
+func (r *MemoryPostRepository) Count(ctx context.Context, public bool, deleted bool) (count int64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		if post.Public == public && post.Deleted == deleted {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountPublicByTopic returns number of public posts of given topic
+func (r *MemoryPostRepository) CountPublicByTopic(ctx context.Context, topic string) (count int64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		if inScope(post, false) && (topic == "all" || post.Topic == topic) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountByAuthor returns number of posts written by given author, including deleted ones
+func (r *MemoryPostRepository) CountByAuthor(ctx context.Context, authorId primitive.ObjectID) (count int64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		if post.AuthorId == authorId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountBySlug returns number of posts having given slug, except post by given id
+func (r *MemoryPostRepository) CountBySlug(ctx context.Context, slug string, except primitive.ObjectID) (count int64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		if post.Slug == slug && post.Id != except {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Insert writes new post document
+func (r *MemoryPostRepository) Insert(ctx context.Context, post *models.PostDocument) (*mongo.InsertOneResult, error) {
+	var (
+		clone *models.PostDocument
+		err   error
+	)
+
+	if clone, err = clonePost(post); err != nil {
+		return nil, err
+	}
+
+	if clone.Id.IsZero() {
+		clone.Id = primitive.NewObjectID()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.posts[clone.Id]; exists {
+		return nil, duplicateKeyError("_id")
+	}
+
+	if r.slugTaken(clone.Slug, clone.Id) {
+		return nil, duplicateKeyError("slug")
+	}
+
+	r.posts[clone.Id] = clone
+	return &mongo.InsertOneResult{InsertedID: clone.Id}, nil
+}
+
+// Update sets given fields of post document by id
+func (r *MemoryPostRepository) Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (*mongo.UpdateResult, error) {
+	var (
+		raw     []byte
+		doc     bson.M
+		updated *models.PostDocument
+		err     error
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[id]
+	if !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	// apply fields over the encoded document, same as $set does
+	if raw, err = bson.Marshal(post); err != nil {
+		return nil, err
+	}
+
+	if err = bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for key, value := range fields {
+		doc[key] = value
+	}
+
+	if raw, err = bson.Marshal(doc); err != nil {
+		return nil, err
+	}
+
+	if err = bson.Unmarshal(raw, &updated); err != nil {
+		return nil, err
+	}
+
+	if r.slugTaken(updated.Slug, id) {
+		return nil, duplicateKeyError("slug")
+	}
+
+	r.posts[id] = updated
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+// Replace overwrites whole post document having same id
+func (r *MemoryPostRepository) Replace(ctx context.Context, post *models.PostDocument) (*mongo.UpdateResult, error) {
+	clone, err := clonePost(post)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.posts[clone.Id]; !ok {
+		return &mongo.UpdateResult{}, nil
+	}
+
+	if r.slugTaken(clone.Slug, clone.Id) {
+		return nil, duplicateKeyError("slug")
+	}
+
+	r.posts[clone.Id] = clone
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
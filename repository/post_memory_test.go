@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// insertPost writes post with given fields into repo and fails test on error
+func insertPost(t *testing.T, repo *MemoryPostRepository, post models.PostDocument) primitive.ObjectID {
+	t.Helper()
+
+	if post.Id.IsZero() {
+		post.Id = primitive.NewObjectID()
+	}
+
+	if _, err := repo.Insert(context.Background(), &post); err != nil {
+		t.Fatalf("Insert(%q) error = %v", post.Slug, err)
+	}
+	return post.Id
+}
+
+func TestMemoryPostIsolation(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		repo    = NewMemoryPostRepository()
+		related = insertPost(t, repo, models.PostDocument{
+			Slug:       "related",
+			Public:     true,
+			CoverImage: &models.PostCoverImage{Id: "related-cover"},
+		})
+		id = insertPost(t, repo, models.PostDocument{
+			Slug:       "post",
+			Tags:       []string{"go"},
+			Body:       bson.M{"source": "text"},
+			Public:     true,
+			CoverImage: &models.PostCoverImage{Id: "cover"},
+			Related:    []primitive.ObjectID{related},
+		})
+	)
+
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T)
+	}{
+		{"find by id", func(t *testing.T) {
+			post, err := repo.FindById(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			post.Tags[0] = "changed"
+			post.Body["source"] = "changed"
+			post.CoverImage.Id = "changed"
+		}},
+		{"find object by id", func(t *testing.T) {
+			view, err := repo.FindObjectById(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			view.Tags[0] = "changed"
+			view.Body["source"] = "changed"
+			view.CoverImage.Id = "changed"
+			view.RelatedPosts[0].CoverImage.Id = "changed"
+		}},
+		{"find metadata by id", func(t *testing.T) {
+			doc, err := repo.FindMetadataById(ctx, id, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc.Tags[0] = "changed"
+			doc.CoverImage.Id = "changed"
+		}},
+		{"find metadata", func(t *testing.T) {
+			docs, err := repo.FindMetadata(ctx, &types.GetPostsMetadataOptions{Topic: "all"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range docs {
+				docs[i].Tags = append(docs[i].Tags[:0], "changed")
+				docs[i].CoverImage.Id = "changed"
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mutate(t)
+
+			post := repo.posts[id]
+			if post.Tags[0] != "go" || post.Body["source"] != "text" || post.CoverImage.Id != "cover" {
+				t.Errorf("stored post changed through returned value: %+v", post)
+			}
+
+			if repo.posts[related].CoverImage.Id != "related-cover" {
+				t.Errorf("stored related post changed through returned value")
+			}
+		})
+	}
+}
+
+func TestMemoryPostDuplicateSlug(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		repo  = NewMemoryPostRepository()
+		first = insertPost(t, repo, models.PostDocument{Slug: "taken"})
+		other = insertPost(t, repo, models.PostDocument{Slug: "free"})
+	)
+
+	tests := []struct {
+		name  string
+		write func() error
+	}{
+		{"insert", func() error {
+			_, err := repo.Insert(ctx, &models.PostDocument{Slug: "taken"})
+			return err
+		}},
+		{"insert same id", func() error {
+			_, err := repo.Insert(ctx, &models.PostDocument{Id: first, Slug: "another"})
+			return err
+		}},
+		{"update", func() error {
+			_, err := repo.Update(ctx, other, bson.M{"slug": "taken"})
+			return err
+		}},
+		{"replace", func() error {
+			_, err := repo.Replace(ctx, &models.PostDocument{Id: other, Slug: "taken"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.write(); !mongo.IsDuplicateKeyError(err) {
+				t.Errorf("error = %v, want duplicate key error", err)
+			}
+		})
+	}
+
+	if _, err := repo.Update(ctx, first, bson.M{"slug": "taken"}); err != nil {
+		t.Errorf("Update() keeping own slug error = %v", err)
+	}
+}
+
+func TestMemoryPostUpdate(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		repo = NewMemoryPostRepository()
+		id   = insertPost(t, repo, models.PostDocument{Slug: "post", Title: "Old", Tags: []string{"a"}})
+	)
+
+	res, err := repo.Update(ctx, id, bson.M{"title": "New", "tags": []string{"b", "c"}})
+	if err != nil || res.MatchedCount != 1 {
+		t.Fatalf("Update() = %+v, %v", res, err)
+	}
+
+	post, _ := repo.FindById(ctx, id)
+	if post.Title != "New" || post.Slug != "post" || len(post.Tags) != 2 || post.Tags[0] != "b" {
+		t.Errorf("updated post = %+v", post)
+	}
+
+	if res, err := repo.Update(ctx, primitive.NewObjectID(), bson.M{"title": "x"}); err != nil || res.MatchedCount != 0 {
+		t.Errorf("Update() of missing post = %+v, %v, want no match", res, err)
+	}
+}
+
+func TestMemoryPostFindMetadata(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		repo   = NewMemoryPostRepository()
+		author = primitive.NewObjectID()
+		base   = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	insertPost(t, repo, models.PostDocument{Slug: "b", Title: "B", Topic: "go", Public: true, AuthorId: author, CreatedAt: base.Add(2 * time.Hour)})
+	insertPost(t, repo, models.PostDocument{Slug: "a", Title: "A", Topic: "web", Public: true, CreatedAt: base.Add(time.Hour)})
+	insertPost(t, repo, models.PostDocument{Slug: "c", Title: "C", Topic: "go", Public: true, CreatedAt: base.Add(3 * time.Hour)})
+	insertPost(t, repo, models.PostDocument{Slug: "draft", Title: "D", Topic: "go", CreatedAt: base})
+	insertPost(t, repo, models.PostDocument{Slug: "gone", Title: "E", Topic: "go", Public: true, Deleted: true, CreatedAt: base})
+
+	tests := []struct {
+		name   string
+		params types.GetPostsMetadataOptions
+		want   []string
+	}{
+		{"title order", types.GetPostsMetadataOptions{Topic: "all", SortBy: "title"}, []string{"a", "b", "c"}},
+		{"newest", types.GetPostsMetadataOptions{Topic: "all", SortBy: "newest"}, []string{"c", "b", "a"}},
+		{"oldest", types.GetPostsMetadataOptions{Topic: "all", SortBy: "oldest"}, []string{"a", "b", "c"}},
+		{"topic", types.GetPostsMetadataOptions{Topic: "go", SortBy: "title"}, []string{"b", "c"}},
+		{"author", types.GetPostsMetadataOptions{Topic: "all", AuthorId: author.Hex()}, []string{"b"}},
+		{"private", types.GetPostsMetadataOptions{Topic: "all", Private: true}, []string{"draft"}},
+		{"skip and limit", types.GetPostsMetadataOptions{Topic: "all", SortBy: "title", Skip: 1, Limit: 1}, []string{"b"}},
+		{"skip past end", types.GetPostsMetadataOptions{Topic: "all", Skip: 5}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := repo.FindMetadata(ctx, &tt.params)
+			if err != nil {
+				t.Fatalf("FindMetadata() error = %v", err)
+			}
+
+			var got []string
+			for _, doc := range docs {
+				got = append(got, doc.Slug)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("FindMetadata() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("FindMetadata() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
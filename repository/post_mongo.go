@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoPostRepository stores posts in MongoDB "posts" collection
+// and reads metadata from its views
+type MongoPostRepository struct{}
+
+// NewMongoPostRepository creates new instance of MongoPostRepository
+func NewMongoPostRepository() *MongoPostRepository {
+	return &MongoPostRepository{}
+}
+
+// metadataCollection returns name of metadata view by given scope
+func metadataCollection(private bool) string {
+	if private {
+		return "privatePostsMetadata"
+	} else {
+		return "publicPostsMetadata"
+	}
+}
+
+// Ping checks whether MongoDB server is reachable
+func (r *MongoPostRepository) Ping(ctx context.Context) error {
+	return db.PingMongoDb(ctx)
+}
+
+// EnsureIndexes creates unique index on slug of posts collection
+func (r *MongoPostRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := db.
+		MongoDb().
+		Collection("posts").
+		Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetName("slug_unique").SetUnique(true),
+		})
+	return err
+}
+
+// FindById returns raw post document by given id
+func (r *MongoPostRepository) FindById(ctx context.Context, id primitive.ObjectID) (doc *models.PostDocument, err error) {
+	if err = db.
+		MongoDb().
+		Collection("posts").
+		FindOne(ctx, bson.D{{Key: "_id", Value: id}}).
+		Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// FindMetadataById returns public or private post metadata by given id
+func (r *MongoPostRepository) FindMetadataById(ctx context.Context, id primitive.ObjectID, private bool) (doc *models.PostMetadataDocument, err error) {
+	if err = db.
+		MongoDb().
+		Collection(metadataCollection(private)).
+		FindOne(ctx, bson.D{{Key: "_id", Value: id}}).
+		Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// FindObjectById returns post object view by given id
+func (r *MongoPostRepository) FindObjectById(ctx context.Context, id primitive.ObjectID) (doc *models.PostObjectView, err error) {
+	if err = db.
+		MongoDb().
+		Collection("postsObject").
+		FindOne(ctx, bson.D{{Key: "_id", Value: id}}).
+		Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// FindMetadata returns list of post metadata matching given options
+func (r *MongoPostRepository) FindMetadata(ctx context.Context, params *types.GetPostsMetadataOptions) ([]models.PostMetadataDocument, error) {
+	var (
+		posts    []models.PostMetadataDocument
+		findOpts = options.Find()
+		filter   = bson.D{}
+	)
+
+	// Use specific topic
+	if params.Topic != "all" {
+		filter = append(filter, bson.E{Key: "topic", Value: params.Topic})
+	}
+
+	// Use specific author
+	if authorId, err := metadataAuthor(params); err != nil {
+		return nil, err
+	} else if !authorId.IsZero() {
+		filter = append(filter, bson.E{Key: "authorId", Value: authorId})
+	}
+
+	if sortProp, sortOrder := metadataSortSpec(params.SortBy); sortProp != "" {
+		findOpts.SetSort(bson.D{{Key: sortProp, Value: sortOrder}})
+	}
+
+	findOpts.SetLimit(params.Limit)
+	findOpts.SetSkip(params.Skip)
+
+	cur, err := db.
+		MongoDb().
+		Collection(metadataCollection(params.Private)).
+		Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FindAll returns every post document including private and deleted ones
+func (r *MongoPostRepository) FindAll(ctx context.Context) (posts []models.PostDocument, err error) {
+	var cur *mongo.Cursor
+
+	if cur, err = db.MongoDb().Collection("posts").Find(ctx, bson.D{}); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// FindScheduled returns non-deleted posts having publish or unpublish time set
+func (r *MongoPostRepository) FindScheduled(ctx context.Context) (posts []models.PostDocument, err error) {
+	var (
+		cur      *mongo.Cursor
+		findOpts = options.Find().SetProjection(bson.D{{Key: "body", Value: 0}})
+		filter   = bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "publishAt", Value: bson.D{{Key: "$ne", Value: nil}}}},
+				bson.D{{Key: "unpublishAt", Value: bson.D{{Key: "$ne", Value: nil}}}},
+			}},
+		}
+	)
+
+	if cur, err = db.MongoDb().Collection("posts").Find(ctx, filter, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// Count returns number of posts with given public and deleted flags
+func (r *MongoPostRepository) Count(ctx context.Context, public bool, deleted bool) (int64, error) {
+	return db.
+		MongoDb().
+		Collection("posts").
+		CountDocuments(
+			ctx,
+			bson.D{
+				{Key: "public", Value: public},
+				{Key: "deleted", Value: deleted},
+			})
+}
+
+// CountPublicByTopic returns number of public posts of given topic
+func (r *MongoPostRepository) CountPublicByTopic(ctx context.Context, topic string) (int64, error) {
+	filter := bson.D{
+		{Key: "public", Value: true},
+		{Key: "deleted", Value: false},
+	}
+
+	if topic != "all" {
+		filter = append(filter, primitive.E{Key: "topic", Value: topic})
+	}
+
+	return db.MongoDb().Collection("posts").CountDocuments(ctx, filter)
+}
+
+// CountByAuthor returns number of posts written by given author, including deleted ones
+func (r *MongoPostRepository) CountByAuthor(ctx context.Context, authorId primitive.ObjectID) (int64, error) {
+	return db.
+		MongoDb().
+		Collection("posts").
+		CountDocuments(ctx, bson.D{{Key: "authorId", Value: authorId}})
+}
+
+// CountBySlug returns number of posts having given slug, except post by given id
+func (r *MongoPostRepository) CountBySlug(ctx context.Context, slug string, except primitive.ObjectID) (int64, error) {
+	return db.
+		MongoDb().
+		Collection("posts").
+		CountDocuments(
+			ctx,
+			bson.D{
+				{Key: "slug", Value: slug},
+				{Key: "_id", Value: bson.D{{Key: "$ne", Value: except}}},
+			})
+}
+
+// Insert writes new post document into posts collection
+func (r *MongoPostRepository) Insert(ctx context.Context, post *models.PostDocument) (*mongo.InsertOneResult, error) {
+	return db.MongoDb().Collection("posts").InsertOne(ctx, post)
+}
+
+// Update sets given fields of post document by id
+func (r *MongoPostRepository) Update(ctx context.Context, id primitive.ObjectID, fields bson.M) (*mongo.UpdateResult, error) {
+	return db.
+		MongoDb().
+		Collection("posts").
+		UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.M{"$set": fields})
+}
+
+// Replace overwrites whole post document having same id
+func (r *MongoPostRepository) Replace(ctx context.Context, post *models.PostDocument) (*mongo.UpdateResult, error) {
+	return db.
+		MongoDb().
+		Collection("posts").
+		ReplaceOne(ctx, bson.D{{Key: "_id", Value: post.Id}}, post)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SlugRedirectRepository describes storage of redirects from former post slugs
+type SlugRedirectRepository interface {
+	// EnsureIndexes creates indexes required by redirects, including unique source slug index
+	EnsureIndexes(ctx context.Context) error
+
+	// FindAll returns redirects of given post ordered by source slug, every redirect when post id is zero
+	FindAll(ctx context.Context, postId primitive.ObjectID) ([]models.SlugRedirectDocument, error)
+
+	// FindByFrom returns redirect by source slug
+	FindByFrom(ctx context.Context, from string) (*models.SlugRedirectDocument, error)
+
+	// Insert writes new redirect document
+	Insert(ctx context.Context, redirect *models.SlugRedirectDocument) error
+
+	// Retarget points every redirect of given post to new slug
+	Retarget(ctx context.Context, postId primitive.ObjectID, to string, at time.Time) error
+
+	// Delete removes redirect by id and returns number of removed documents
+	Delete(ctx context.Context, id primitive.ObjectID) (int64, error)
+
+	// DeleteByFrom removes redirect by source slug
+	DeleteByFrom(ctx context.Context, from string) error
+}
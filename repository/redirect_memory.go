@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemorySlugRedirectRepository keeps redirects in process memory
+type MemorySlugRedirectRepository struct {
+	mu        sync.RWMutex
+	redirects []*models.SlugRedirectDocument
+}
+
+// NewMemorySlugRedirectRepository creates new empty instance of MemorySlugRedirectRepository
+func NewMemorySlugRedirectRepository() *MemorySlugRedirectRepository {
+	return &MemorySlugRedirectRepository{}
+}
+
+// EnsureIndexes does nothing, source slug uniqueness is checked on insert
+func (r *MemorySlugRedirectRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// FindAll returns redirects of given post ordered by source slug, every redirect when post id is zero
+func (r *MemorySlugRedirectRepository) FindAll(ctx context.Context, postId primitive.ObjectID) (redirects []models.SlugRedirectDocument, err error) {
+	r.mu.RLock()
+	for _, redirect := range r.redirects {
+		if postId.IsZero() || redirect.PostId == postId {
+			redirects = append(redirects, *redirect)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(redirects, func(i, j int) bool { return redirects[i].From < redirects[j].From })
+	return redirects, nil
+}
+
+// FindByFrom returns redirect by source slug
+func (r *MemorySlugRedirectRepository) FindByFrom(ctx context.Context, from string) (*models.SlugRedirectDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, redirect := range r.redirects {
+		if redirect.From == from {
+			clone := *redirect
+			return &clone, nil
+		}
+	}
+
+	return nil, mongo.ErrNoDocuments
+}
+
+// Insert writes new redirect document
+func (r *MemorySlugRedirectRepository) Insert(ctx context.Context, redirect *models.SlugRedirectDocument) error {
+	clone := *redirect
+	if clone.Id.IsZero() {
+		clone.Id = primitive.NewObjectID()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.redirects {
+		if existing.From == clone.From {
+			return duplicateKeyError("from")
+		}
+	}
+
+	r.redirects = append(r.redirects, &clone)
+	return nil
+}
+
+// Retarget points every redirect of given post to new slug
+func (r *MemorySlugRedirectRepository) Retarget(ctx context.Context, postId primitive.ObjectID, to string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, redirect := range r.redirects {
+		if redirect.PostId == postId {
+			redirect.To = to
+			redirect.UpdatedAt = at
+		}
+	}
+	return nil
+}
+
+// Delete removes redirect by id and returns number of removed documents
+func (r *MemorySlugRedirectRepository) Delete(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	return r.remove(func(redirect *models.SlugRedirectDocument) bool { return redirect.Id == id }), nil
+}
+
+// DeleteByFrom removes redirect by source slug
+func (r *MemorySlugRedirectRepository) DeleteByFrom(ctx context.Context, from string) error {
+	r.remove(func(redirect *models.SlugRedirectDocument) bool { return redirect.From == from })
+	return nil
+}
+
+// remove drops redirects matching given predicate and returns their count
+func (r *MemorySlugRedirectRepository) remove(match func(*models.SlugRedirectDocument) bool) (count int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.redirects[:0]
+	for _, redirect := range r.redirects {
+		if match(redirect) {
+			count++
+		} else {
+			kept = append(kept, redirect)
+		}
+	}
+	r.redirects = kept
+	return count
+}
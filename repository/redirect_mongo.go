@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSlugRedirectRepository stores redirects in MongoDB "slugRedirects" collection
+type MongoSlugRedirectRepository struct{}
+
+// NewMongoSlugRedirectRepository creates new instance of MongoSlugRedirectRepository
+func NewMongoSlugRedirectRepository() *MongoSlugRedirectRepository {
+	return &MongoSlugRedirectRepository{}
+}
+
+// EnsureIndexes creates unique index on source slug and index on post id
+func (r *MongoSlugRedirectRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := db.
+		MongoDb().
+		Collection("slugRedirects").
+		Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "from", Value: 1}},
+				Options: options.Index().SetName("from_unique").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "postId", Value: 1}},
+				Options: options.Index().SetName("postId"),
+			},
+		})
+	return err
+}
+
+// FindAll returns redirects of given post ordered by source slug, every redirect when post id is zero
+func (r *MongoSlugRedirectRepository) FindAll(ctx context.Context, postId primitive.ObjectID) (redirects []models.SlugRedirectDocument, err error) {
+	var (
+		cur      *mongo.Cursor
+		filter   = bson.D{}
+		findOpts = options.Find().SetSort(bson.D{{Key: "from", Value: 1}})
+	)
+
+	if !postId.IsZero() {
+		filter = bson.D{{Key: "postId", Value: postId}}
+	}
+
+	if cur, err = db.
+		MongoDb().
+		Collection("slugRedirects").
+		Find(ctx, filter, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &redirects); err != nil {
+		return nil, err
+	}
+
+	return redirects, nil
+}
+
+// FindByFrom returns redirect by source slug
+func (r *MongoSlugRedirectRepository) FindByFrom(ctx context.Context, from string) (redirect *models.SlugRedirectDocument, err error) {
+	if err = db.
+		MongoDb().
+		Collection("slugRedirects").
+		FindOne(ctx, bson.D{{Key: "from", Value: from}}).
+		Decode(&redirect); err != nil {
+		return nil, err
+	}
+	return redirect, nil
+}
+
+// Insert writes new redirect document
+func (r *MongoSlugRedirectRepository) Insert(ctx context.Context, redirect *models.SlugRedirectDocument) error {
+	_, err := db.MongoDb().Collection("slugRedirects").InsertOne(ctx, redirect)
+	return err
+}
+
+// Retarget points every redirect of given post to new slug
+func (r *MongoSlugRedirectRepository) Retarget(ctx context.Context, postId primitive.ObjectID, to string, at time.Time) error {
+	_, err := db.
+		MongoDb().
+		Collection("slugRedirects").
+		UpdateMany(
+			ctx,
+			bson.D{{Key: "postId", Value: postId}},
+			bson.M{"$set": bson.M{"to": to, "updatedAt": at}})
+	return err
+}
+
+// Delete removes redirect by id and returns number of removed documents
+func (r *MongoSlugRedirectRepository) Delete(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	res, err := db.
+		MongoDb().
+		Collection("slugRedirects").
+		DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// DeleteByFrom removes redirect by source slug
+func (r *MongoSlugRedirectRepository) DeleteByFrom(ctx context.Context, from string) error {
+	_, err := db.
+		MongoDb().
+		Collection("slugRedirects").
+		DeleteOne(ctx, bson.D{{Key: "from", Value: from}})
+	return err
+}
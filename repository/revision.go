@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostRevisionRepository describes storage of post revision snapshots
+type PostRevisionRepository interface {
+	// Insert writes new revision document
+	Insert(ctx context.Context, rev *models.PostRevisionDocument) error
+
+	// FindById returns revision with snapshot by given id
+	FindById(ctx context.Context, id primitive.ObjectID) (*models.PostRevisionDocument, error)
+
+	// FindByPost returns revisions of given post, newest first
+	FindByPost(ctx context.Context, postId primitive.ObjectID) ([]models.PostRevisionSummary, error)
+}
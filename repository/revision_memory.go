@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryPostRevisionRepository keeps revisions in process memory
+type MemoryPostRevisionRepository struct {
+	mu   sync.RWMutex
+	revs []*models.PostRevisionDocument
+}
+
+// NewMemoryPostRevisionRepository creates new empty instance of MemoryPostRevisionRepository
+func NewMemoryPostRevisionRepository() *MemoryPostRevisionRepository {
+	return &MemoryPostRevisionRepository{}
+}
+
+// Insert writes new revision document
+func (r *MemoryPostRevisionRepository) Insert(ctx context.Context, rev *models.PostRevisionDocument) error {
+	snapshot, err := clonePost(rev.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	clone := *rev
+	clone.Snapshot = snapshot
+
+	r.mu.Lock()
+	r.revs = append(r.revs, &clone)
+	r.mu.Unlock()
+	return nil
+}
+
+// FindById returns revision with snapshot by given id
+func (r *MemoryPostRevisionRepository) FindById(ctx context.Context, id primitive.ObjectID) (*models.PostRevisionDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rev := range r.revs {
+		if rev.Id == id {
+			snapshot, err := clonePost(rev.Snapshot)
+			if err != nil {
+				return nil, err
+			}
+
+			clone := *rev
+			clone.Snapshot = snapshot
+			return &clone, nil
+		}
+	}
+
+	return nil, mongo.ErrNoDocuments
+}
+
+// FindByPost returns revisions of given post without snapshots, newest first
+func (r *MemoryPostRevisionRepository) FindByPost(ctx context.Context, postId primitive.ObjectID) (revs []models.PostRevisionSummary, err error) {
+	r.mu.RLock()
+	for _, rev := range r.revs {
+		if rev.PostId == postId {
+			revs = append(revs, models.PostRevisionSummary{
+				Id:        rev.Id,
+				PostId:    rev.PostId,
+				Reason:    rev.Reason,
+				Title:     rev.Title,
+				CreatedAt: rev.CreatedAt,
+			})
+		}
+	}
+	r.mu.RUnlock()
+
+	// revisions are appended in time order
+	for i, j := 0, len(revs)-1; i < j; i, j = i+1, j-1 {
+		revs[i], revs[j] = revs[j], revs[i]
+	}
+
+	return revs, nil
+}
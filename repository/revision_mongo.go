@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoPostRevisionRepository stores revisions in MongoDB "postRevisions" collection
+type MongoPostRevisionRepository struct{}
+
+// NewMongoPostRevisionRepository creates new instance of MongoPostRevisionRepository
+func NewMongoPostRevisionRepository() *MongoPostRevisionRepository {
+	return &MongoPostRevisionRepository{}
+}
+
+// Insert writes new revision document
+func (r *MongoPostRevisionRepository) Insert(ctx context.Context, rev *models.PostRevisionDocument) error {
+	_, err := db.MongoDb().Collection("postRevisions").InsertOne(ctx, rev)
+	return err
+}
+
+// FindById returns revision with snapshot by given id
+func (r *MongoPostRevisionRepository) FindById(ctx context.Context, id primitive.ObjectID) (rev *models.PostRevisionDocument, err error) {
+	if err = db.
+		MongoDb().
+		Collection("postRevisions").
+		FindOne(ctx, bson.D{{Key: "_id", Value: id}}).
+		Decode(&rev); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// FindByPost returns revisions of given post without snapshots, newest first
+func (r *MongoPostRevisionRepository) FindByPost(ctx context.Context, postId primitive.ObjectID) (revs []models.PostRevisionSummary, err error) {
+	var (
+		cur      *mongo.Cursor
+		findOpts = options.
+				Find().
+				SetProjection(bson.D{{Key: "snapshot", Value: 0}}).
+				SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+	)
+
+	if cur, err = db.
+		MongoDb().
+		Collection("postRevisions").
+		Find(ctx, bson.D{{Key: "postId", Value: postId}}, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &revs); err != nil {
+		return nil, err
+	}
+
+	return revs, nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TopicRepository describes storage operations required by TopicService
+type TopicRepository interface {
+	// FindAll returns every topic including archived ones sorted by order
+	FindAll(ctx context.Context) ([]models.TopicDocument, error)
+
+	// Insert writes new topic documents
+	Insert(ctx context.Context, topics ...*models.TopicDocument) error
+
+	// Update sets given fields of topic document by id, reports whether topic exists
+	Update(ctx context.Context, id string, fields bson.M) (bool, error)
+
+	// SetOrder assigns order of topics by their position in given ids
+	SetOrder(ctx context.Context, ids []string) error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryTopicRepository keeps topics in process memory
+type MemoryTopicRepository struct {
+	mu     sync.RWMutex
+	topics map[string]*models.TopicDocument
+}
+
+// NewMemoryTopicRepository creates new empty instance of MemoryTopicRepository
+func NewMemoryTopicRepository() *MemoryTopicRepository {
+	return &MemoryTopicRepository{
+		topics: make(map[string]*models.TopicDocument),
+	}
+}
+
+// FindAll returns every topic including archived ones sorted by order
+func (r *MemoryTopicRepository) FindAll(ctx context.Context) ([]models.TopicDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	topics := make([]models.TopicDocument, 0, len(r.topics))
+	for _, topic := range r.topics {
+		topics = append(topics, *topic)
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		if topics[i].Order != topics[j].Order {
+			return topics[i].Order < topics[j].Order
+		}
+		return topics[i].Id < topics[j].Id
+	})
+
+	return topics, nil
+}
+
+// Insert writes new topic documents
+func (r *MemoryTopicRepository) Insert(ctx context.Context, topics ...*models.TopicDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, topic := range topics {
+		if _, exists := r.topics[topic.Id]; exists {
+			return mongo.WriteException{
+				WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key error"}},
+			}
+		}
+	}
+
+	for _, topic := range topics {
+		clone := *topic
+		r.topics[topic.Id] = &clone
+	}
+
+	return nil
+}
+
+// Update sets given fields of topic document by id, reports whether topic exists
+func (r *MemoryTopicRepository) Update(ctx context.Context, id string, fields bson.M) (bool, error) {
+	var (
+		raw     []byte
+		doc     bson.M
+		updated *models.TopicDocument
+		err     error
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	topic, ok := r.topics[id]
+	if !ok {
+		return false, nil
+	}
+
+	// apply fields over the encoded document, same as $set does
+	if raw, err = bson.Marshal(topic); err != nil {
+		return false, err
+	}
+
+	if err = bson.Unmarshal(raw, &doc); err != nil {
+		return false, err
+	}
+
+	for key, value := range fields {
+		doc[key] = value
+	}
+
+	if raw, err = bson.Marshal(doc); err != nil {
+		return false, err
+	}
+
+	if err = bson.Unmarshal(raw, &updated); err != nil {
+		return false, err
+	}
+
+	r.topics[id] = updated
+	return true, nil
+}
+
+// SetOrder assigns order of topics by their position in given ids
+func (r *MemoryTopicRepository) SetOrder(ctx context.Context, ids []string) error {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, id := range ids {
+		if topic, ok := r.topics[id]; ok {
+			topic.Order = i
+			topic.UpdatedAt = now
+		}
+	}
+
+	return nil
+}
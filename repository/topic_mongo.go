@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoTopicRepository stores topics in MongoDB "topics" collection
+type MongoTopicRepository struct{}
+
+// NewMongoTopicRepository creates new instance of MongoTopicRepository
+func NewMongoTopicRepository() *MongoTopicRepository {
+	return &MongoTopicRepository{}
+}
+
+// FindAll returns every topic including archived ones sorted by order
+func (r *MongoTopicRepository) FindAll(ctx context.Context) (topics []models.TopicDocument, err error) {
+	var (
+		cur      *mongo.Cursor
+		findOpts = options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}})
+	)
+
+	if cur, err = db.MongoDb().Collection("topics").Find(ctx, bson.D{}, findOpts); err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &topics); err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// Insert writes new topic documents
+func (r *MongoTopicRepository) Insert(ctx context.Context, topics ...*models.TopicDocument) error {
+	docs := make([]interface{}, len(topics))
+
+	for i, topic := range topics {
+		docs[i] = topic
+	}
+
+	_, err := db.MongoDb().Collection("topics").InsertMany(ctx, docs)
+	return err
+}
+
+// Update sets given fields of topic document by id, reports whether topic exists
+func (r *MongoTopicRepository) Update(ctx context.Context, id string, fields bson.M) (bool, error) {
+	res, err := db.
+		MongoDb().
+		Collection("topics").
+		UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.M{"$set": fields})
+
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// SetOrder assigns order of topics by their position in given ids
+func (r *MongoTopicRepository) SetOrder(ctx context.Context, ids []string) error {
+	var (
+		writes = make([]mongo.WriteModel, len(ids))
+		now    = time.Now()
+	)
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	for i, id := range ids {
+		writes[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: id}}).
+			SetUpdate(bson.M{"$set": bson.M{"order": i, "updatedAt": now}})
+	}
+
+	_, err := db.MongoDb().Collection("topics").BulkWrite(ctx, writes)
+	return err
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// illegalOperationCode is returned by standalone servers for transactional writes
+const illegalOperationCode = 20
+
+// MongoTransactor runs writes in MongoDB multi-document transaction
+type MongoTransactor struct {
+	once sync.Once
+}
+
+// NewMongoTransactor creates new instance of MongoTransactor
+func NewMongoTransactor() *MongoTransactor {
+	return &MongoTransactor{}
+}
+
+// Run calls fn in transaction, standalone servers without transaction
+// support run fn without one
+func (t *MongoTransactor) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	var serverErr mongo.ServerError
+
+	err := db.MongoDb().Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sc)
+		})
+		return err
+	})
+
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(illegalOperationCode) {
+		t.once.Do(func() {
+			util.Log.Warning(fmt.Sprintf("[MongoTransactor.Run] Transactions are not supported, writes are not atomic: %s", err.Error()))
+		})
+		return fn(ctx)
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/repository"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// audited operations
+const (
+	AuditPostCreate        = "post.create"
+	AuditPostUpdate        = "post.update"
+	AuditPostScope         = "post.scope"
+	AuditPostDelete        = "post.delete"
+	AuditPostRestore       = "post.restore"
+	AuditPostRevision      = "post.revision.restore"
+	AuditPostConvert       = "post.convert"
+	AuditPostSchedule      = "post.schedule"
+	AuditPostScheduleClear = "post.schedule.clear"
+	AuditPostScheduleRun   = "post.schedule.apply"
+	AuditPostSlugMigrate   = "post.slug.migrate"
+	AuditRedirectCreate    = "redirect.create"
+	AuditRedirectDelete    = "redirect.delete"
+	AuditImageUpload       = "image.upload"
+	AuditImageDelete       = "image.delete"
+	AuditAuthorCreate      = "author.create"
+	AuditAuthorUpdate      = "author.update"
+	AuditAuthorDelete      = "author.delete"
+	AuditAuthorAvatar      = "author.avatar"
+	AuditTopicCreate       = "topic.create"
+	AuditTopicUpdate       = "topic.update"
+	AuditTopicArchive      = "topic.archive"
+	AuditTopicReorder      = "topic.reorder"
+	AuditTopicThumb        = "topic.thumb"
+	AuditIndexRepair       = "index.repair"
+)
+
+// audit outcomes
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditActorScheduler is recorded as actor of scheduled scope changes
+const AuditActorScheduler = "scheduler"
+
+type AuditService struct {
+	Ctx  context.Context
+	Repo repository.AuditLogRepository
+}
+
+// NewAuditService creates new instance of AuditService with given audit log storage
+func NewAuditService(repo repository.AuditLogRepository) *AuditService {
+	return &AuditService{
+		Ctx:  nil,
+		Repo: repo,
+	}
+}
+
+// load creates indexes of audit log storage
+func (as *AuditService) load() error {
+	if err := as.Repo.EnsureIndexes(as.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[AuditService.load] %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// Record writes outcome of operation on target, actor defaults to config.Actor
+// and after summary is dropped for failed operations
+// Audit failures are logged and never fail the audited operation, nil service records nothing
+func (as *AuditService) Record(actor string, operation string, targetId string, before bson.M, after bson.M, opErr error) {
+	if as == nil {
+		return
+	}
+
+	if actor == "" {
+		actor = config.Actor()
+	}
+
+	entry := &models.AuditLogDocument{
+		Id:        primitive.NewObjectID(),
+		Actor:     actor,
+		Operation: operation,
+		TargetId:  targetId,
+		Before:    before,
+		After:     after,
+		Outcome:   AuditSuccess,
+		CreatedAt: time.Now(),
+	}
+
+	if opErr != nil {
+		entry.After = nil
+		entry.Outcome = AuditFailure
+		entry.Error = opErr.Error()
+	}
+
+	if err := as.Repo.Insert(as.Ctx, entry); err != nil {
+		util.Log.Error(fmt.Sprintf("[AuditService.Record] %s (operation='%s', targetId='%s')", err.Error(), operation, targetId))
+	}
+}
+
+// GetAuditLog returns audit log entries filtered by post, actor, operation
+// and time range, newest first
+func (as *AuditService) GetAuditLog(params types.GetAuditLogOptions) ([]models.AuditLogDocument, error) {
+	verr := &validation.Error{}
+
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		verr.Add("from", "must not be after to")
+	}
+
+	if params.Limit < 0 {
+		verr.Add("limit", "must not be negative")
+	}
+
+	if params.Skip < 0 {
+		verr.Add("skip", "must not be negative")
+	}
+
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+
+	return as.Repo.Find(as.Ctx, &params)
+}
+
+// postSummary returns fields of post recorded in audit log
+func postSummary(post *models.PostDocument) bson.M {
+	return bson.M{
+		"title":       post.Title,
+		"slug":        post.Slug,
+		"topic":       post.Topic,
+		"format":      post.Format,
+		"public":      post.Public,
+		"deleted":     post.Deleted,
+		"publishAt":   post.PublishAt,
+		"unpublishAt": post.UnpublishAt,
+		"updatedAt":   post.UpdatedAt,
+	}
+}
+
+// summarizePost returns audit summary of stored post by rawid,
+// nil when audit is disabled or post cannot be read
+func (ps *PostService) summarizePost(rawid string) bson.M {
+	if ps.AuditServiceRef == nil {
+		return nil
+	}
+
+	oid, err := primitive.ObjectIDFromHex(rawid)
+	if err != nil {
+		return nil
+	}
+
+	post, err := ps.Repo.FindById(ps.Ctx, oid)
+	if err != nil {
+		return nil
+	}
+	return postSummary(post)
+}
+
+// auditPost records outcome of post operation, stored post is
+// summarized as after state when operation succeeded
+func (ps *PostService) auditPost(operation string, rawid string, before bson.M, err error) {
+	var after bson.M
+
+	if err == nil {
+		after = ps.summarizePost(rawid)
+	}
+	ps.AuditServiceRef.Record("", operation, rawid, before, after, err)
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/repository"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// author payload limits
+const (
+	maxAuthorNameLength = 80
+	maxAuthorBioLength  = 500
+	maxAuthorLinks      = 10
+	maxAuthorLinkName   = 40
+)
+
+// defaultAuthorName is given to author seeded from FMC_ADMIN_ID
+const defaultAuthorName = "Admin"
+
+// ErrAuthorNotFound is returned when author does not exist
+var ErrAuthorNotFound = errors.New("author not found")
+
+type AuthorService struct {
+	Ctx             context.Context
+	Repo            repository.AuthorRepository
+	Media           media.MediaStore
+	PostServiceRef  *PostService
+	AuditServiceRef *AuditService
+
+	mu    sync.RWMutex
+	cache []models.AuthorDocument
+}
+
+// NewAuthorService creates new instance of AuthorService with given author storage and media storage
+func NewAuthorService(repo repository.AuthorRepository, store media.MediaStore) *AuthorService {
+	return &AuthorService{
+		Ctx:   nil,
+		Repo:  repo,
+		Media: store,
+	}
+}
+
+// load loads authors, empty authors collection is seeded with the
+// configured admin so existing posts keep their byline
+func (as *AuthorService) load() (err error) {
+	var docs []models.AuthorDocument
+
+	if docs, err = as.Repo.FindAll(as.Ctx); err != nil {
+		return err
+	}
+
+	if adminId, idErr := primitive.ObjectIDFromHex(config.AdminId()); len(docs) == 0 && idErr == nil {
+		now := time.Now()
+
+		if err = as.Repo.Insert(as.Ctx, &models.AuthorDocument{
+			Id:        adminId,
+			Name:      defaultAuthorName,
+			Links:     []models.AuthorLink{},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}); err != nil {
+			util.Log.Error(fmt.Sprintf("[AuthorService.load] %s", err.Error()))
+			return err
+		}
+		util.Log.Info(fmt.Sprintf("[AuthorService.load] Seeded admin author (id='%s')", adminId.Hex()))
+	}
+
+	return as.refresh()
+}
+
+// refresh reloads author cache from storage
+func (as *AuthorService) refresh() error {
+	docs, err := as.Repo.FindAll(as.Ctx)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[AuthorService.refresh] %s", err.Error()))
+		return err
+	}
+
+	as.mu.Lock()
+	as.cache = docs
+	as.mu.Unlock()
+	return nil
+}
+
+// find returns cached author document by given id
+func (as *AuthorService) find(id primitive.ObjectID) (models.AuthorDocument, bool) {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	for _, doc := range as.cache {
+		if doc.Id == id {
+			return doc, true
+		}
+	}
+	return models.AuthorDocument{}, false
+}
+
+// parseAuthorId returns object id of existing author by given rawid
+func (as *AuthorService) parseAuthorId(rawid string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(rawid)
+	if err != nil {
+		return oid, err
+	}
+
+	if _, ok := as.find(oid); !ok {
+		return oid, fmt.Errorf("%w (id='%s')", ErrAuthorNotFound, rawid)
+	}
+	return oid, nil
+}
+
+// GetAllAuthors returns every author sorted by name
+func (as *AuthorService) GetAllAuthors() []models.AuthorDocument {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	authors := make([]models.AuthorDocument, len(as.cache))
+	copy(authors, as.cache)
+	return authors
+}
+
+// GetAuthorById returns single author by given rawid
+func (as *AuthorService) GetAuthorById(rawid string) (*models.AuthorDocument, error) {
+	oid, err := as.parseAuthorId(rawid)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, _ := as.find(oid)
+	return &doc, nil
+}
+
+// GetAuthorNameById returns name of author by given rawid, empty for unknown authors
+func (as *AuthorService) GetAuthorNameById(rawid string) string {
+	oid, err := primitive.ObjectIDFromHex(rawid)
+	if err != nil {
+		return ""
+	}
+
+	doc, _ := as.find(oid)
+	return doc.Name
+}
+
+// validateAuthor checks author payload and returns its trimmed links
+func validateAuthor(payload *types.AuthorPayload) ([]models.AuthorLink, error) {
+	var (
+		verr  = &validation.Error{}
+		links = make([]models.AuthorLink, 0, len(payload.Links))
+	)
+
+	if verr.Required("name", payload.Name) {
+		verr.MaxLength("name", payload.Name, maxAuthorNameLength)
+	}
+
+	verr.MaxLength("bio", payload.Bio, maxAuthorBioLength)
+
+	if len(payload.Links) > maxAuthorLinks {
+		verr.Add("links", "must have at most %d links, got %d", maxAuthorLinks, len(payload.Links))
+	}
+
+	for i, link := range payload.Links {
+		name := strings.TrimSpace(link.Name)
+		url := strings.TrimSpace(link.Url)
+
+		if field := fmt.Sprintf("links[%d].name", i); verr.Required(field, name) {
+			verr.MaxLength(field, name, maxAuthorLinkName)
+		}
+		verr.Url(fmt.Sprintf("links[%d].url", i), url)
+
+		links = append(links, models.AuthorLink{Name: name, Url: url})
+	}
+
+	return links, verr.Err()
+}
+
+// CreateAuthor inserts new author and returns its id
+func (as *AuthorService) CreateAuthor(payload types.AuthorPayload) (rawid string, err error) {
+	var links []models.AuthorLink
+
+	defer func() { as.AuditServiceRef.Record("", AuditAuthorCreate, rawid, nil, authorSummary(&payload), err) }()
+
+	if links, err = validateAuthor(&payload); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	doc := &models.AuthorDocument{
+		Id:        primitive.NewObjectID(),
+		Name:      strings.TrimSpace(payload.Name),
+		Bio:       strings.TrimSpace(payload.Bio),
+		Links:     links,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = as.Repo.Insert(as.Ctx, doc); err != nil {
+		util.Log.Error(fmt.Sprintf("[AuthorService.CreateAuthor] %s", err.Error()))
+		return "", err
+	} else {
+		util.Log.Info(fmt.Sprintf("[AuthorService.CreateAuthor] Inserted author (id='%s')", doc.Id.Hex()))
+	}
+
+	return doc.Id.Hex(), as.refresh()
+}
+
+// update sets given fields of author and refreshes cache
+func (as *AuthorService) update(fn string, id primitive.ObjectID, fields bson.M) error {
+	fields["updatedAt"] = time.Now()
+
+	if found, err := as.Repo.Update(as.Ctx, id, fields); err != nil {
+		util.Log.Error(fmt.Sprintf("[AuthorService.%s] %s", fn, err.Error()))
+		return err
+	} else if !found {
+		return ErrAuthorNotFound
+	} else {
+		util.Log.Info(fmt.Sprintf("[AuthorService.%s] Updated author (id='%s')", fn, id.Hex()))
+	}
+
+	return as.refresh()
+}
+
+// UpdateAuthor updates name, bio and links of existing author,
+// search records of public posts are updated when name changes
+func (as *AuthorService) UpdateAuthor(rawid string, payload types.AuthorPayload) (err error) {
+	var (
+		oid     primitive.ObjectID
+		links   []models.AuthorLink
+		current models.AuthorDocument
+	)
+
+	defer func() {
+		as.AuditServiceRef.Record("", AuditAuthorUpdate, rawid, authorDocumentSummary(&current), authorSummary(&payload), err)
+	}()
+
+	if oid, err = as.parseAuthorId(rawid); err != nil {
+		return err
+	}
+	current, _ = as.find(oid)
+
+	if links, err = validateAuthor(&payload); err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(payload.Name)
+	if err = as.update("UpdateAuthor", oid, bson.M{
+		"name":  name,
+		"bio":   strings.TrimSpace(payload.Bio),
+		"links": links,
+	}); err != nil {
+		return err
+	}
+
+	if name != current.Name {
+		return as.PostServiceRef.reindexAuthorPosts(rawid)
+	}
+	return nil
+}
+
+// DeleteAuthor removes author who has no posts, including deleted ones
+func (as *AuthorService) DeleteAuthor(rawid string) (err error) {
+	var (
+		oid     primitive.ObjectID
+		current models.AuthorDocument
+		count   int64
+	)
+
+	defer func() {
+		as.AuditServiceRef.Record("", AuditAuthorDelete, rawid, authorDocumentSummary(&current), nil, err)
+	}()
+
+	if oid, err = as.parseAuthorId(rawid); err != nil {
+		return err
+	}
+	current, _ = as.find(oid)
+
+	if count, err = as.PostServiceRef.Repo.CountByAuthor(as.Ctx, oid); err != nil {
+		return err
+	} else if count > 0 {
+		return fmt.Errorf("author has %d posts, assign them to another author first", count)
+	}
+
+	if _, err = as.Repo.Delete(as.Ctx, oid); err != nil {
+		util.Log.Error(fmt.Sprintf("[AuthorService.DeleteAuthor] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info(fmt.Sprintf("[AuthorService.DeleteAuthor] Deleted author (id='%s')", rawid))
+	}
+
+	return as.refresh()
+}
+
+// UploadAuthorAvatar uploads avatar image and assigns it to author
+func (as *AuthorService) UploadAuthorAvatar(rawid string, imageData []byte) (res *types.UploadedImageFile, err error) {
+	var oid primitive.ObjectID
+
+	defer func() {
+		var after bson.M
+		if res != nil {
+			after = bson.M{"avatarPath": res.PublicId}
+		}
+		as.AuditServiceRef.Record("", AuditAuthorAvatar, rawid, nil, after, err)
+	}()
+
+	if oid, err = as.parseAuthorId(rawid); err != nil {
+		return nil, err
+	}
+
+	if res, err = as.Media.Upload(as.Ctx, "fivemin-prod/author-avatars", imageData); err != nil {
+		return nil, err
+	}
+
+	if err = as.update("UploadAuthorAvatar", oid, bson.M{"avatarId": res.AssetId, "avatarPath": res.PublicId}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// authorSummary returns fields of author payload recorded in audit log
+func authorSummary(payload *types.AuthorPayload) bson.M {
+	return bson.M{"name": payload.Name, "bio": payload.Bio, "links": len(payload.Links)}
+}
+
+// authorDocumentSummary returns fields of stored author recorded in audit log,
+// nil for unknown author
+func authorDocumentSummary(doc *models.AuthorDocument) bson.M {
+	if doc.Id.IsZero() {
+		return nil
+	}
+	return bson.M{"name": doc.Name, "bio": doc.Bio, "links": len(doc.Links)}
+}
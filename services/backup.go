@@ -0,0 +1,414 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/backup"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postbody"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// restore modes
+const (
+	// RestoreModeEmpty restores only when storage holds no posts,
+	// topics seeded on startup are replaced by archived ones
+	RestoreModeEmpty = "empty"
+
+	// RestoreModeSkipExisting keeps records that already exist
+	RestoreModeSkipExisting = "skip-existing"
+
+	// RestoreModeOverwrite replaces records that already exist
+	RestoreModeOverwrite = "overwrite"
+)
+
+type BackupService struct {
+	Ctx             context.Context
+	Dir             string
+	PostServiceRef  *PostService
+	TopicServiceRef *TopicService
+}
+
+// NewBackupService creates new instance of BackupService writing
+// archives without explicit path into given directory
+func NewBackupService(dir string) *BackupService {
+	return &BackupService{
+		Dir: dir,
+	}
+}
+
+// postImages returns public ids of cover and embedded images of post
+func postImages(post *models.PostDocument) (ids []string) {
+	if post.CoverImage != nil && post.CoverImage.Path != "" {
+		ids = append(ids, post.CoverImage.Path)
+	}
+
+	doc, err := postbody.Parse(post.Format, post.Body)
+	if err != nil {
+		return ids
+	}
+
+	for i := range doc.Blocks {
+		if block := &doc.Blocks[i]; block.Type == "image" {
+			if id := block.ImagePublicId(); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
+
+// referencedImages returns sorted unique public ids of images used by posts and topics
+func referencedImages(posts []models.PostDocument, topics []models.TopicDocument) []string {
+	set := make(map[string]bool)
+
+	for i := range posts {
+		for _, id := range postImages(&posts[i]) {
+			set[id] = true
+		}
+	}
+
+	for _, topic := range topics {
+		if topic.ThumbPath != "" {
+			set[topic.ThumbPath] = true
+		}
+	}
+
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// CreateBackup writes every post including deleted ones and every topic
+// into archive file, referenced images are included on request
+// Archive is written into backup directory when file is empty
+func (bs *BackupService) CreateBackup(file string, images bool) (*types.BackupResult, error) {
+	var (
+		posts    []models.PostDocument
+		topics   []models.TopicDocument
+		manifest *types.BackupManifest
+		err      error
+	)
+
+	if file == "" {
+		file = filepath.Join(bs.Dir, fmt.Sprintf("fconsole-%s.zip", time.Now().Format("20060102-150405")))
+	}
+
+	if posts, err = bs.PostServiceRef.Repo.FindAll(bs.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[BackupService.CreateBackup] %s", err.Error()))
+		return nil, err
+	}
+
+	if topics, err = bs.TopicServiceRef.Repo.FindAll(bs.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[BackupService.CreateBackup] %s", err.Error()))
+		return nil, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return nil, err
+	}
+
+	// write into temporary file so a failed backup never replaces a good one
+	tmp := file + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		out.Close()
+		if err != nil {
+			os.Remove(tmp)
+		}
+	}()
+
+	w := backup.NewWriter(out)
+
+	if err = w.WritePosts(posts); err != nil {
+		return nil, err
+	}
+
+	if err = w.WriteTopics(topics); err != nil {
+		return nil, err
+	}
+
+	if images {
+		for _, id := range referencedImages(posts, topics) {
+			data, derr := bs.PostServiceRef.Media.Download(bs.Ctx, id)
+			if derr != nil {
+				util.Log.Warning(fmt.Sprintf("[BackupService.CreateBackup] Skipped image '%s': %s", id, derr.Error()))
+				w.AddMissing(id)
+				continue
+			}
+
+			if err = w.WriteImage(id, data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if manifest, err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	if err = out.Close(); err != nil {
+		return nil, err
+	}
+
+	if err = os.Rename(tmp, file); err != nil {
+		return nil, err
+	}
+
+	util.Log.Info(fmt.Sprintf(
+		"[BackupService.CreateBackup] Backup written (file='%s', posts=%d, topics=%d, images=%d)",
+		file,
+		manifest.Posts,
+		manifest.Topics,
+		len(manifest.Images)))
+
+	return &types.BackupResult{File: file, Manifest: manifest}, nil
+}
+
+// GetBackupManifest returns manifest of archive file
+func (bs *BackupService) GetBackupManifest(file string) (*types.BackupManifest, error) {
+	archive, err := backup.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	return &archive.Manifest, nil
+}
+
+// restoreTopics writes archived topics, returns topics that were
+// or in dry run would be written
+func (bs *BackupService) restoreTopics(archive *backup.Archive, existing map[string]bool, opts *types.RestoreOptions, report *types.RestoreReport) (written []*models.TopicDocument) {
+	for i := range archive.Topics {
+		var (
+			topic  = &archive.Topics[i]
+			fields bson.M
+			err    error
+		)
+
+		if existing[topic.Id] && opts.Mode == RestoreModeSkipExisting {
+			report.Topics.Skipped++
+			continue
+		}
+
+		if !opts.DryRun {
+			if !existing[topic.Id] {
+				err = bs.TopicServiceRef.Repo.Insert(bs.Ctx, topic)
+			} else if fields, err = topicFields(topic); err == nil {
+				_, err = bs.TopicServiceRef.Repo.Update(bs.Ctx, topic.Id, fields)
+			}
+
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("topic '%s': %s", topic.Id, err.Error()))
+				continue
+			}
+		}
+
+		if existing[topic.Id] {
+			report.Topics.Overwritten++
+		} else {
+			report.Topics.Created++
+		}
+
+		written = append(written, topic)
+	}
+
+	return written
+}
+
+// topicFields returns every field of topic document except id
+func topicFields(topic *models.TopicDocument) (fields bson.M, err error) {
+	raw, err := bson.Marshal(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bson.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	delete(fields, "_id")
+	return fields, nil
+}
+
+// restorePosts writes archived posts, returns posts that were
+// or in dry run would be written
+func (bs *BackupService) restorePosts(archive *backup.Archive, existing map[primitive.ObjectID]bool, opts *types.RestoreOptions, report *types.RestoreReport) (written []*models.PostDocument) {
+	repo := bs.PostServiceRef.Repo
+
+	for i := range archive.Posts {
+		var (
+			post = &archive.Posts[i]
+			err  error
+		)
+
+		if existing[post.Id] && opts.Mode != RestoreModeOverwrite {
+			report.Posts.Skipped++
+			continue
+		}
+
+		if !opts.DryRun {
+			if existing[post.Id] {
+				_, err = repo.Replace(bs.Ctx, post)
+			} else {
+				_, err = repo.Insert(bs.Ctx, post)
+			}
+
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("post '%s': %s", post.Id.Hex(), err.Error()))
+				continue
+			}
+		}
+
+		if existing[post.Id] {
+			report.Posts.Overwritten++
+		} else {
+			report.Posts.Created++
+		}
+
+		written = append(written, post)
+	}
+
+	return written
+}
+
+// RestoreBackup writes archived posts and topics back into storage
+// Existing records are handled by mode, dry run only reports what would happen
+func (bs *BackupService) RestoreBackup(file string, opts types.RestoreOptions) (*types.RestoreReport, error) {
+	var (
+		archive       *backup.Archive
+		posts         []models.PostDocument
+		topics        []models.TopicDocument
+		existingPosts = make(map[primitive.ObjectID]bool)
+		existingTopic = make(map[string]bool)
+		err           error
+	)
+
+	if opts.Mode == "" {
+		opts.Mode = RestoreModeEmpty
+	}
+
+	if opts.Mode != RestoreModeEmpty && opts.Mode != RestoreModeSkipExisting && opts.Mode != RestoreModeOverwrite {
+		return nil, fmt.Errorf("unknown restore mode '%s'", opts.Mode)
+	}
+
+	if archive, err = backup.Open(file); err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	if posts, err = bs.PostServiceRef.Repo.FindAll(bs.Ctx); err != nil {
+		return nil, err
+	}
+
+	if topics, err = bs.TopicServiceRef.Repo.FindAll(bs.Ctx); err != nil {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		existingPosts[post.Id] = true
+	}
+
+	for _, topic := range topics {
+		existingTopic[topic.Id] = true
+	}
+
+	if opts.Mode == RestoreModeEmpty && len(posts) > 0 {
+		return nil, fmt.Errorf(
+			"storage already holds %d posts, use '%s' or '%s' mode to restore into non-empty storage",
+			len(posts),
+			RestoreModeSkipExisting,
+			RestoreModeOverwrite)
+	}
+
+	report := &types.RestoreReport{DryRun: opts.DryRun, Mode: opts.Mode, Errors: []string{}}
+
+	writtenTopics := bs.restoreTopics(archive, existingTopic, &opts, report)
+	writtenPosts := bs.restorePosts(archive, existingPosts, &opts, report)
+
+	if opts.Images {
+		bs.restoreImages(archive, writtenPosts, writtenTopics, &opts, report)
+	}
+
+	if !opts.DryRun {
+		if len(writtenTopics) > 0 {
+			if err = bs.TopicServiceRef.refresh(); err != nil {
+				report.Errors = append(report.Errors, err.Error())
+			}
+		}
+
+		for _, post := range writtenPosts {
+			if err = bs.PostServiceRef.queueIndex(post.Id, post.Public && !post.Deleted); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("index of post '%s': %s", post.Id.Hex(), err.Error()))
+			}
+		}
+	}
+
+	util.Log.Info(fmt.Sprintf(
+		"[BackupService.RestoreBackup] Restored backup (file='%s', mode='%s', dryRun=%t, errors=%d)",
+		file,
+		opts.Mode,
+		opts.DryRun,
+		len(report.Errors)))
+
+	if len(report.Errors) > 0 {
+		return report, errors.New("restore finished with errors")
+	}
+
+	return report, nil
+}
+
+// restoreImages writes archived images referenced by written records
+func (bs *BackupService) restoreImages(archive *backup.Archive, posts []*models.PostDocument, topics []*models.TopicDocument, opts *types.RestoreOptions, report *types.RestoreReport) {
+	var (
+		pdocs = make([]models.PostDocument, len(posts))
+		tdocs = make([]models.TopicDocument, len(topics))
+	)
+
+	for i := range posts {
+		pdocs[i] = *posts[i]
+	}
+
+	for i := range topics {
+		tdocs[i] = *topics[i]
+	}
+
+	for _, id := range referencedImages(pdocs, tdocs) {
+		if !archive.HasImage(id) {
+			report.Images.Skipped++
+			continue
+		}
+
+		if !opts.DryRun {
+			data, err := archive.Image(id)
+			if err == nil {
+				_, err = bs.PostServiceRef.Media.Put(bs.Ctx, id, data)
+			}
+
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("image '%s': %s", id, err.Error()))
+				continue
+			}
+		}
+
+		report.Images.Created++
+	}
+}
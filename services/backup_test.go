@@ -0,0 +1,222 @@
+package services
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const testCoverId = "posts/cover"
+
+// newTestBackupService returns backup service over newTestPostService
+func newTestBackupService(t *testing.T) *BackupService {
+	t.Helper()
+
+	ps := newTestPostService(t)
+	bs := NewBackupService(t.TempDir())
+	bs.Ctx = ps.Ctx
+	bs.PostServiceRef = ps
+	bs.TopicServiceRef = ps.TopicServiceRef
+	return bs
+}
+
+// writeTestBackup stores a public post with cover image, a private post and
+// a deleted post, then writes backup archive including images
+func writeTestBackup(t *testing.T) (*BackupService, string) {
+	t.Helper()
+
+	var (
+		bs  = newTestBackupService(t)
+		ps  = bs.PostServiceRef
+		img = testImage(t)
+	)
+
+	if _, err := ps.Media.Put(ps.Ctx, testCoverId, img); err != nil {
+		t.Fatal(err)
+	}
+
+	public := insertTestPost(t, ps, "public", "go")
+	if _, err := ps.Repo.Update(ps.Ctx, public.Id, bson.M{
+		"public":     true,
+		"coverImage": &models.PostCoverImage{Id: "cover", Path: testCoverId},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	insertTestPost(t, ps, "private", "go")
+
+	deleted := insertTestPost(t, ps, "deleted", "old")
+	if _, err := ps.Repo.Update(ps.Ctx, deleted.Id, bson.M{"deleted": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "backup.zip")
+	res, err := bs.CreateBackup(file, true)
+	if err != nil {
+		t.Fatalf("CreateBackup() error = %v", err)
+	}
+
+	if res.Manifest.Posts != 3 || res.Manifest.Topics != 2 || len(res.Manifest.Images) != 1 || len(res.Manifest.Missing) != 0 {
+		t.Fatalf("manifest = %+v, want 3 posts, 2 topics and 1 image", res.Manifest)
+	}
+
+	return bs, file
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	src, file := writeTestBackup(t)
+	dst := newTestBackupService(t)
+
+	report, err := dst.RestoreBackup(file, types.RestoreOptions{Images: true})
+	if err != nil {
+		t.Fatalf("RestoreBackup() error = %v, report %+v", err, report)
+	}
+
+	want := types.RestoreReport{
+		Mode:   RestoreModeEmpty,
+		Posts:  types.RestoreCount{Created: 3},
+		Topics: types.RestoreCount{Overwritten: 2},
+		Images: types.RestoreCount{Created: 1},
+		Errors: []string{},
+	}
+	if !reflect.DeepEqual(*report, want) {
+		t.Errorf("report = %+v, want %+v", *report, want)
+	}
+
+	srcPosts, _ := src.PostServiceRef.Repo.FindAll(src.Ctx)
+	dstPosts, err := dst.PostServiceRef.Repo.FindAll(dst.Ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(byPostSlug(dstPosts), byPostSlug(srcPosts)) {
+		t.Errorf("restored posts = %+v, want %+v", dstPosts, srcPosts)
+	}
+
+	srcTopics, _ := src.TopicServiceRef.Repo.FindAll(src.Ctx)
+	dstTopics, _ := dst.TopicServiceRef.Repo.FindAll(dst.Ctx)
+
+	// archive keeps timestamps at millisecond precision like MongoDB does
+	for i := range srcTopics {
+		srcTopics[i].CreatedAt = srcTopics[i].CreatedAt.Truncate(time.Millisecond).UTC()
+		srcTopics[i].UpdatedAt = srcTopics[i].UpdatedAt.Truncate(time.Millisecond).UTC()
+	}
+	if !reflect.DeepEqual(dstTopics, srcTopics) {
+		t.Errorf("restored topics = %+v, want %+v", dstTopics, srcTopics)
+	}
+
+	data, err := dst.PostServiceRef.Media.Download(dst.Ctx, testCoverId)
+	if err != nil || !bytes.Equal(data, testImage(t)) {
+		t.Errorf("restored cover image = %d bytes, %v", len(data), err)
+	}
+}
+
+func TestRestoreBackupModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    types.RestoreOptions
+		want    types.RestoreReport
+		wantErr bool
+	}{
+		{
+			name:    "empty mode rejects non-empty storage",
+			opts:    types.RestoreOptions{Mode: RestoreModeEmpty},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			opts:    types.RestoreOptions{Mode: "merge"},
+			wantErr: true,
+		},
+		{
+			name: "skip existing",
+			opts: types.RestoreOptions{Mode: RestoreModeSkipExisting},
+			want: types.RestoreReport{
+				Mode:   RestoreModeSkipExisting,
+				Posts:  types.RestoreCount{Created: 2, Skipped: 1},
+				Topics: types.RestoreCount{Skipped: 2},
+			},
+		},
+		{
+			name: "overwrite",
+			opts: types.RestoreOptions{Mode: RestoreModeOverwrite},
+			want: types.RestoreReport{
+				Mode:   RestoreModeOverwrite,
+				Posts:  types.RestoreCount{Created: 2, Overwritten: 1},
+				Topics: types.RestoreCount{Overwritten: 2},
+			},
+		},
+		{
+			name: "dry run",
+			opts: types.RestoreOptions{Mode: RestoreModeOverwrite, DryRun: true, Images: true},
+			want: types.RestoreReport{
+				DryRun: true,
+				Mode:   RestoreModeOverwrite,
+				Posts:  types.RestoreCount{Created: 2, Overwritten: 1},
+				Topics: types.RestoreCount{Overwritten: 2},
+				Images: types.RestoreCount{Created: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, file := writeTestBackup(t)
+			bs := newTestBackupService(t)
+			ps := bs.PostServiceRef
+
+			// target storage already holds an edited copy of the public post
+			posts, _ := src.PostServiceRef.Repo.FindAll(src.Ctx)
+			public := byPostSlug(posts)["public"]
+			public.Title = "Edited"
+			if _, err := ps.Repo.Insert(ps.Ctx, &public); err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := bs.RestoreBackup(file, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("RestoreBackup() = %+v, want error", report)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RestoreBackup() error = %v, report %+v", err, report)
+			}
+
+			tt.want.Errors = []string{}
+			if !reflect.DeepEqual(*report, tt.want) {
+				t.Errorf("report = %+v, want %+v", *report, tt.want)
+			}
+
+			posts, _ = ps.Repo.FindAll(ps.Ctx)
+			wantCount, wantTitle := 3, "Stored"
+			if tt.opts.DryRun {
+				wantCount, wantTitle = 1, "Edited"
+			} else if tt.opts.Mode == RestoreModeSkipExisting {
+				wantTitle = "Edited"
+			}
+
+			if len(posts) != wantCount {
+				t.Errorf("stored %d posts, want %d", len(posts), wantCount)
+			}
+			if title := byPostSlug(posts)["public"].Title; title != wantTitle {
+				t.Errorf("public post title = %q, want %q", title, wantTitle)
+			}
+		})
+	}
+}
+
+// byPostSlug returns posts keyed by slug
+func byPostSlug(posts []models.PostDocument) map[string]models.PostDocument {
+	m := make(map[string]models.PostDocument, len(posts))
+	for _, post := range posts {
+		m[post.Slug] = post
+	}
+	return m
+}
@@ -0,0 +1,400 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// timeout of storage availability check during draft sync
+const draftPingTimeout = 5 * time.Second
+
+// errStorageOffline is recorded on drafts pushed before the console connected to post storage
+var errStorageOffline = errors.New("post storage is not connected, draft is queued")
+
+type DraftService struct {
+	Ctx            context.Context
+	Dir            string
+	PostServiceRef *PostService
+
+	// Online reports whether post services are connected and loaded,
+	// drafts are queued without push while it returns false
+	Online func() bool
+
+	mu   sync.Mutex
+	stop chan struct{}
+}
+
+// NewDraftService creates new instance of DraftService storing drafts under given directory
+func NewDraftService(dir string) *DraftService {
+	return &DraftService{
+		Dir: dir,
+	}
+}
+
+// draftFile returns path of draft file by given draft id
+func (ds *DraftService) draftFile(id string) (string, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return "", fmt.Errorf("invalid draft id '%s'", id)
+	}
+	return filepath.Join(ds.Dir, id+".json"), nil
+}
+
+// read returns draft stored in file by given id
+func (ds *DraftService) read(id string) (*types.Draft, error) {
+	var draft types.Draft
+
+	file, err := ds.draftFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &draft); err != nil {
+		return nil, fmt.Errorf("corrupted draft '%s': %w", id, err)
+	}
+
+	return &draft, nil
+}
+
+// write stores draft into file, replacing it atomically
+func (ds *DraftService) write(draft *types.Draft) error {
+	file, err := ds.draftFile(draft.Id)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(ds.Dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return err
+	}
+
+	tmp := file + ".tmp"
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// SaveDraft writes draft to disk, a new id is assigned to drafts without one
+func (ds *DraftService) SaveDraft(draft types.Draft) (*types.Draft, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if draft.Id == "" {
+		draft.Id = primitive.NewObjectID().Hex()
+	} else if existing, err := ds.read(draft.Id); err == nil {
+		// keep queue state of drafts waiting to be pushed
+		draft.Queued = draft.Queued || existing.Queued
+	}
+
+	draft.SavedAt = time.Now()
+
+	if err := ds.write(&draft); err != nil {
+		util.Log.Error(fmt.Sprintf("[DraftService.SaveDraft] %s", err.Error()))
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+// GetDrafts returns all local drafts, most recently saved first
+func (ds *DraftService) GetDrafts() ([]types.Draft, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	entries, err := os.ReadDir(ds.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []types.Draft{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	drafts := []types.Draft{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		draft, err := ds.read(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			util.Log.Warning(fmt.Sprintf("[DraftService.GetDrafts] %s", err.Error()))
+			continue
+		}
+		drafts = append(drafts, *draft)
+	}
+
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].SavedAt.After(drafts[j].SavedAt)
+	})
+
+	return drafts, nil
+}
+
+// GetDraft returns single draft by given id
+func (ds *DraftService) GetDraft(id string) (*types.Draft, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	return ds.read(id)
+}
+
+// GetPostDraft returns latest draft of existing post, nil when none exists
+func (ds *DraftService) GetPostDraft(postId string) (*types.Draft, error) {
+	drafts, err := ds.GetDrafts()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range drafts {
+		if drafts[i].PostId == postId {
+			return &drafts[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteDraft removes draft by given id
+func (ds *DraftService) DeleteDraft(id string) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	file, err := ds.draftFile(id)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// push writes draft into post storage and removes it on success
+func (ds *DraftService) push(draft *types.Draft) (postId string, err error) {
+	payload := draft.Payload
+
+	if draft.PostId == "" {
+		res, err := ds.PostServiceRef.CreatePost(&payload)
+		if err != nil {
+			return "", err
+		}
+		postId = res.InsertedID.(primitive.ObjectID).Hex()
+
+		// draft kept by failed removal must update created post instead of creating another one
+		draft.PostId = postId
+		if err = ds.write(draft); err != nil {
+			util.Log.Warning(fmt.Sprintf("[DraftService.push] %s", err.Error()))
+		}
+	} else {
+		if _, err = ds.PostServiceRef.UpdatePostById(draft.PostId, types.UpdatePostPayload{
+			Title:             payload.Title,
+			Slug:              payload.Slug,
+			Desc:              payload.Desc,
+			Tags:              payload.Tags,
+			Topic:             payload.Topic,
+			Body:              payload.Body,
+			Public:            payload.Public,
+			CoverImageId:      payload.CoverImageId,
+			CoverImagePath:    payload.CoverImagePath,
+			CoverImageRefName: payload.CoverImageRefName,
+			CoverImageRefUrl:  payload.CoverImageRefUrl,
+			License:           payload.License,
+			RelatedPosts:      payload.RelatedPosts,
+		}); err != nil {
+			return "", err
+		}
+		postId = draft.PostId
+	}
+
+	file, _ := ds.draftFile(draft.Id)
+	if err = os.Remove(file); err != nil {
+		util.Log.Warning(fmt.Sprintf("[DraftService.push] %s", err.Error()))
+	}
+
+	util.Log.Info(fmt.Sprintf("[DraftService.push] Pushed draft (id='%s', postId='%s')", draft.Id, postId))
+	return postId, nil
+}
+
+// online reports whether drafts can be pushed to post storage
+func (ds *DraftService) online() bool {
+	return ds.Online == nil || ds.Online()
+}
+
+// storageUnreachable reports whether push failed because post storage could not be reached,
+// validation errors never are while other errors are confirmed by ping
+func (ds *DraftService) storageUnreachable(err error) bool {
+	var (
+		verr         *validation.Error
+		selectionErr topology.ServerSelectionError
+	)
+
+	switch {
+	case errors.As(err, &verr):
+		return false
+	case mongo.IsNetworkError(err), mongo.IsTimeout(err), errors.Is(err, mongo.ErrClientDisconnected), errors.As(err, &selectionErr):
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ds.Ctx, draftPingTimeout)
+	defer cancel()
+	return ds.PostServiceRef.Repo.Ping(ctx) != nil
+}
+
+// PushDraft saves draft as post and removes local copy
+// When storage is unreachable the draft is queued and pushed by background sync,
+// other errors such as validation errors are returned and take draft off the queue
+func (ds *DraftService) PushDraft(id string) (string, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	draft, err := ds.read(id)
+	if err != nil {
+		return "", err
+	}
+
+	if !ds.online() {
+		draft.Queued = true
+		draft.PushError = errStorageOffline.Error()
+		if err = ds.write(draft); err != nil {
+			return "", err
+		}
+		return "", errStorageOffline
+	}
+
+	postId, err := ds.push(draft)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[DraftService.PushDraft] %s", err.Error()))
+
+		queued := ds.storageUnreachable(err)
+		if !queued && !draft.Queued {
+			return "", err
+		}
+
+		draft.Queued = queued
+		draft.PushError = err.Error()
+		if werr := ds.write(draft); werr != nil {
+			return "", werr
+		}
+		return "", err
+	}
+
+	return postId, nil
+}
+
+// PushQueuedDrafts pushes every queued draft once storage is reachable
+// and returns number of pushed drafts
+// Failed drafts are skipped and reported together after the remaining ones are pushed
+func (ds *DraftService) PushQueuedDrafts() (int, error) {
+	if !ds.online() {
+		return 0, errStorageOffline
+	}
+
+	drafts, err := ds.GetDrafts()
+	if err != nil {
+		return 0, err
+	}
+
+	pushed, failed := 0, 0
+	for _, draft := range drafts {
+		if !draft.Queued {
+			continue
+		}
+
+		if pushed+failed == 0 {
+			ctx, cancel := context.WithTimeout(ds.Ctx, draftPingTimeout)
+			err = ds.PostServiceRef.Repo.Ping(ctx)
+			cancel()
+
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if _, err = ds.PushDraft(draft.Id); err != nil {
+			util.Log.Error(fmt.Sprintf("[DraftService.PushQueuedDrafts] %s (id='%s')", err.Error(), draft.Id))
+			failed++
+			continue
+		}
+		pushed++
+	}
+
+	if failed > 0 {
+		return pushed, fmt.Errorf("failed to push %d queued drafts", failed)
+	}
+	return pushed, nil
+}
+
+// sync pushes queued drafts once, failures are left for next sync
+func (ds *DraftService) sync() {
+	if n, err := ds.PushQueuedDrafts(); err != nil {
+		util.Log.Debug(fmt.Sprintf("[DraftService.sync] %s", err.Error()))
+	} else if n > 0 {
+		util.Log.Info(fmt.Sprintf("[DraftService.sync] Pushed %d queued drafts", n))
+	}
+}
+
+// startSync pushes queued drafts right away and then periodically
+// until stopSync is called
+func (ds *DraftService) startSync(interval time.Duration) {
+	ds.mu.Lock()
+	if ds.stop != nil {
+		ds.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	ds.stop = stop
+	ds.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// drafts queued while storage was unreachable are pushed as soon as it is back
+		ds.sync()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				ds.sync()
+			}
+		}
+	}()
+}
+
+// stopSync stops background draft sync
+func (ds *DraftService) stopSync() {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.stop != nil {
+		close(ds.stop)
+		ds.stop = nil
+	}
+}
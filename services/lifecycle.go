@@ -0,0 +1,56 @@
+package services
+
+import "time"
+
+// Lifecycle loads services from connected storage and controls their
+// background jobs. It is not bound to the frontend, so these operations
+// stay out of reach of frontend calls
+type Lifecycle struct {
+	Post   *PostService
+	Topic  *TopicService
+	Author *AuthorService
+	Audit  *AuditService
+	Draft  *DraftService
+}
+
+// Load reads topics and authors into service caches and
+// prepares audit log and post indexes of connected storage
+func (l *Lifecycle) Load() error {
+	if err := l.Topic.load(); err != nil {
+		return err
+	}
+
+	if err := l.Author.load(); err != nil {
+		return err
+	}
+
+	if err := l.Audit.load(); err != nil {
+		return err
+	}
+
+	return l.Post.load()
+}
+
+// StartJobs starts post scheduler and search index worker, which first
+// run schedules and index updates missed while the console was not running
+func (l *Lifecycle) StartJobs(interval time.Duration) {
+	l.Post.startScheduler(interval)
+	l.Post.startIndexWorker(interval)
+}
+
+// StopJobs stops post scheduler and search index worker
+func (l *Lifecycle) StopJobs() {
+	l.Post.stopScheduler()
+	l.Post.stopIndexWorker()
+}
+
+// StartDraftSync pushes drafts queued while storage was unreachable right away
+// and then on every interval, only the desktop app syncs its local drafts
+func (l *Lifecycle) StartDraftSync(interval time.Duration) {
+	l.Draft.startSync(interval)
+}
+
+// StopDraftSync stops background draft sync
+func (l *Lifecycle) StopDraftSync() {
+	l.Draft.stopSync()
+}
@@ -3,233 +3,198 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
-	"github.com/rajatxs/go-fconsole/config"
-	"github.com/rajatxs/go-fconsole/db"
+	"github.com/rajatxs/go-fconsole/indexer"
+	"github.com/rajatxs/go-fconsole/media"
 	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postbody"
+	"github.com/rajatxs/go-fconsole/render"
+	"github.com/rajatxs/go-fconsole/repository"
 	"github.com/rajatxs/go-fconsole/types"
 	"github.com/rajatxs/go-fconsole/util"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type PostService struct {
-	Ctx             context.Context
-	TopicServiceRef *TopicService
+	Ctx              context.Context
+	TopicServiceRef  *TopicService
+	AuthorServiceRef *AuthorService
+	AuditServiceRef  *AuditService
+	Repo             repository.PostRepository
+	Revisions        repository.PostRevisionRepository
+	Redirects        repository.SlugRedirectRepository
+	Indexer          indexer.SearchIndexer
+	Media            media.MediaStore
+	Outbox           repository.IndexOutboxRepository
+	Tx               repository.Transactor
+
+	// OnIndexProgress receives progress of ReconcileIndex when set
+	OnIndexProgress func(progress types.IndexProgress)
+
+	mu        sync.Mutex
+	stop      chan struct{}
+	indexStop chan struct{}
 }
 
-// NewPostService creates new instance of PostService
-func NewPostService() *PostService {
+// NewPostService creates new instance of PostService with given post storage,
+// revision storage, slug redirect storage, search index, media storage,
+// search index outbox and transaction runner
+func NewPostService(
+	repo repository.PostRepository,
+	revs repository.PostRevisionRepository,
+	redirects repository.SlugRedirectRepository,
+	idx indexer.SearchIndexer,
+	store media.MediaStore,
+	outbox repository.IndexOutboxRepository,
+	tx repository.Transactor,
+) *PostService {
 	return &PostService{
-		Ctx: nil,
+		Ctx:       nil,
+		Repo:      repo,
+		Revisions: revs,
+		Redirects: redirects,
+		Indexer:   idx,
+		Media:     store,
+		Outbox:    outbox,
+		Tx:        tx,
 	}
 }
 
-// saveIndex writes new object to post search index
-func (ps *PostService) saveIndex(id primitive.ObjectID) (res search.SaveObjectRes, err error) {
-	var (
-		metadata *models.PostMetadataDocument
-		record   *models.PostIndex
-		nilRes   = search.SaveObjectRes{}
-	)
-
-	if err = db.
-		MongoDb().
-		Collection("publicPostsMetadata").
-		FindOne(ps.Ctx, &primitive.D{{Key: "_id", Value: id}}).
-		Decode(&metadata); err != nil {
-		return nilRes, err
-	}
+// saveIndex writes search record of given public post
+func (ps *PostService) saveIndex(post *models.PostDocument) (err error) {
+	var record *models.PostIndex
 
-	record = &models.PostIndex{
-		ObjectId:  id.Hex(),
-		Name:      metadata.Title,
-		Topic:     ps.TopicServiceRef.GetTopicNameById(metadata.Topic),
-		Desc:      metadata.Desc,
-		Tags:      metadata.Tags,
-		Url:       fmt.Sprintf("%s/%s", config.ClientUrl(), metadata.Slug),
-		Image:     util.GetPostCoverImageUrl(metadata.CoverImage.Path),
-		CreatedAt: metadata.CreatedAt,
-		UpdatedAt: metadata.UpdatedAt,
+	if record, err = ps.indexRecord(post); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.saveIndex] %s", err.Error()))
+		return err
 	}
 
-	if res, err = util.PostIndex().SaveObject(record); err != nil {
+	if err = ps.Indexer.SaveObject(record); err != nil {
 		util.Log.Error(fmt.Sprintf("[PostService.saveIndex] %s", err.Error()))
-		return nilRes, err
+		return err
 	} else {
-		util.Log.Info(fmt.Sprintf("[PostService.saveIndex] Saved post index (id='%s')", id.Hex()))
-		return res, nil
+		util.Log.Info(fmt.Sprintf("[PostService.saveIndex] Saved post index (id='%s')", post.Id.Hex()))
+		return nil
 	}
 }
 
 // dropIndex removes object from post search index
-func (ps *PostService) dropIndex(id primitive.ObjectID) (res search.DeleteTaskRes, err error) {
-	if res, err = util.PostIndex().DeleteObject(id.Hex()); err != nil {
+func (ps *PostService) dropIndex(id primitive.ObjectID) (err error) {
+	if err = ps.Indexer.DeleteObject(id.Hex()); err != nil {
 		util.Log.Error(fmt.Sprintf("[PostService.dropIndex] %s", err.Error()))
-		return search.DeleteTaskRes{}, err
+		return err
 	} else {
-		util.Log.Info(fmt.Sprintf("[PostService.saveIndex] Dropped post index (id='%s')", id.Hex()))
-		return res, nil
+		util.Log.Info(fmt.Sprintf("[PostService.dropIndex] Dropped post index (id='%s')", id.Hex()))
+		return nil
 	}
 }
 
-// updateIndex handles update/delete operation in post search index
-func (ps *PostService) updateIndex(id primitive.ObjectID, public bool) (err error) {
-	if config.IsProd() {
-		if public {
-			// add object to search index
-			_, err = ps.saveIndex(id)
-		} else {
-			// remove object from search index
-			_, err = ps.dropIndex(id)
+// reindexAuthorPosts saves search records of public posts by given author,
+// used when author name changes
+func (ps *PostService) reindexAuthorPosts(authorId string) error {
+	posts, err := ps.Repo.FindMetadata(ps.Ctx, &types.GetPostsMetadataOptions{Topic: "all", AuthorId: authorId})
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		if err = ps.queueIndex(post.Id, true); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
+// reindexTopicPosts saves search records of public posts in given topic,
+// used when topic name changes
+func (ps *PostService) reindexTopicPosts(topicId string) error {
+	posts, err := ps.Repo.FindMetadata(ps.Ctx, &types.GetPostsMetadataOptions{Topic: topicId})
+	if err != nil {
 		return err
-	} else {
-		return nil
 	}
+
+	for _, post := range posts {
+		if err = ps.queueIndex(post.Id, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchPosts returns indexed post records matching given query
+func (ps *PostService) SearchPosts(query string, limit int) ([]models.PostIndex, error) {
+	return ps.Indexer.Search(query, limit)
 }
 
 // GetPostMetadataById returns Post metadata by given Raw ID
 // By default this method will return public post
 func (ps *PostService) GetPostMetadataById(rawid string, private bool) (*models.PostMetadataDocument, error) {
 	var (
-		doc      *models.PostMetadataDocument
-		oid      primitive.ObjectID
-		collName string
-		filter   primitive.D
-		err      error
+		oid primitive.ObjectID
+		err error
 	)
 
-	if private {
-		collName = "privatePostsMetadata"
-	} else {
-		collName = "publicPostsMetadata"
-	}
-
 	// Parse ObjectId
 	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
 		return nil, err
-	} else {
-		filter = bson.D{{Key: "_id", Value: oid}}
 	}
 
-	// Find single post document by _id
-	if err = db.
-		MongoDb().
-		Collection(collName).
-		FindOne(ps.Ctx, filter).
-		Decode(&doc); err != nil {
-		return nil, err
-	} else {
-		return doc, err
-	}
+	// Find single post metadata by _id
+	return ps.Repo.FindMetadataById(ps.Ctx, oid, private)
 }
 
 // GetPostById returns Post document by given Raw ID
 func (ps *PostService) GetPostById(rawid string) (*models.PostObjectView, error) {
 	var (
-		doc    *models.PostObjectView
-		oid    primitive.ObjectID
-		filter primitive.D
-		err    error
+		oid primitive.ObjectID
+		err error
 	)
 
 	// Parse ObjectId
 	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
 		return nil, err
-	} else {
-		filter = bson.D{{Key: "_id", Value: oid}}
 	}
 
-	// Find single post document by _id
-	if err = db.
-		MongoDb().
-		Collection("postsObject").
-		FindOne(ps.Ctx, filter).
-		Decode(&doc); err != nil {
-		return nil, err
-	} else {
-		return doc, err
-	}
+	// Find single post object by _id
+	return ps.Repo.FindObjectById(ps.Ctx, oid)
 }
 
-// GetPostsMetadata returns list of metadata of posts
-// Need to provide result limit (default is 0)
-func (ps *PostService) GetPostsMetadata(params *types.GetPostsMetadataOptions) ([]models.PostMetadataDocument, error) {
+// RenderPostHTML returns HTML of post body by given Raw ID
+func (ps *PostService) RenderPostHTML(rawid string) (string, error) {
 	var (
-		posts     []models.PostMetadataDocument
-		findOpts  = options.Find()
-		collName  string
-		sortProp  string
-		sortOrder int
-		filter    = bson.D{}
+		oid  primitive.ObjectID
+		post *models.PostDocument
+		err  error
 	)
 
-	if params.Private {
-		collName = "privatePostsMetadata"
-	} else {
-		collName = "publicPostsMetadata"
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return "", err
 	}
 
-	// Use specific topic
-	if params.Topic != "all" {
-		filter = bson.D{{Key: "topic", Value: params.Topic}}
+	if post, err = ps.Repo.FindById(ps.Ctx, oid); err != nil {
+		return "", err
 	}
 
-	switch params.SortBy {
-	case "title":
-		sortProp = "title"
-		sortOrder = 1
-
-	case "topic":
-		sortProp = "topic"
-		sortOrder = 1
-
-	case "newest":
-		sortProp = "createdAt"
-		sortOrder = -1
-
-	case "oldest":
-		sortProp = "createdAt"
-		sortOrder = 1
-
-	case "updated":
-		sortProp = "updatedAt"
-		sortOrder = -1
+	doc, err := postbody.Parse(post.Format, post.Body)
+	if err != nil {
+		return "", err
 	}
 
-	findOpts.SetSort(map[string]int{sortProp: sortOrder})
-	findOpts.SetLimit(params.Limit)
-	findOpts.SetSkip(params.Skip)
-
-	if cur, err := db.
-		MongoDb().
-		Collection(collName).
-		Find(ps.Ctx, filter, nil, findOpts); err != nil {
-		log.Println(err)
-	} else {
-		for cur.Next(ps.Ctx) {
-			var post models.PostMetadataDocument
-
-			if err = cur.Decode(&post); err != nil {
-				return nil, err
-			}
-			posts = append(posts, post)
-		}
+	return render.DocumentHTML(doc), nil
+}
 
-		if err = cur.Err(); err != nil {
-			return nil, err
-		}
+// GetPostsMetadata returns list of metadata of posts
+// Need to provide result limit (default is 0)
+func (ps *PostService) GetPostsMetadata(params *types.GetPostsMetadataOptions) ([]models.PostMetadataDocument, error) {
+	posts, err := ps.Repo.FindMetadata(ps.Ctx, params)
 
-		if err = cur.Close(ps.Ctx); err != nil {
-			return nil, err
-		}
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.GetPostsMetadata] %s", err.Error()))
+		return nil, err
 	}
 
 	return posts, nil
@@ -238,215 +203,287 @@ func (ps *PostService) GetPostsMetadata(params *types.GetPostsMetadataOptions) (
 // GetPostCount returns number of post (public + private) in posts collection
 // By default the result will not include count of deleted posts
 func (ps *PostService) GetPostCount(scope string, includeDeleted bool) (int64, error) {
-	return db.
-		MongoDb().
-		Collection("posts").
-		CountDocuments(
-			ps.Ctx,
-			bson.D{
-				{Key: "public", Value: scope == "public"},
-				{Key: "deleted", Value: includeDeleted},
-			})
+	return ps.Repo.Count(ps.Ctx, scope == "public", includeDeleted)
 }
 
 // GetPublicPostCountByTopic returns number of public post by given topic in posts collection
 func (ps *PostService) GetPublicPostCountByTopic(topic string) (int64, error) {
-	filter := bson.D{
-		{Key: "public", Value: true},
-		{Key: "deleted", Value: false},
-	}
-
-	if topic != "all" {
-		filter = append(filter, primitive.E{Key: "topic", Value: topic})
-	}
-
-	return db.MongoDb().Collection("posts").CountDocuments(ps.Ctx, filter)
+	return ps.Repo.CountPublicByTopic(ps.Ctx, topic)
 }
 
 // CreatePost inserts new post document into posts collection
-func (ps *PostService) CreatePost(payload *types.CreatePostPayload) (*mongo.InsertOneResult, error) {
+func (ps *PostService) CreatePost(payload *types.CreatePostPayload) (res *mongo.InsertOneResult, err error) {
 	var (
 		authorId     primitive.ObjectID
-		res          *mongo.InsertOneResult
 		relatedPosts []primitive.ObjectID
-		err          error
+		postId       string
 	)
 
+	defer func() { ps.auditPost(AuditPostCreate, postId, nil, err) }()
+
+	if err = ps.validateCreatePost(payload); err != nil {
+		return nil, err
+	}
+
 	if authorId, err = primitive.ObjectIDFromHex(payload.AuthorId); err != nil {
 		return nil, err
 	}
 
+	if payload.Format, err = postbody.Normalize(payload.Format); err != nil {
+		return nil, err
+	}
+
 	// parse related post ids
 	if relatedPosts, err = util.ParsePostIds(payload.RelatedPosts); err != nil {
 		return nil, err
 	}
 
-	newPost := bson.M{
-		"title":    payload.Title,
-		"slug":     payload.Slug,
-		"desc":     payload.Desc,
-		"topic":    payload.Topic,
-		"tags":     payload.Tags,
-		"body":     payload.Body,
-		"format":   payload.Format,
-		"stars":    0,
-		"authorId": authorId,
-		"public":   payload.Public,
-		"deleted":  false,
-		"coverImage": &models.PostCoverImage{
+	// given slug was checked by validation, missing one is generated from title
+	generated := payload.Slug == ""
+	base := titleSlug(payload.Title, "")
+	if generated {
+		if payload.Slug, err = ps.uniqueSlug(base, primitive.NilObjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	newPost := &models.PostDocument{
+		Id:       primitive.NewObjectID(),
+		Title:    payload.Title,
+		Slug:     payload.Slug,
+		Desc:     payload.Desc,
+		Topic:    payload.Topic,
+		Tags:     payload.Tags,
+		Body:     payload.Body,
+		Format:   payload.Format,
+		Stars:    0,
+		AuthorId: authorId,
+		Public:   payload.Public,
+		Deleted:  false,
+		CoverImage: &models.PostCoverImage{
 			Id:      payload.CoverImageId,
 			Path:    payload.CoverImagePath,
 			RefName: payload.CoverImageRefName,
 			RefUrl:  payload.CoverImageRefUrl,
 		},
-		"license":   payload.License,
-		"related":   relatedPosts,
-		"createdAt": time.Now(),
-		"updatedAt": time.Now(),
+		License:   payload.License,
+		Related:   relatedPosts,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
 
-	if res, err = db.MongoDb().Collection("posts").InsertOne(ps.Ctx, newPost); err != nil {
-		util.Log.Error(fmt.Sprintf("[PostService.CreatePost] %s", err.Error()))
-		return nil, err
-	} else {
-		util.Log.Info(fmt.Sprintf("[PostService.CreatePost] Inserted post document (id='%s')", res.InsertedID.(primitive.ObjectID).Hex()))
+	// another post may take the slug between lookup and insert
+	for attempt := 0; ; attempt++ {
+		// post and its search index update are written together
+		err = ps.writeWithIndex(newPost.Id, newPost.Public, func(ctx context.Context) (err error) {
+			res, err = ps.Repo.Insert(ctx, newPost)
+			return err
+		})
+
+		if err == nil || !mongo.IsDuplicateKeyError(err) || attempt == 2 {
+			break
+		}
+
+		if !generated {
+			err = slugTakenError()
+			break
+		}
+
+		if newPost.Slug, err = ps.uniqueSlug(base, primitive.NilObjectID); err != nil {
+			break
+		}
+		payload.Slug = newPost.Slug
 	}
 
-	// add object to search index
-	if err = ps.updateIndex(res.InsertedID.(primitive.ObjectID), payload.Public); err != nil {
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.CreatePost] %s", err.Error()))
 		return nil, err
+	} else {
+		postId = newPost.Id.Hex()
+		util.Log.Info(fmt.Sprintf("[PostService.CreatePost] Inserted post document (id='%s')", postId))
 	}
 
 	return res, nil
 }
 
 // UpdatePostById updates existing post document by given rawid
-func (ps *PostService) UpdatePostById(rawid string, payload types.UpdatePostPayload) (*mongo.UpdateResult, error) {
+func (ps *PostService) UpdatePostById(rawid string, payload types.UpdatePostPayload) (res *mongo.UpdateResult, err error) {
 	var (
 		oid          primitive.ObjectID
-		filter       bson.D
-		update       bson.D
+		current      *models.PostDocument
+		fields       bson.M
 		relatedPosts []primitive.ObjectID
-		res          *mongo.UpdateResult
-		err          error
+		before       = ps.summarizePost(rawid)
 	)
 
+	defer func() { ps.auditPost(AuditPostUpdate, rawid, before, err) }()
+
 	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
 		return nil, err
 	} else {
-		filter = bson.D{{Key: "_id", Value: oid}}
+		// body must match stored format, formats change through ConvertPostFormat
+		if current, err = ps.Repo.FindById(ps.Ctx, oid); err != nil {
+			return nil, err
+		}
+
+		if err = ps.validateUpdatePost(current, &payload); err != nil {
+			return nil, err
+		}
 
 		// parse related post ids
 		if relatedPosts, err = util.ParsePostIds(payload.RelatedPosts); err != nil {
 			return nil, err
 		}
 
-		update = bson.D{
-			{Key: "$set", Value: bson.D{
-				{Key: "title", Value: payload.Title},
-				{Key: "slug", Value: payload.Slug},
-				{Key: "desc", Value: payload.Desc},
-				{Key: "topic", Value: payload.Topic},
-				{Key: "tags", Value: payload.Tags},
-				{Key: "body", Value: payload.Body},
-				{Key: "public", Value: payload.Public},
-				{Key: "coverImage", Value: &models.PostCoverImage{
-					Id:      payload.CoverImageId,
-					Path:    payload.CoverImagePath,
-					RefName: payload.CoverImageRefName,
-					RefUrl:  payload.CoverImageRefUrl,
-				}},
-				{Key: "license", Value: payload.License},
-				{Key: "related", Value: relatedPosts},
-				{Key: "updatedAt", Value: time.Now()},
-			}},
+		fields = bson.M{
+			"title":  payload.Title,
+			"slug":   payload.Slug,
+			"desc":   payload.Desc,
+			"topic":  payload.Topic,
+			"tags":   payload.Tags,
+			"body":   payload.Body,
+			"public": payload.Public,
+			"coverImage": &models.PostCoverImage{
+				Id:      payload.CoverImageId,
+				Path:    payload.CoverImagePath,
+				RefName: payload.CoverImageRefName,
+				RefUrl:  payload.CoverImageRefUrl,
+			},
+			"license":   payload.License,
+			"related":   relatedPosts,
+			"updatedAt": time.Now(),
 		}
 	}
 
-	// update document
-	if res, err = db.MongoDb().Collection("posts").UpdateOne(ps.Ctx, filter, update); err != nil {
+	// keep previous version, update document, redirect former slug
+	// and queue search index update in one transaction
+	if err = ps.writeWithIndex(oid, payload.Public, func(ctx context.Context) (err error) {
+		if err = ps.snapshotPost(ctx, oid, RevisionReasonUpdate); err != nil {
+			return err
+		}
+
+		if res, err = ps.Repo.Update(ctx, oid, fields); err != nil {
+			return err
+		}
+
+		if current.Slug != payload.Slug {
+			return ps.recordSlugChange(ctx, oid, current.Slug, payload.Slug)
+		}
+		return nil
+	}); err != nil {
 		util.Log.Error(fmt.Sprintf("[PostService.UpdatePostById] %s", err.Error()))
-		return nil, err
+		return nil, slugConflictError(err)
 	} else {
 		util.Log.Info(fmt.Sprintf("[PostService.UpdatePostById] Updated post document (id='%s')", oid.Hex()))
 	}
 
-	// update search index
-	if err = ps.updateIndex(oid, payload.Public); err != nil {
-		return nil, err
-	}
-
 	return res, nil
 }
 
 // UpdatePostScope set specified scope of post
-func (ps *PostService) UpdatePostScope(rawid string, scope string) error {
+func (ps *PostService) UpdatePostScope(rawid string, scope string) (err error) {
 	var (
 		oid    primitive.ObjectID
-		err    error
-		filter bson.D
-		update bson.M
 		public = scope == "public"
+		before = ps.summarizePost(rawid)
 	)
 
+	defer func() { ps.auditPost(AuditPostScope, rawid, before, err) }()
+
 	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
 		return err
-	} else {
-		filter = bson.D{{Key: "_id", Value: oid}}
-		update = bson.M{"$set": bson.M{"public": public, "updatedAt": time.Now()}}
 	}
 
-	// update scope
-	if _, err = db.MongoDb().Collection("posts").UpdateOne(ps.Ctx, filter, update); err != nil {
+	// keep previous version, update scope and queue search index update
+	if err = ps.writeWithIndex(oid, public, func(ctx context.Context) error {
+		if err := ps.snapshotPost(ctx, oid, RevisionReasonScope); err != nil {
+			return err
+		}
+
+		_, err := ps.Repo.Update(ctx, oid, bson.M{"public": public, "updatedAt": time.Now()})
+		return err
+	}); err != nil {
 		util.Log.Error(fmt.Sprintf("[PostService.UpdatePostScope] %s", err.Error()))
 		return err
 	} else {
 		util.Log.Info(fmt.Sprintf("[PostService.UpdatePostScope] Updated post scope (id='%s', scope='%s')", oid.Hex(), scope))
 	}
 
-	// update search index
-	return ps.updateIndex(oid, public)
+	return nil
 }
 
 // SetPostDeleteFlag sets post delete flag by given post rawid
-func (ps *PostService) SetPostDeleteFlag(rawid string, value bool) error {
+func (ps *PostService) SetPostDeleteFlag(rawid string, value bool) (err error) {
 	var (
-		oid    primitive.ObjectID
-		err    error
-		filter bson.D
-		update bson.M
+		oid       primitive.ObjectID
+		before    = ps.summarizePost(rawid)
+		operation = AuditPostDelete
 	)
 
+	if !value {
+		operation = AuditPostRestore
+	}
+	defer func() { ps.auditPost(operation, rawid, before, err) }()
+
 	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
 		return err
-	} else {
-		filter = bson.D{{Key: "_id", Value: oid}}
-		update = bson.M{"$set": bson.M{"deleted": value, "updatedAt": time.Now()}}
 	}
 
-	// update document
-	if _, err = db.MongoDb().Collection("posts").UpdateOne(ps.Ctx, filter, update); err != nil {
+	// update document and queue search index update
+	if err = ps.writeWithIndex(oid, !value, func(ctx context.Context) error {
+		_, err := ps.Repo.Update(ctx, oid, bson.M{"deleted": value, "updatedAt": time.Now()})
+		return err
+	}); err != nil {
 		util.Log.Error(fmt.Sprintf("[PostService.SetPostDeleteFlag] %s", err.Error()))
 		return err
 	} else {
 		util.Log.Info(fmt.Sprintf("[PostService.SetPostDeleteFlag] Updated post delete flag (id='%s', value=%t)", oid.Hex(), value))
 	}
 
-	// update search index
-	return ps.updateIndex(oid, !value)
+	return nil
+}
+
+// uploadImage uploads image into given folder and records it in audit log
+func (ps *PostService) uploadImage(folder string, imageData []byte) (res *types.UploadedImageFile, err error) {
+	var (
+		targetId string
+		after    bson.M
+	)
+
+	if res, err = ps.Media.Upload(ps.Ctx, folder, imageData); err == nil {
+		targetId = res.PublicId
+		after = bson.M{"folder": folder, "format": res.Format, "size": len(imageData)}
+	}
+
+	ps.AuditServiceRef.Record("", AuditImageUpload, targetId, nil, after, err)
+	return res, err
 }
 
 // UploadPostCoverImage uploads cover image and returns uploaded file response
 func (ps *PostService) UploadPostCoverImage(imageData []byte) (res *types.UploadedImageFile, err error) {
-	return util.UploadImage(ps.Ctx, "fivemin-prod/post-cover-images", imageData)
+	return ps.uploadImage("fivemin-prod/post-cover-images", imageData)
 }
 
 // UploadPostEmbedImage uploads post embedded image and returns uploaded file response
 func (ps *PostService) UploadPostEmbedImage(imageData []byte) (res *types.UploadedImageFile, err error) {
-	return util.UploadImage(ps.Ctx, "fivemin-prod/post-images", imageData)
+	return ps.uploadImage("fivemin-prod/post-images", imageData)
 }
 
 // DeletePostImage removes post related image from storage bucket
 func (ps *PostService) DeletePostImage(publicId string) error {
-	return util.DeleteImage(ps.Ctx, publicId)
+	err := ps.Media.Delete(ps.Ctx, publicId)
+	ps.AuditServiceRef.Record("", AuditImageDelete, publicId, nil, nil, err)
+	return err
+}
+
+// GetPostImages returns list of uploaded post cover and embedded images
+func (ps *PostService) GetPostImages(kind string) ([]types.MediaFile, error) {
+	switch kind {
+	case "cover":
+		return ps.Media.List(ps.Ctx, "fivemin-prod/post-cover-images")
+	case "embed":
+		return ps.Media.List(ps.Ctx, "fivemin-prod/post-images")
+	default:
+		return nil, fmt.Errorf("unknown image kind '%s'", kind)
+	}
 }
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postdiff"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// storedPost returns comparable fields of stored post by given rawid
+func (ps *PostService) storedPost(rawid string) (*postdiff.Post, error) {
+	var (
+		oid  primitive.ObjectID
+		post *models.PostDocument
+		err  error
+	)
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return nil, err
+	}
+
+	if post, err = ps.Repo.FindById(ps.Ctx, oid); err != nil {
+		return nil, err
+	}
+
+	return postdiff.FromDocument(post), nil
+}
+
+// DiffPostUpdate compares stored post with update payload about to be saved
+func (ps *PostService) DiffPostUpdate(rawid string, payload types.UpdatePostPayload) (*types.PostDiff, error) {
+	old, err := ps.storedPost(rawid)
+	if err != nil {
+		return nil, err
+	}
+
+	new, err := postdiff.FromUpdatePayload(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return postdiff.Compare(old, new)
+}
+
+// DiffPostSnapshot compares stored post with exported JSON snapshot of a post
+func (ps *PostService) DiffPostSnapshot(rawid string, snapshot string) (*types.PostDiff, error) {
+	old, err := ps.storedPost(rawid)
+	if err != nil {
+		return nil, err
+	}
+
+	new, err := postdiff.FromSnapshot([]byte(snapshot))
+	if err != nil {
+		return nil, err
+	}
+
+	return postdiff.Compare(old, new)
+}
+
+// DiffPostRevision compares revision snapshot with current version of the post
+func (ps *PostService) DiffPostRevision(rawid string) (*types.PostDiff, error) {
+	rev, err := ps.GetPostRevision(rawid)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := ps.storedPost(rev.PostId.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	return postdiff.Compare(postdiff.FromDocument(rev.Snapshot), current)
+}
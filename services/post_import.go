@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/importer"
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// import item statuses
+const (
+	ImportCreated = "created"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+const (
+	// maxImportImageBytes limits size of downloaded images
+	maxImportImageBytes = 20 << 20
+
+	// maxImportDescLength limits length of description taken from content
+	maxImportDescLength = 200
+
+	// defaultImportLicense is license of imported posts unless given
+	defaultImportLicense = "CC-BY-4.0"
+)
+
+var importHttpClient = &http.Client{Timeout: 30 * time.Second}
+
+// resolveImportUrl returns absolute url of image referenced by export
+func resolveImportUrl(raw string, siteUrl string) (string, error) {
+	siteUrl = strings.TrimSuffix(siteUrl, "/")
+
+	switch {
+	case strings.HasPrefix(raw, "http://"), strings.HasPrefix(raw, "https://"):
+		return raw, nil
+	case strings.HasPrefix(raw, "//"):
+		return "https:" + raw, nil
+	case siteUrl == "":
+		return "", fmt.Errorf("cannot resolve relative image url '%s' without site url", raw)
+	case strings.HasPrefix(raw, "__GHOST_URL__"):
+		return siteUrl + strings.TrimPrefix(raw, "__GHOST_URL__"), nil
+	case strings.HasPrefix(raw, "/"):
+		return siteUrl + raw, nil
+	default:
+		return siteUrl + "/" + raw, nil
+	}
+}
+
+// fetchImportImage downloads image referenced by export
+func fetchImportImage(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := importHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image download failed with status %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxImportImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxImportImageBytes {
+		return nil, fmt.Errorf("image is larger than %d MB", maxImportImageBytes>>20)
+	}
+
+	if kind := http.DetectContentType(data); !strings.HasPrefix(kind, "image/") {
+		return nil, fmt.Errorf("downloaded file is %s, not an image", kind)
+	}
+
+	return data, nil
+}
+
+// importTopics resolves topics of imported entries
+type importTopics struct {
+	lookup   map[string]string
+	mapping  map[string]string
+	fallback string
+}
+
+// newImportTopics creates topic resolver matching categories against
+// explicit mapping first and then against topic ids and names
+func newImportTopics(topics types.Topics, opts *types.ImportOptions) (*importTopics, error) {
+	it := &importTopics{
+		lookup:   make(map[string]string),
+		mapping:  make(map[string]string),
+		fallback: opts.Topic,
+	}
+
+	if _, ok := topics[it.fallback]; !ok && it.fallback != "" {
+		return nil, fmt.Errorf("unknown topic '%s'", it.fallback)
+	}
+
+	for id, topic := range topics {
+		it.lookup[strings.ToLower(id)] = id
+		it.lookup[strings.ToLower(topic.Name)] = id
+	}
+
+	for category, id := range opts.Topics {
+		if _, ok := topics[id]; !ok {
+			return nil, fmt.Errorf("category '%s' is mapped to unknown topic '%s'", category, id)
+		}
+		it.mapping[strings.ToLower(category)] = id
+	}
+
+	return it, nil
+}
+
+// resolve returns topic of first matching category, fallback topic
+// is returned when none matches and may be empty
+func (it *importTopics) resolve(categories []string) string {
+	for _, category := range categories {
+		if id, ok := it.mapping[strings.ToLower(category)]; ok {
+			return id
+		}
+	}
+
+	for _, category := range categories {
+		if id, ok := it.lookup[strings.ToLower(category)]; ok {
+			return id
+		}
+	}
+
+	return it.fallback
+}
+
+// importDesc returns description of entry, taken from excerpt or first paragraph
+func importDesc(entry *importer.Entry, doc *editorjs.Document) string {
+	desc := strings.Join(strings.Fields(editorjs.StripTags(entry.Excerpt)), " ")
+
+	for i := 0; desc == "" && i < len(doc.Blocks); i++ {
+		if doc.Blocks[i].Type == "paragraph" {
+			desc = strings.Join(strings.Fields(doc.Blocks[i].PlainText()), " ")
+		}
+	}
+
+	if utf8.RuneCountInString(desc) <= maxImportDescLength {
+		return desc
+	}
+
+	runes := []rune(desc)[:maxImportDescLength]
+	if cut := strings.LastIndexByte(string(runes), ' '); cut > 0 {
+		return string(runes)[:cut] + "..."
+	}
+	return string(runes) + "..."
+}
+
+// importEmbedImages uploads images of image blocks referenced by url,
+// failed images keep their original url
+func (ps *PostService) importEmbedImages(doc *editorjs.Document, siteUrl string) (warnings []string) {
+	for i := range doc.Blocks {
+		block := &doc.Blocks[i]
+		if block.Type != "image" || block.ImagePublicId() != "" {
+			continue
+		}
+
+		url, err := resolveImportUrl(block.ImageUrl(), siteUrl)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+			continue
+		}
+
+		data, err := fetchImportImage(ps.Ctx, url)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("image '%s': %s", url, err.Error()))
+			continue
+		}
+
+		res, err := ps.UploadPostEmbedImage(data)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("image '%s': %s", url, err.Error()))
+			continue
+		}
+
+		block.Data["file"] = map[string]interface{}{
+			"id":   res.AssetId,
+			"path": res.PublicId,
+			"url":  ps.Media.Url(res.PublicId, &media.Transform{Crop: "scale", Height: 600}),
+		}
+	}
+
+	return warnings
+}
+
+// importEntry creates post of single export entry
+func (ps *PostService) importEntry(entry *importer.Entry, item *types.ImportItem, topics *importTopics, slugs map[string]bool, opts *types.ImportOptions) error {
+	var (
+		doc     = importer.HTMLToDocument(entry.HTML, opts.Source == importer.SourceWordPress)
+		body    bson.M
+		payload *types.CreatePostPayload
+		oid     primitive.ObjectID
+		err     error
+	)
+
+	item.Topic = topics.resolve(entry.Categories)
+
+	switch {
+	case entry.Type == importer.EntryPage && !opts.Pages:
+		item.Status, item.Reason = ImportSkipped, "page"
+		return nil
+	case entry.Status == "trash" || entry.Status == "auto-draft" || entry.Status == "inherit":
+		item.Status, item.Reason = ImportSkipped, fmt.Sprintf("%s entry", entry.Status)
+		return nil
+	case entry.Title == "":
+		return errors.New("missing title")
+	case entry.Slug == "":
+		return errors.New("missing slug")
+	case slugs[entry.Slug]:
+		item.Status, item.Reason = ImportSkipped, "slug already exists"
+		return nil
+	case item.Topic == "":
+		return errors.New("no topic matches categories and no default topic is given")
+	case len(doc.Blocks) == 0:
+		return errors.New("no convertible content")
+	}
+
+	payload = &types.CreatePostPayload{
+		Title:        entry.Title,
+		Slug:         entry.Slug,
+		Desc:         importDesc(entry, doc),
+		Tags:         append([]string{}, entry.Tags...),
+		Topic:        item.Topic,
+		Format:       models.PostFormatBlock,
+		Public:       opts.Public && entry.Published,
+		AuthorId:     config.AdminId(),
+		License:      opts.License,
+		RelatedPosts: []string{},
+	}
+
+	if payload.License == "" {
+		payload.License = defaultImportLicense
+	}
+
+	if len(payload.Tags) > maxPostTags {
+		item.Warnings = append(item.Warnings, fmt.Sprintf("kept first %d of %d tags", maxPostTags, len(payload.Tags)))
+		payload.Tags = payload.Tags[:maxPostTags]
+	}
+
+	if opts.DryRun {
+		item.Status = ImportCreated
+		slugs[entry.Slug] = true
+		return nil
+	}
+
+	if !opts.SkipImages {
+		if entry.FeatureImage != "" {
+			if err = ps.importCoverImage(entry.FeatureImage, opts.SiteUrl, payload); err != nil {
+				item.Warnings = append(item.Warnings, fmt.Sprintf("cover image: %s", err.Error()))
+			}
+		}
+
+		item.Warnings = append(item.Warnings, ps.importEmbedImages(doc, opts.SiteUrl)...)
+	}
+
+	// public posts require cover image
+	if payload.Public && payload.CoverImagePath == "" {
+		item.Warnings = append(item.Warnings, "no cover image, imported as private")
+		payload.Public = false
+	}
+
+	if body, err = doc.Body(); err != nil {
+		return err
+	}
+	payload.Body = body
+
+	res, err := ps.CreatePost(payload)
+	if err != nil {
+		return err
+	}
+
+	oid = res.InsertedID.(primitive.ObjectID)
+	item.Status, item.PostId = ImportCreated, oid.Hex()
+	slugs[entry.Slug] = true
+
+	// keep original publish date
+	if !entry.PublishedAt.IsZero() {
+		if _, err = ps.Repo.Update(ps.Ctx, oid, bson.M{"createdAt": entry.PublishedAt}); err != nil {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("publish date: %s", err.Error()))
+		} else if err = ps.queueIndex(oid, payload.Public); err != nil {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("search index: %s", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// importCoverImage uploads featured image of entry as post cover image
+func (ps *PostService) importCoverImage(raw string, siteUrl string, payload *types.CreatePostPayload) error {
+	url, err := resolveImportUrl(raw, siteUrl)
+	if err != nil {
+		return err
+	}
+
+	data, err := fetchImportImage(ps.Ctx, url)
+	if err != nil {
+		return err
+	}
+
+	res, err := ps.UploadPostCoverImage(data)
+	if err != nil {
+		return err
+	}
+
+	payload.CoverImageId = res.AssetId
+	payload.CoverImagePath = res.PublicId
+	return nil
+}
+
+// ImportPosts creates posts from WordPress WXR or Ghost JSON export,
+// every entry is reported as created, skipped or failed
+// Entries whose slug already exists are skipped so an import can be repeated
+func (ps *PostService) ImportPosts(data []byte, opts types.ImportOptions) (*types.ImportReport, error) {
+	var (
+		entries []importer.Entry
+		posts   []models.PostDocument
+		topics  *importTopics
+		slugs   = make(map[string]bool)
+		err     error
+	)
+
+	if opts.Source == "" {
+		if opts.Source, err = importer.Detect(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if entries, err = importer.Parse(opts.Source, data); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.ImportPosts] %s", err.Error()))
+		return nil, err
+	}
+
+	if topics, err = newImportTopics(*ps.TopicServiceRef.GetAllTopics(), &opts); err != nil {
+		return nil, err
+	}
+
+	if posts, err = ps.Repo.FindAll(ps.Ctx); err != nil {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		slugs[post.Slug] = true
+	}
+
+	report := &types.ImportReport{
+		Source: opts.Source,
+		DryRun: opts.DryRun,
+		Items:  make([]types.ImportItem, 0, len(entries)),
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		item := types.ImportItem{
+			SourceId: entry.SourceId,
+			Title:    entry.Title,
+			Slug:     entry.Slug,
+		}
+
+		if err = ps.importEntry(entry, &item, topics, slugs, &opts); err != nil {
+			item.Status, item.Reason = ImportFailed, err.Error()
+		}
+
+		switch item.Status {
+		case ImportCreated:
+			report.Created++
+		case ImportSkipped:
+			report.Skipped++
+		case ImportFailed:
+			report.Failed++
+		}
+
+		report.Items = append(report.Items, item)
+	}
+
+	util.Log.Info(fmt.Sprintf(
+		"[PostService.ImportPosts] Imported %s export (created=%d, skipped=%d, failed=%d, dryRun=%t)",
+		opts.Source,
+		report.Created,
+		report.Skipped,
+		report.Failed,
+		opts.DryRun))
+
+	return report, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postbody"
+	"github.com/rajatxs/go-fconsole/util"
+)
+
+// search record settings
+const (
+	// indexRecordMaxBytes keeps encoded record under Algolia's per-record size limit
+	indexRecordMaxBytes = 10000
+
+	// excerptMaxChars is length of body summary kept in search record
+	excerptMaxChars = 300
+
+	readingWordsPerMinute = 200
+)
+
+// readingTime returns minutes needed to read given number of words, rounded up
+func readingTime(words int) int {
+	return (words + readingWordsPerMinute - 1) / readingWordsPerMinute
+}
+
+// excerpt returns beginning of text up to excerptMaxChars characters, cut at word boundary
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	chars := 0
+	for i := range text {
+		if chars == excerptMaxChars {
+			return truncateWords(text, i)
+		}
+		chars++
+	}
+	return text
+}
+
+// metadataRecord returns search record fields derived from post metadata,
+// body fields are left empty and so is image of posts without cover
+func (ps *PostService) metadataRecord(metadata *models.PostMetadataDocument) *models.PostIndex {
+	var image string
+
+	if cover := metadata.CoverImage; cover != nil && cover.Path != "" {
+		image = ps.Media.Url(cover.Path, &media.Transform{Crop: "scale", Height: 600, Format: "webp"})
+	}
+
+	return &models.PostIndex{
+		ObjectId:  metadata.Id.Hex(),
+		Schema:    models.PostIndexSchema,
+		Name:      metadata.Title,
+		Topic:     ps.TopicServiceRef.GetTopicNameById(metadata.Topic),
+		Author:    ps.AuthorServiceRef.GetAuthorNameById(metadata.AuthorId.Hex()),
+		Desc:      metadata.Desc,
+		Tags:      metadata.Tags,
+		Url:       fmt.Sprintf("%s/%s", config.ClientUrl(), metadata.Slug),
+		Image:     image,
+		License:   metadata.License,
+		CreatedAt: metadata.CreatedAt,
+		UpdatedAt: metadata.UpdatedAt,
+	}
+}
+
+// recordOutdated reports whether indexed record differs from fields derived
+// from current metadata, such as url after slug change or renamed topic and author
+// Body fields are covered by updatedAt, description may be shortened to fit size limit
+func recordOutdated(indexed *models.PostIndex, expected *models.PostIndex) bool {
+	return indexed.Schema != expected.Schema ||
+		!indexed.UpdatedAt.Equal(expected.UpdatedAt) ||
+		!indexed.CreatedAt.Equal(expected.CreatedAt) ||
+		indexed.Name != expected.Name ||
+		indexed.Topic != expected.Topic ||
+		indexed.Author != expected.Author ||
+		indexed.Url != expected.Url ||
+		indexed.Image != expected.Image ||
+		indexed.License != expected.License ||
+		!strings.HasPrefix(expected.Desc, indexed.Desc) ||
+		strings.Join(indexed.Tags, "\x00") != strings.Join(expected.Tags, "\x00")
+}
+
+// indexRecord returns search record of given public post
+// Body that cannot be read is logged and indexed without text
+func (ps *PostService) indexRecord(post *models.PostDocument) (*models.PostIndex, error) {
+	digest, err := postbody.Summarize(post.Format, post.Body)
+	if err != nil {
+		util.Log.Warning(fmt.Sprintf("[PostService.indexRecord] %s (id='%s')", err.Error(), post.Id.Hex()))
+		digest = &postbody.Digest{Headings: []string{}}
+	}
+
+	record := ps.metadataRecord(&models.PostMetadataDocument{
+		Id:         post.Id,
+		Title:      post.Title,
+		Slug:       post.Slug,
+		Desc:       post.Desc,
+		Tags:       post.Tags,
+		Topic:      post.Topic,
+		AuthorId:   post.AuthorId,
+		License:    post.License,
+		CreatedAt:  post.CreatedAt,
+		UpdatedAt:  post.UpdatedAt,
+		CoverImage: post.CoverImage,
+	})
+	record.Excerpt = excerpt(digest.Text)
+	record.Headings = digest.Headings
+	record.WordCount = digest.WordCount
+	record.ReadingTime = readingTime(digest.WordCount)
+
+	if err = fitIndexRecord(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// fitIndexRecord shortens excerpt, then drops trailing headings and then
+// shortens description until encoded record fits indexRecordMaxBytes
+func fitIndexRecord(record *models.PostIndex) error {
+	for {
+		raw, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		excess := len(raw) - indexRecordMaxBytes
+		switch {
+		case excess <= 0:
+			return nil
+		case record.Excerpt != "":
+			record.Excerpt = truncateWords(record.Excerpt, len(record.Excerpt)-excess)
+		case len(record.Headings) > 0:
+			record.Headings = record.Headings[:len(record.Headings)-1]
+		case record.Desc != "":
+			record.Desc = truncateWords(record.Desc, len(record.Desc)-excess)
+		default:
+			return fmt.Errorf("search record of post '%s' exceeds %d bytes", record.ObjectId, indexRecordMaxBytes)
+		}
+	}
+}
+
+// truncateWords cuts text to at most max bytes, preferring the last word boundary
+func truncateWords(text string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+
+	if len(text) <= max {
+		return text
+	}
+
+	// step back to rune boundary
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	text = text[:max]
+
+	if space := strings.LastIndexByte(text, ' '); space > 0 {
+		text = text[:space]
+	}
+	return strings.TrimSpace(text)
+}
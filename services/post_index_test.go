@@ -0,0 +1,93 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"empty", "", ""},
+		{"short text kept", "Hello\n\nworld", "Hello world"},
+		{"cut at word boundary", long, strings.TrimSpace(strings.Repeat("word ", 60))},
+		{"multibyte", strings.Repeat("ü", 400), strings.Repeat("ü", 300)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := excerpt(tt.text)
+			if got != tt.want {
+				t.Errorf("excerpt() = %q, want %q", got, tt.want)
+			}
+
+			if n := utf8.RuneCountInString(got); n > excerptMaxChars {
+				t.Errorf("excerpt has %d characters, want at most %d", n, excerptMaxChars)
+			}
+		})
+	}
+}
+
+func TestFitIndexRecord(t *testing.T) {
+	record := &models.PostIndex{
+		ObjectId: "post",
+		Excerpt:  strings.Repeat("lorem ", 50),
+		Headings: []string{strings.Repeat("a", 6000), strings.Repeat("b", 6000)},
+		Desc:     "description",
+	}
+
+	if err := fitIndexRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(record)
+	if len(raw) > indexRecordMaxBytes {
+		t.Errorf("record has %d bytes, want at most %d", len(raw), indexRecordMaxBytes)
+	}
+
+	if record.Excerpt != "" || len(record.Headings) != 1 || record.Desc != "description" {
+		t.Errorf("excerpt and trailing heading should be dropped first, got excerpt=%q headings=%d desc=%q",
+			record.Excerpt, len(record.Headings), record.Desc)
+	}
+}
+
+func TestMetadataRecordImage(t *testing.T) {
+	ps := newTestPostService(t)
+
+	tests := []struct {
+		name  string
+		cover *models.PostCoverImage
+		want  string
+	}{
+		{"no cover", nil, ""},
+		{"empty cover path", &models.PostCoverImage{RefName: "Photo"}, ""},
+		{"cover", &models.PostCoverImage{Id: "cover", Path: "posts/cover"}, media.LocalUrlPrefix + "posts/cover"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := ps.metadataRecord(&models.PostMetadataDocument{
+				Id:         primitive.NewObjectID(),
+				Title:      "Post",
+				Topic:      "go",
+				AuthorId:   testAuthorId,
+				CoverImage: tt.cover,
+			})
+
+			if record.Image != tt.want {
+				t.Errorf("Image = %q, want %q", record.Image, tt.want)
+			}
+		})
+	}
+}
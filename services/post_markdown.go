@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/config"
+	"github.com/rajatxs/go-fconsole/editorjs"
+	"github.com/rajatxs/go-fconsole/markdown"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postbody"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ExportPostMarkdown returns post as Markdown with YAML front matter by given Raw ID
+func (ps *PostService) ExportPostMarkdown(rawid string) (string, error) {
+	var (
+		oid  primitive.ObjectID
+		post *models.PostDocument
+		doc  *editorjs.Document
+		err  error
+	)
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return "", err
+	}
+
+	if post, err = ps.Repo.FindById(ps.Ctx, oid); err != nil {
+		return "", err
+	}
+
+	fm := &markdown.FrontMatter{
+		Title:   post.Title,
+		Slug:    post.Slug,
+		Desc:    post.Desc,
+		Topic:   post.Topic,
+		License: post.License,
+		Tags:    post.Tags,
+	}
+
+	// markdown posts are exported as stored
+	if post.Format == models.PostFormatMarkdown {
+		source, err := markdown.Source(post.Body)
+		if err != nil {
+			return "", err
+		}
+		return fm.String() + "\n" + source, nil
+	}
+
+	if doc, err = postbody.Parse(post.Format, post.Body); err != nil {
+		return "", err
+	}
+
+	return fm.String() + "\n" + markdown.FromDocument(doc), nil
+}
+
+// CreatePostFromMarkdown creates private block post from Markdown source,
+// post fields are read from YAML front matter
+func (ps *PostService) CreatePostFromMarkdown(source string) (*mongo.InsertOneResult, error) {
+	fm, doc, err := markdown.Parse(source)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.CreatePostFromMarkdown] %s", err.Error()))
+		return nil, err
+	}
+
+	if strings.TrimSpace(fm.Title) == "" {
+		return nil, errors.New("front matter must define title")
+	}
+
+	body, err := doc.Body()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &types.CreatePostPayload{
+		Title:        fm.Title,
+		Slug:         fm.Slug,
+		Desc:         fm.Desc,
+		Tags:         fm.Tags,
+		Topic:        fm.Topic,
+		Body:         body,
+		Format:       models.PostFormatBlock,
+		Public:       false,
+		AuthorId:     config.AdminId(),
+		License:      fm.License,
+		RelatedPosts: []string{},
+	}
+
+	if payload.Tags == nil {
+		payload.Tags = []string{}
+	}
+
+	return ps.CreatePost(payload)
+}
+
+// ConvertPostFormat converts body of post into given format,
+// previous version is kept in revision history
+func (ps *PostService) ConvertPostFormat(rawid string, format string) (err error) {
+	var (
+		oid    primitive.ObjectID
+		post   *models.PostDocument
+		body   bson.M
+		before = ps.summarizePost(rawid)
+	)
+
+	defer func() { ps.auditPost(AuditPostConvert, rawid, before, err) }()
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return err
+	}
+
+	if format, err = postbody.Normalize(format); err != nil {
+		return err
+	}
+
+	if post, err = ps.Repo.FindById(ps.Ctx, oid); err != nil {
+		return err
+	}
+
+	if current, _ := postbody.Normalize(post.Format); current == format {
+		return nil
+	}
+
+	if body, err = postbody.Convert(post.Body, post.Format, format); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.ConvertPostFormat] %s", err.Error()))
+		return err
+	}
+
+	if err = ps.writeWithIndex(oid, post.Public && !post.Deleted, func(ctx context.Context) error {
+		if err := ps.snapshotPost(ctx, oid, RevisionReasonConvert); err != nil {
+			return err
+		}
+
+		_, err := ps.Repo.Update(ctx, oid, bson.M{
+			"format":    format,
+			"body":      body,
+			"updatedAt": time.Now(),
+		})
+		return err
+	}); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.ConvertPostFormat] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info(fmt.Sprintf("[PostService.ConvertPostFormat] Converted post to %s (id='%s')", format, rawid))
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/indexer"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// search index outbox settings
+const (
+	indexRetryBase = time.Minute
+	indexRetryMax  = time.Hour
+	indexBatchSize = 100
+
+	// IndexStuckAttempts is number of failed attempts after which outbox entry is reported as stuck
+	IndexStuckAttempts = 5
+)
+
+// indexAction returns search index operation matching post visibility
+func indexAction(public bool) string {
+	if public {
+		return indexer.ActionSave
+	}
+	return indexer.ActionDelete
+}
+
+// indexRetryDelay returns wait time before given attempt, doubling from indexRetryBase up to indexRetryMax
+func indexRetryDelay(attempt int) time.Duration {
+	delay := indexRetryBase
+
+	for i := 1; i < attempt && delay < indexRetryMax; i++ {
+		delay *= 2
+	}
+
+	if delay > indexRetryMax {
+		delay = indexRetryMax
+	}
+	return delay
+}
+
+// writeWithIndex runs write and queues search index update of post in one
+// transaction, then applies the update right away
+// Failed index updates stay in outbox and are retried by index worker
+func (ps *PostService) writeWithIndex(id primitive.ObjectID, public bool, write func(ctx context.Context) error) error {
+	if err := ps.Tx.Run(ps.Ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		return ps.Outbox.Enqueue(ctx, id, indexAction(public), time.Now())
+	}); err != nil {
+		return err
+	}
+
+	ps.flushIndex(id)
+	return nil
+}
+
+// queueIndex queues search index update of post written outside of
+// transaction and applies it right away
+func (ps *PostService) queueIndex(id primitive.ObjectID, public bool) error {
+	if err := ps.Outbox.Enqueue(ps.Ctx, id, indexAction(public), time.Now()); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.queueIndex] %s", err.Error()))
+		return err
+	}
+
+	ps.flushIndex(id)
+	return nil
+}
+
+// flushIndex applies pending outbox entry of post, failures are recorded for retry
+func (ps *PostService) flushIndex(id primitive.ObjectID) {
+	entry, err := ps.Outbox.FindByPost(ps.Ctx, id)
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			util.Log.Error(fmt.Sprintf("[PostService.flushIndex] %s", err.Error()))
+		}
+		return
+	}
+
+	_ = ps.applyIndexEntry(entry)
+}
+
+// syncIndex writes current state of post to search index, public posts are
+// saved while private, deleted and missing posts are removed
+func (ps *PostService) syncIndex(id primitive.ObjectID) error {
+	post, err := ps.Repo.FindById(ps.Ctx, id)
+
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ps.dropIndex(id)
+	case err != nil:
+		return err
+	case post.Public && !post.Deleted:
+		return ps.saveIndex(post)
+	default:
+		return ps.dropIndex(id)
+	}
+}
+
+// applyIndexEntry syncs search index of entry's post and removes entry,
+// failed attempt is scheduled again with exponential backoff
+func (ps *PostService) applyIndexEntry(entry *models.IndexOutboxDocument) (err error) {
+	now := time.Now()
+
+	if err = ps.syncIndex(entry.PostId); err != nil {
+		next := now.Add(indexRetryDelay(entry.Attempts + 1))
+
+		if failErr := ps.Outbox.Fail(ps.Ctx, entry.Id, entry.Version, err.Error(), next, now); failErr != nil {
+			util.Log.Error(fmt.Sprintf("[PostService.applyIndexEntry] %s", failErr.Error()))
+		} else {
+			util.Log.Warning(fmt.Sprintf(
+				"[PostService.applyIndexEntry] Index update failed, retrying at %s (postId='%s', attempts=%d)",
+				next.Format(time.RFC3339),
+				entry.PostId.Hex(),
+				entry.Attempts+1))
+		}
+		return err
+	}
+
+	// entry enqueued again meanwhile stays for the next run
+	if _, err = ps.Outbox.Complete(ps.Ctx, entry.Id, entry.Version); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.applyIndexEntry] %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// processIndexEntries applies given outbox entries and returns number of applied ones
+func (ps *PostService) processIndexEntries(entries []models.IndexOutboxDocument) int {
+	applied := 0
+
+	for i := range entries {
+		if ps.applyIndexEntry(&entries[i]) == nil {
+			applied++
+		}
+	}
+	return applied
+}
+
+// processIndexOutbox applies outbox entries whose next attempt is due
+// and returns number of applied entries
+func (ps *PostService) processIndexOutbox() (int, error) {
+	entries, err := ps.Outbox.FindDue(ps.Ctx, time.Now(), indexBatchSize)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.processIndexOutbox] %s", err.Error()))
+		return 0, err
+	}
+	return ps.processIndexEntries(entries), nil
+}
+
+// RetryIndexOutbox applies every outbox entry immediately, ignoring backoff,
+// and returns number of applied entries
+func (ps *PostService) RetryIndexOutbox() (int, error) {
+	entries, err := ps.Outbox.FindFailing(ps.Ctx, 0)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.RetryIndexOutbox] %s", err.Error()))
+		return 0, err
+	}
+	return ps.processIndexEntries(entries), nil
+}
+
+// GetIndexOutbox returns pending search index updates, stuck limits
+// result to entries failed at least IndexStuckAttempts times
+func (ps *PostService) GetIndexOutbox(stuck bool) ([]models.IndexOutboxDocument, error) {
+	minAttempts := 0
+	if stuck {
+		minAttempts = IndexStuckAttempts
+	}
+
+	entries, err := ps.Outbox.FindFailing(ps.Ctx, minAttempts)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.GetIndexOutbox] %s", err.Error()))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// startIndexWorker applies due outbox entries immediately and then on every
+// interval until stopIndexWorker is called
+func (ps *PostService) startIndexWorker(interval time.Duration) {
+	ps.mu.Lock()
+	if ps.indexStop != nil {
+		ps.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	ps.indexStop = stop
+	ps.mu.Unlock()
+
+	run := func() {
+		if n, err := ps.processIndexOutbox(); err != nil {
+			util.Log.Error(fmt.Sprintf("[PostService.startIndexWorker] %s", err.Error()))
+		} else if n > 0 {
+			util.Log.Info(fmt.Sprintf("[PostService.startIndexWorker] Applied %d index updates", n))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// stopIndexWorker stops background outbox worker
+func (ps *PostService) stopIndexWorker() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.indexStop != nil {
+		close(ps.indexStop)
+		ps.indexStop = nil
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rajatxs/go-fconsole/indexer"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// number of search index writes sent in single batch
+const reindexBatchSize = 500
+
+// reportIndexProgress passes reconciliation progress to OnIndexProgress
+func (ps *PostService) reportIndexProgress(stage string, done int, total int) {
+	if ps.OnIndexProgress != nil {
+		ps.OnIndexProgress(types.IndexProgress{Stage: stage, Done: done, Total: total})
+	}
+}
+
+// ReconcileIndex compares every search index record with public posts and
+// reports posts missing from index, orphaned records of deleted, private or
+// removed posts and stale records whose updatedAt, record layout or derived
+// fields such as url, topic name or author name differ
+// In fix mode missing and stale records are saved and orphaned ones deleted in batches
+func (ps *PostService) ReconcileIndex(fix bool) (report *types.IndexReconcileReport, err error) {
+	var (
+		posts   []models.PostMetadataDocument
+		records []models.PostIndex
+		public  = make(map[string]*models.PostMetadataDocument)
+		indexed = make(map[string]bool)
+		stale   []*models.PostMetadataDocument
+		saves   []indexer.BatchOperation
+		deletes []indexer.BatchOperation
+	)
+
+	ps.reportIndexProgress(types.IndexStageScan, 0, 0)
+
+	if posts, err = ps.Repo.FindMetadata(ps.Ctx, &types.GetPostsMetadataOptions{Topic: "all"}); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.ReconcileIndex] %s", err.Error()))
+		return nil, err
+	}
+
+	if records, err = ps.Indexer.Browse(); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.ReconcileIndex] %s", err.Error()))
+		return nil, err
+	}
+
+	report = &types.IndexReconcileReport{
+		Fix:      fix,
+		Posts:    len(posts),
+		Records:  len(records),
+		Missing:  []string{},
+		Orphaned: []string{},
+		Stale:    []string{},
+	}
+
+	for i := range posts {
+		public[posts[i].Id.Hex()] = &posts[i]
+	}
+
+	for i := range records {
+		record := &records[i]
+		indexed[record.ObjectId] = true
+
+		if post, ok := public[record.ObjectId]; !ok {
+			report.Orphaned = append(report.Orphaned, record.ObjectId)
+			deletes = append(deletes, indexer.BatchOperation{Action: indexer.ActionDelete, ObjectId: record.ObjectId})
+		} else if recordOutdated(record, ps.metadataRecord(post)) {
+			report.Stale = append(report.Stale, record.ObjectId)
+			stale = append(stale, post)
+		}
+	}
+
+	for id, post := range public {
+		if !indexed[id] {
+			report.Missing = append(report.Missing, id)
+			stale = append(stale, post)
+		}
+	}
+
+	sort.Strings(report.Missing)
+	sort.Strings(report.Orphaned)
+	sort.Strings(report.Stale)
+	ps.reportIndexProgress(types.IndexStageScan, len(records), len(records))
+
+	if fix && len(stale)+len(deletes) > 0 {
+		defer func() {
+			ps.AuditServiceRef.Record("", AuditIndexRepair, "posts", nil, bson.M{
+				"missing":  len(report.Missing),
+				"orphaned": len(report.Orphaned),
+				"stale":    len(report.Stale),
+				"saved":    report.Saved,
+				"deleted":  report.Deleted,
+			}, err)
+		}()
+
+		// records are built from full posts since metadata lacks body
+		for _, metadata := range stale {
+			var (
+				post   *models.PostDocument
+				record *models.PostIndex
+			)
+
+			if post, err = ps.Repo.FindById(ps.Ctx, metadata.Id); err != nil {
+				util.Log.Error(fmt.Sprintf("[PostService.ReconcileIndex] %s", err.Error()))
+				return report, err
+			}
+
+			if record, err = ps.indexRecord(post); err != nil {
+				util.Log.Error(fmt.Sprintf("[PostService.ReconcileIndex] %s", err.Error()))
+				return report, err
+			}
+			saves = append(saves, indexer.BatchOperation{Action: indexer.ActionSave, ObjectId: record.ObjectId, Record: record})
+		}
+
+		if report.Saved, err = ps.applyIndexBatches(types.IndexStageSave, saves); err != nil {
+			util.Log.Error(fmt.Sprintf("[PostService.ReconcileIndex] %s", err.Error()))
+			return report, err
+		}
+
+		if report.Deleted, err = ps.applyIndexBatches(types.IndexStageDelete, deletes); err != nil {
+			util.Log.Error(fmt.Sprintf("[PostService.ReconcileIndex] %s", err.Error()))
+			return report, err
+		}
+	}
+
+	ps.reportIndexProgress(types.IndexStageDone, report.Saved+report.Deleted, len(stale)+len(deletes))
+
+	util.Log.Info(fmt.Sprintf(
+		"[PostService.ReconcileIndex] Reconciled search index (fix=%t, missing=%d, orphaned=%d, stale=%d, saved=%d, deleted=%d)",
+		fix,
+		len(report.Missing),
+		len(report.Orphaned),
+		len(report.Stale),
+		report.Saved,
+		report.Deleted))
+
+	return report, nil
+}
+
+// applyIndexBatches writes given operations in batches of reindexBatchSize
+// and returns number of applied operations
+func (ps *PostService) applyIndexBatches(stage string, ops []indexer.BatchOperation) (int, error) {
+	done := 0
+
+	for done < len(ops) {
+		end := done + reindexBatchSize
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		if err := ps.Indexer.Batch(ops[done:end]); err != nil {
+			return done, err
+		}
+
+		done = end
+		ps.reportIndexProgress(stage, done, len(ops))
+	}
+	return done, nil
+}
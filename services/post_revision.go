@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Revision reasons
+const (
+	RevisionReasonUpdate  = "update"
+	RevisionReasonScope   = "scope"
+	RevisionReasonRestore = "restore"
+	RevisionReasonConvert = "convert"
+)
+
+// snapshotPost writes current state of post into revision history,
+// ctx is the transaction context of the write the snapshot belongs to
+func (ps *PostService) snapshotPost(ctx context.Context, id primitive.ObjectID, reason string) error {
+	var (
+		post *models.PostDocument
+		err  error
+	)
+
+	if post, err = ps.Repo.FindById(ctx, id); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.snapshotPost] %s", err.Error()))
+		return err
+	}
+
+	rev := &models.PostRevisionDocument{
+		Id:        primitive.NewObjectID(),
+		PostId:    id,
+		Reason:    reason,
+		Title:     post.Title,
+		Snapshot:  post,
+		CreatedAt: time.Now(),
+	}
+
+	if err = ps.Revisions.Insert(ctx, rev); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.snapshotPost] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info(fmt.Sprintf("[PostService.snapshotPost] Saved post revision (id='%s', postId='%s', reason='%s')", rev.Id.Hex(), id.Hex(), reason))
+	}
+
+	return nil
+}
+
+// GetPostRevisions returns revision history of post by given rawid, newest first
+func (ps *PostService) GetPostRevisions(rawid string) ([]models.PostRevisionSummary, error) {
+	var (
+		oid primitive.ObjectID
+		err error
+	)
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return nil, err
+	}
+
+	return ps.Revisions.FindByPost(ps.Ctx, oid)
+}
+
+// GetPostRevision returns single revision with post snapshot by given revision rawid
+func (ps *PostService) GetPostRevision(rawid string) (*models.PostRevisionDocument, error) {
+	var (
+		oid primitive.ObjectID
+		err error
+	)
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return nil, err
+	}
+
+	return ps.Revisions.FindById(ps.Ctx, oid)
+}
+
+// RestorePostRevision replaces current post content with given revision snapshot
+// Current content is saved as a new revision before restoring
+func (ps *PostService) RestorePostRevision(rawid string) (res *mongo.UpdateResult, err error) {
+	var (
+		rev    *models.PostRevisionDocument
+		post   *models.PostDocument
+		postId string
+		before bson.M
+	)
+
+	defer func() { ps.auditPost(AuditPostRevision, postId, before, err) }()
+
+	if rev, err = ps.GetPostRevision(rawid); err != nil {
+		return nil, err
+	}
+
+	postId = rev.PostId.Hex()
+	before = ps.summarizePost(postId)
+
+	if post, err = ps.Repo.FindById(ps.Ctx, rev.PostId); err != nil {
+		return nil, err
+	}
+
+	snapshot := rev.Snapshot
+
+	// slug, topic and related posts of the snapshot may have changed since
+	if err = ps.validateRestorePost(post, snapshot); err != nil {
+		return nil, err
+	}
+
+	fields := bson.M{
+		"title":      snapshot.Title,
+		"slug":       snapshot.Slug,
+		"desc":       snapshot.Desc,
+		"topic":      snapshot.Topic,
+		"tags":       snapshot.Tags,
+		"body":       snapshot.Body,
+		"format":     snapshot.Format,
+		"public":     snapshot.Public,
+		"coverImage": snapshot.CoverImage,
+		"license":    snapshot.License,
+		"related":    snapshot.Related,
+		"updatedAt":  time.Now(),
+	}
+
+	// keep current version, restore and redirect former slug in one transaction
+	if err = ps.writeWithIndex(rev.PostId, snapshot.Public && !post.Deleted, func(ctx context.Context) (err error) {
+		if err = ps.snapshotPost(ctx, rev.PostId, RevisionReasonRestore); err != nil {
+			return err
+		}
+
+		if res, err = ps.Repo.Update(ctx, rev.PostId, fields); err != nil {
+			return err
+		}
+
+		if post.Slug != snapshot.Slug {
+			return ps.recordSlugChange(ctx, rev.PostId, post.Slug, snapshot.Slug)
+		}
+		return nil
+	}); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.RestorePostRevision] %s", err.Error()))
+		return nil, slugConflictError(err)
+	} else {
+		util.Log.Info(fmt.Sprintf("[PostService.RestorePostRevision] Restored post revision (id='%s', postId='%s')", rev.Id.Hex(), rev.PostId.Hex()))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// updateTestPost applies update to payload of stored post and saves it
+func updateTestPost(t *testing.T, ps *PostService, post *models.PostDocument, update func(p *types.UpdatePostPayload)) {
+	t.Helper()
+
+	current, err := ps.Repo.FindById(ps.Ctx, post.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := types.UpdatePostPayload{
+		Title:   current.Title,
+		Slug:    current.Slug,
+		Topic:   current.Topic,
+		Body:    current.Body,
+		License: current.License,
+	}
+	for _, oid := range current.Related {
+		payload.RelatedPosts = append(payload.RelatedPosts, oid.Hex())
+	}
+	update(&payload)
+
+	if _, err := ps.UpdatePostById(post.Id.Hex(), payload); err != nil {
+		t.Fatalf("UpdatePostById() error = %v", err)
+	}
+}
+
+func TestRestorePostRevision(t *testing.T) {
+	tests := []struct {
+		name    string
+		topic   string
+		setup   func(t *testing.T, ps *PostService, post *models.PostDocument)
+		wantErr bool
+	}{
+		{
+			name:  "previous title",
+			topic: "go",
+			setup: func(t *testing.T, ps *PostService, post *models.PostDocument) {
+				updateTestPost(t, ps, post, func(p *types.UpdatePostPayload) { p.Title = "Edited" })
+			},
+		},
+		{
+			name:  "archived topic",
+			topic: "old",
+			setup: func(t *testing.T, ps *PostService, post *models.PostDocument) {
+				updateTestPost(t, ps, post, func(p *types.UpdatePostPayload) { p.Topic = "go" })
+			},
+			wantErr: true,
+		},
+		{
+			name:  "slug taken since",
+			topic: "go",
+			setup: func(t *testing.T, ps *PostService, post *models.PostDocument) {
+				updateTestPost(t, ps, post, func(p *types.UpdatePostPayload) { p.Slug = "renamed" })
+				insertTestPost(t, ps, "post", "go")
+			},
+			wantErr: true,
+		},
+		{
+			name:  "related post deleted since",
+			topic: "go",
+			setup: func(t *testing.T, ps *PostService, post *models.PostDocument) {
+				related := insertTestPost(t, ps, "related", "go")
+				updateTestPost(t, ps, post, func(p *types.UpdatePostPayload) { p.RelatedPosts = []string{related.Id.Hex()} })
+				updateTestPost(t, ps, post, func(p *types.UpdatePostPayload) { p.RelatedPosts = nil })
+
+				if _, err := ps.Repo.Update(ps.Ctx, related.Id, bson.M{"deleted": true}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := newTestPostService(t)
+			post := insertTestPost(t, ps, "post", tt.topic)
+			tt.setup(t, ps, post)
+
+			revs, err := ps.GetPostRevisions(post.Id.Hex())
+			if err != nil || len(revs) == 0 {
+				t.Fatalf("GetPostRevisions() = %v, %v", revs, err)
+			}
+
+			rev, err := ps.GetPostRevision(revs[0].Id.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			before, _ := ps.Repo.FindById(ps.Ctx, post.Id)
+
+			_, err = ps.RestorePostRevision(revs[0].Id.Hex())
+			if tt.wantErr {
+				if !isValidationError(err) {
+					t.Fatalf("RestorePostRevision() error = %v, want validation error", err)
+				}
+
+				if after, _ := ps.Repo.FindById(ps.Ctx, post.Id); after.Title != before.Title || after.Slug != before.Slug || after.Topic != before.Topic {
+					t.Errorf("rejected restore changed post to %+v", after)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RestorePostRevision() error = %v", err)
+			}
+
+			restored, _ := ps.Repo.FindById(ps.Ctx, post.Id)
+			if restored.Title != rev.Snapshot.Title || restored.Slug != rev.Snapshot.Slug || restored.Topic != rev.Snapshot.Topic {
+				t.Errorf("restored post = %+v, want snapshot %+v", restored, rev.Snapshot)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RevisionReasonSchedule marks revisions written by scheduled scope changes
+const RevisionReasonSchedule = "schedule"
+
+// SetPostSchedule sets publish and/or unpublish time of post
+// Given times must lie in the future and unpublish time, given or stored,
+// must not be earlier than publish time
+func (ps *PostService) SetPostSchedule(rawid string, schedule types.PostSchedulePayload) (err error) {
+	var (
+		oid     primitive.ObjectID
+		current *models.PostDocument
+		now     = time.Now()
+		fields  = bson.M{"updatedAt": now}
+		before  = ps.summarizePost(rawid)
+	)
+
+	defer func() { ps.auditPost(AuditPostSchedule, rawid, before, err) }()
+
+	if schedule.PublishAt == nil && schedule.UnpublishAt == nil {
+		return errors.New("publish or unpublish time is required")
+	}
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return err
+	}
+
+	if current, err = ps.Repo.FindById(ps.Ctx, oid); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("post not found (id='%s')", rawid)
+		}
+		return err
+	}
+
+	if err = validateSchedule(current, &schedule, now); err != nil {
+		return err
+	}
+
+	if schedule.PublishAt != nil {
+		fields["publishAt"] = schedule.PublishAt.UTC()
+	}
+
+	if schedule.UnpublishAt != nil {
+		fields["unpublishAt"] = schedule.UnpublishAt.UTC()
+	}
+
+	if res, err := ps.Repo.Update(ps.Ctx, oid, fields); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.SetPostSchedule] %s", err.Error()))
+		return err
+	} else if res.MatchedCount == 0 {
+		return fmt.Errorf("post not found (id='%s')", rawid)
+	} else {
+		util.Log.Info(fmt.Sprintf("[PostService.SetPostSchedule] Updated post schedule (id='%s')", rawid))
+	}
+
+	return nil
+}
+
+// validateSchedule checks given schedule against current time and schedule stored in post
+func validateSchedule(post *models.PostDocument, schedule *types.PostSchedulePayload, now time.Time) error {
+	var (
+		verr        = &validation.Error{}
+		publishAt   = post.PublishAt
+		unpublishAt = post.UnpublishAt
+	)
+
+	if schedule.PublishAt != nil {
+		publishAt = schedule.PublishAt
+
+		if !schedule.PublishAt.After(now) {
+			verr.Add("publishAt", "must be in the future")
+		}
+	}
+
+	if schedule.UnpublishAt != nil {
+		unpublishAt = schedule.UnpublishAt
+
+		if !schedule.UnpublishAt.After(now) {
+			verr.Add("unpublishAt", "must be in the future")
+		}
+	}
+
+	if publishAt != nil && unpublishAt != nil && unpublishAt.Before(*publishAt) {
+		verr.Add("unpublishAt", "must not be earlier than publish time %s", publishAt.UTC().Format(time.RFC3339))
+	}
+
+	return verr.Err()
+}
+
+// ClearPostSchedule removes publish and unpublish time of post
+func (ps *PostService) ClearPostSchedule(rawid string) (err error) {
+	var (
+		oid    primitive.ObjectID
+		before = ps.summarizePost(rawid)
+	)
+
+	defer func() { ps.auditPost(AuditPostScheduleClear, rawid, before, err) }()
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return err
+	}
+
+	if _, err = ps.Repo.Update(ps.Ctx, oid, bson.M{
+		"publishAt":   nil,
+		"unpublishAt": nil,
+		"updatedAt":   time.Now(),
+	}); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.ClearPostSchedule] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info(fmt.Sprintf("[PostService.ClearPostSchedule] Cleared post schedule (id='%s')", rawid))
+	}
+
+	return nil
+}
+
+// nextScheduleTime returns earliest pending time of schedule
+func nextScheduleTime(s *types.PostSchedule) time.Time {
+	switch {
+	case s.PublishAt == nil:
+		return *s.UnpublishAt
+	case s.UnpublishAt == nil || s.PublishAt.Before(*s.UnpublishAt):
+		return *s.PublishAt
+	default:
+		return *s.UnpublishAt
+	}
+}
+
+// GetScheduledPosts returns pending schedules ordered by next run time
+func (ps *PostService) GetScheduledPosts() ([]types.PostSchedule, error) {
+	posts, err := ps.Repo.FindScheduled(ps.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]types.PostSchedule, len(posts))
+	for i, post := range posts {
+		schedules[i] = types.PostSchedule{
+			PostId:      post.Id.Hex(),
+			Title:       post.Title,
+			Public:      post.Public,
+			PublishAt:   post.PublishAt,
+			UnpublishAt: post.UnpublishAt,
+		}
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return nextScheduleTime(&schedules[i]).Before(nextScheduleTime(&schedules[j]))
+	})
+
+	return schedules, nil
+}
+
+// applySchedule flips scope of post whose publish or unpublish time has come
+func (ps *PostService) applySchedule(post *models.PostDocument, now time.Time) (err error) {
+	var (
+		fields  = bson.M{}
+		public  = post.Public
+		publish = post.PublishAt != nil && !post.PublishAt.After(now)
+		hide    = post.UnpublishAt != nil && !post.UnpublishAt.After(now)
+	)
+
+	if !publish && !hide {
+		return nil
+	}
+
+	if publish {
+		fields["publishAt"] = nil
+		public = true
+	}
+
+	if hide {
+		fields["unpublishAt"] = nil
+
+		// when both are due the later one decides final scope
+		if !publish || !post.UnpublishAt.Before(*post.PublishAt) {
+			public = false
+		}
+	}
+
+	defer func() {
+		var after bson.M
+		if err == nil {
+			after = ps.summarizePost(post.Id.Hex())
+		}
+		ps.AuditServiceRef.Record(AuditActorScheduler, AuditPostScheduleRun, post.Id.Hex(), postSummary(post), after, err)
+	}()
+
+	fields["public"] = public
+	fields["updatedAt"] = now
+
+	if err = ps.writeWithIndex(post.Id, public, func(ctx context.Context) error {
+		if err := ps.snapshotPost(ctx, post.Id, RevisionReasonSchedule); err != nil {
+			return err
+		}
+
+		_, err := ps.Repo.Update(ctx, post.Id, fields)
+		return err
+	}); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.applySchedule] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info(fmt.Sprintf("[PostService.applySchedule] Applied post schedule (id='%s', public=%t)", post.Id.Hex(), public))
+	}
+
+	return nil
+}
+
+// runDueSchedules applies every publish and unpublish time that has passed,
+// including ones missed while the app was not running
+func (ps *PostService) runDueSchedules() (applied int, err error) {
+	var (
+		posts []models.PostDocument
+		now   = time.Now()
+	)
+
+	if posts, err = ps.Repo.FindScheduled(ps.Ctx); err != nil {
+		return 0, err
+	}
+
+	for i := range posts {
+		post := &posts[i]
+		due := (post.PublishAt != nil && !post.PublishAt.After(now)) ||
+			(post.UnpublishAt != nil && !post.UnpublishAt.After(now))
+
+		if !due {
+			continue
+		}
+
+		// failed post is retried on the next run and does not block others
+		if err := ps.applySchedule(post, now); err != nil {
+			util.Log.Error(fmt.Sprintf("[PostService.runDueSchedules] %s (id='%s')", err.Error(), post.Id.Hex()))
+			continue
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// startScheduler runs due schedules immediately and then on every interval
+// until stopScheduler is called
+func (ps *PostService) startScheduler(interval time.Duration) {
+	ps.mu.Lock()
+	if ps.stop != nil {
+		ps.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	ps.stop = stop
+	ps.mu.Unlock()
+
+	run := func() {
+		if n, err := ps.runDueSchedules(); err != nil {
+			util.Log.Error(fmt.Sprintf("[PostService.startScheduler] %s", err.Error()))
+		} else if n > 0 {
+			util.Log.Info(fmt.Sprintf("[PostService.startScheduler] Applied %d post schedules", n))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// stopScheduler stops background schedule runner
+func (ps *PostService) stopScheduler() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.stop != nil {
+		close(ps.stop)
+		ps.stop = nil
+	}
+}
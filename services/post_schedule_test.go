@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	var (
+		now    = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		past   = now.Add(-time.Hour)
+		soon   = now.Add(time.Hour)
+		later  = now.Add(2 * time.Hour)
+		stored = &models.PostDocument{PublishAt: &later}
+	)
+
+	tests := []struct {
+		name     string
+		post     *models.PostDocument
+		schedule types.PostSchedulePayload
+		wantErr  bool
+	}{
+		{"publish only", &models.PostDocument{}, types.PostSchedulePayload{PublishAt: &soon}, false},
+		{"publish before unpublish", &models.PostDocument{}, types.PostSchedulePayload{PublishAt: &soon, UnpublishAt: &later}, false},
+		{"same publish and unpublish", &models.PostDocument{}, types.PostSchedulePayload{PublishAt: &soon, UnpublishAt: &soon}, false},
+		{"unpublish before publish", &models.PostDocument{}, types.PostSchedulePayload{PublishAt: &later, UnpublishAt: &soon}, true},
+		{"unpublish before stored publish", stored, types.PostSchedulePayload{UnpublishAt: &soon}, true},
+		{"new publish before unpublish", stored, types.PostSchedulePayload{PublishAt: &soon, UnpublishAt: &later}, false},
+		{"past publish", &models.PostDocument{}, types.PostSchedulePayload{PublishAt: &past}, true},
+		{"past unpublish", &models.PostDocument{}, types.PostSchedulePayload{UnpublishAt: &past}, true},
+		{"publish now", &models.PostDocument{}, types.PostSchedulePayload{PublishAt: &now}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSchedule(tt.post, &tt.schedule, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSchedule() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/slug"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultSlug is used for titles without any transliterable letters or digits
+const defaultSlug = "post"
+
+// maxSlugSuffix limits numeric suffixes tried for single base slug
+const maxSlugSuffix = 1000
+
+// RevisionReasonSlugMigration marks revisions written by duplicate slug migration
+const RevisionReasonSlugMigration = "slug-migration"
+
+// load creates indexes of posts, redirects and index outbox
+// The unique slug index cannot be built while posts share a slug, this is
+// reported and left to MigrateDuplicateSlugs
+func (ps *PostService) load() (err error) {
+	if err = ps.Repo.EnsureIndexes(ps.Ctx); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			util.Log.Error(fmt.Sprintf("[PostService.load] %s", err.Error()))
+			return err
+		}
+		util.Log.Warning("[PostService.load] Posts share slugs, run 'fconsole posts dedupe-slugs' to rename them and build the unique slug index")
+	}
+
+	if err = ps.Redirects.EnsureIndexes(ps.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.load] %s", err.Error()))
+		return err
+	}
+
+	if err = ps.Outbox.EnsureIndexes(ps.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.load] %s", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// MigrateDuplicateSlugs keeps slug of the oldest post and gives suffixed
+// slugs to newer posts sharing it, posts without slug get one from title
+// Each rename is snapshot into revision history, retargets redirects of the
+// post and queues its search index update in one transaction, the shared
+// slug stays with the oldest post so no redirect is added from it
+// Unique slug index is built once every duplicate is renamed
+func (ps *PostService) MigrateDuplicateSlugs(dryRun bool) (report *types.SlugMigrationReport, err error) {
+	var (
+		posts    []models.PostDocument
+		groups   = make(map[string][]models.PostDocument)
+		reserved = make(map[string]bool)
+	)
+
+	if posts, err = ps.Repo.FindAll(ps.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.MigrateDuplicateSlugs] %s", err.Error()))
+		return nil, err
+	}
+
+	for _, post := range posts {
+		groups[post.Slug] = append(groups[post.Slug], post)
+	}
+
+	report = &types.SlugMigrationReport{DryRun: dryRun, Renamed: []types.SlugRename{}}
+
+	for current, group := range groups {
+		if current != "" && len(group) < 2 {
+			continue
+		}
+
+		sort.Slice(group, func(i, j int) bool {
+			if !group[i].CreatedAt.Equal(group[j].CreatedAt) {
+				return group[i].CreatedAt.Before(group[j].CreatedAt)
+			}
+			return group[i].Id.Hex() < group[j].Id.Hex()
+		})
+
+		// the oldest post keeps a non empty slug
+		if current != "" {
+			group = group[1:]
+		}
+
+		for i := range group {
+			post := &group[i]
+			var renamed string
+
+			// slugs picked in dry run are not written, so they are reserved here
+			if renamed, err = ps.freeSlug(titleSlug(post.Title, current), post.Id, reserved); err != nil {
+				util.Log.Error(fmt.Sprintf("[PostService.MigrateDuplicateSlugs] %s", err.Error()))
+				return report, err
+			}
+			reserved[renamed] = true
+
+			if !dryRun {
+				if err = ps.renameSlug(post, renamed); err != nil {
+					util.Log.Error(fmt.Sprintf("[PostService.MigrateDuplicateSlugs] %s", err.Error()))
+					return report, err
+				}
+			}
+
+			report.Renamed = append(report.Renamed, types.SlugRename{
+				PostId: post.Id.Hex(),
+				Title:  post.Title,
+				From:   current,
+				To:     renamed,
+			})
+		}
+	}
+
+	sort.Slice(report.Renamed, func(i, j int) bool {
+		return report.Renamed[i].PostId < report.Renamed[j].PostId
+	})
+
+	if dryRun {
+		return report, nil
+	}
+
+	if err = ps.Repo.EnsureIndexes(ps.Ctx); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.MigrateDuplicateSlugs] %s", err.Error()))
+		return report, err
+	}
+
+	util.Log.Info(fmt.Sprintf("[PostService.MigrateDuplicateSlugs] Renamed %d duplicate slugs", len(report.Renamed)))
+	return report, nil
+}
+
+// renameSlug gives post new slug made by duplicate slug migration
+func (ps *PostService) renameSlug(post *models.PostDocument, renamed string) (err error) {
+	before := postSummary(post)
+
+	defer func() {
+		var after bson.M
+		if err == nil {
+			after = ps.summarizePost(post.Id.Hex())
+		}
+		ps.AuditServiceRef.Record("", AuditPostSlugMigrate, post.Id.Hex(), before, after, err)
+	}()
+
+	if err = ps.writeWithIndex(post.Id, post.Public && !post.Deleted, func(ctx context.Context) (err error) {
+		if err = ps.snapshotPost(ctx, post.Id, RevisionReasonSlugMigration); err != nil {
+			return err
+		}
+
+		if _, err = ps.Repo.Update(ctx, post.Id, bson.M{"slug": renamed, "updatedAt": time.Now()}); err != nil {
+			return err
+		}
+
+		// former slug stays with another post, only redirects of this post are retargeted
+		return ps.recordSlugChange(ctx, post.Id, "", renamed)
+	}); err != nil {
+		return err
+	}
+
+	util.Log.Warning(fmt.Sprintf("[PostService.renameSlug] Renamed duplicate slug (id='%s', from='%s', to='%s')", post.Id.Hex(), post.Slug, renamed))
+	return nil
+}
+
+// titleSlug returns given slug, or slug of title when it is empty
+func titleSlug(title string, current string) string {
+	if current != "" {
+		return current
+	}
+
+	if s := slug.Make(title); s != "" {
+		return s
+	}
+	return defaultSlug
+}
+
+// slugTaken reports whether slug is used by post other than given id,
+// or is source of redirect pointing to another post
+func (ps *PostService) slugTaken(s string, except primitive.ObjectID) (bool, error) {
+	count, err := ps.Repo.CountBySlug(ps.Ctx, s, except)
+	if err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	redirect, err := ps.Redirects.FindByFrom(ps.Ctx, s)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return redirect.PostId != except, nil
+	}
+}
+
+// uniqueSlug returns base slug, or base with the lowest free numeric suffix
+// starting from 2 when base is taken by another post
+func (ps *PostService) uniqueSlug(base string, except primitive.ObjectID) (string, error) {
+	return ps.freeSlug(base, except, nil)
+}
+
+// freeSlug works like uniqueSlug and skips reserved slugs as well
+func (ps *PostService) freeSlug(base string, except primitive.ObjectID, reserved map[string]bool) (string, error) {
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = slug.WithSuffix(base, n)
+		}
+
+		if reserved[candidate] {
+			continue
+		}
+
+		taken, err := ps.slugTaken(candidate, except)
+		if err != nil {
+			return "", err
+		}
+
+		if !taken {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free slug for '%s'", base)
+}
+
+// slugTakenError returns field error of slug used by another post
+func slugTakenError() error {
+	verr := &validation.Error{}
+	verr.Add("slug", "is already used by another post or redirect")
+	return verr
+}
+
+// slugConflictError turns duplicate key error of concurrent slug change into field error
+func slugConflictError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return slugTakenError()
+	}
+	return err
+}
+
+// GenerateSlug returns unused slug made from given title
+func (ps *PostService) GenerateSlug(title string) (string, error) {
+	return ps.uniqueSlug(titleSlug(title, ""), primitive.NilObjectID)
+}
+
+// recordSlugChange stores redirect from former slug of post and points
+// older redirects of the post to its new slug
+func (ps *PostService) recordSlugChange(ctx context.Context, postId primitive.ObjectID, from string, to string) (err error) {
+	now := time.Now()
+
+	// post took back one of its former slugs
+	if err = ps.Redirects.DeleteByFrom(ctx, to); err != nil {
+		return err
+	}
+
+	if err = ps.Redirects.Retarget(ctx, postId, to, now); err != nil {
+		return err
+	}
+
+	if from == "" {
+		return nil
+	}
+
+	if err = ps.Redirects.Insert(ctx, &models.SlugRedirectDocument{
+		Id:        primitive.NewObjectID(),
+		PostId:    postId,
+		From:      from,
+		To:        to,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	util.Log.Info(fmt.Sprintf("[PostService.recordSlugChange] Added slug redirect (postId='%s', from='%s', to='%s')", postId.Hex(), from, to))
+	return nil
+}
+
+// GetSlugRedirects returns redirects of given post, every redirect when rawPostId is empty
+func (ps *PostService) GetSlugRedirects(rawPostId string) ([]models.SlugRedirectDocument, error) {
+	var (
+		oid primitive.ObjectID
+		err error
+	)
+
+	if rawPostId != "" {
+		if oid, err = primitive.ObjectIDFromHex(rawPostId); err != nil {
+			return nil, err
+		}
+	}
+
+	return ps.Redirects.FindAll(ps.Ctx, oid)
+}
+
+// AddSlugRedirect adds redirect from given slug to current slug of post,
+// useful for links published before redirects were recorded
+func (ps *PostService) AddSlugRedirect(from string, rawPostId string) (redirect *models.SlugRedirectDocument, err error) {
+	var (
+		verr = &validation.Error{}
+		oid  primitive.ObjectID
+		post *models.PostDocument
+	)
+
+	defer func() {
+		var after bson.M
+		if err == nil {
+			after = bson.M{"redirectId": redirect.Id.Hex(), "from": redirect.From, "to": redirect.To}
+		}
+		ps.AuditServiceRef.Record("", AuditRedirectCreate, rawPostId, nil, after, err)
+	}()
+
+	if oid, err = primitive.ObjectIDFromHex(rawPostId); err != nil {
+		verr.Add("postId", "is not a valid post id")
+	} else if post, err = ps.Repo.FindById(ps.Ctx, oid); errors.Is(err, mongo.ErrNoDocuments) {
+		verr.Add("postId", "references unknown post '%s'", rawPostId)
+	} else if err != nil {
+		return nil, err
+	} else if post.Deleted {
+		verr.Add("postId", "references deleted post '%s'", rawPostId)
+	}
+
+	if verr.Required("from", from) && verr.MaxLength("from", from, slug.MaxLength) && verr.Slug("from", from) {
+		var taken bool
+
+		if taken, err = ps.slugTaken(from, primitive.NilObjectID); err != nil {
+			return nil, err
+		}
+
+		if taken || (post != nil && post.Slug == from) {
+			verr.Add("from", "is already used by a post or redirect")
+		}
+	}
+
+	if err = verr.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	redirect = &models.SlugRedirectDocument{
+		Id:        primitive.NewObjectID(),
+		PostId:    oid,
+		From:      from,
+		To:        post.Slug,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = ps.Redirects.Insert(ps.Ctx, redirect); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.AddSlugRedirect] %s", err.Error()))
+		return nil, err
+	}
+
+	util.Log.Info(fmt.Sprintf("[PostService.AddSlugRedirect] Added slug redirect (postId='%s', from='%s', to='%s')", oid.Hex(), from, post.Slug))
+	return redirect, nil
+}
+
+// DeleteSlugRedirect removes redirect by given rawid
+func (ps *PostService) DeleteSlugRedirect(rawid string) (err error) {
+	var (
+		oid   primitive.ObjectID
+		count int64
+	)
+
+	defer func() { ps.AuditServiceRef.Record("", AuditRedirectDelete, rawid, nil, nil, err) }()
+
+	if oid, err = primitive.ObjectIDFromHex(rawid); err != nil {
+		return err
+	}
+
+	if count, err = ps.Redirects.Delete(ps.Ctx, oid); err != nil {
+		util.Log.Error(fmt.Sprintf("[PostService.DeleteSlugRedirect] %s", err.Error()))
+		return err
+	}
+
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	util.Log.Info(fmt.Sprintf("[PostService.DeleteSlugRedirect] Deleted slug redirect (id='%s')", oid.Hex()))
+	return nil
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rajatxs/go-fconsole/indexer"
+	"github.com/rajatxs/go-fconsole/markdown"
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/repository"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"github.com/rajatxs/go-fconsole/validation"
+	"github.com/wailsapp/wails/v2/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var testAuthorId = primitive.NewObjectID()
+
+// newTestPostService returns post service backed by memory repositories,
+// knowing topics 'go' and archived 'old' and a single author
+func newTestPostService(t *testing.T) *PostService {
+	t.Helper()
+
+	if util.Log == nil {
+		util.Log = logger.NewDefaultLogger()
+	}
+
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		topics = repository.NewMemoryTopicRepository()
+		people = repository.NewMemoryAuthorRepository()
+	)
+
+	if err := topics.Insert(ctx,
+		&models.TopicDocument{Id: "go", Name: "Go", CreatedAt: now, UpdatedAt: now},
+		&models.TopicDocument{Id: "old", Name: "Old", Archived: true, CreatedAt: now, UpdatedAt: now},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := people.Insert(ctx, &models.AuthorDocument{Id: testAuthorId, Name: "Admin", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := indexer.NewLocalIndexer(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := media.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps := NewPostService(
+		repository.NewMemoryPostRepository(),
+		repository.NewMemoryPostRevisionRepository(),
+		repository.NewMemorySlugRedirectRepository(),
+		idx,
+		store,
+		repository.NewMemoryIndexOutboxRepository(),
+		repository.NewMemoryTransactor(),
+	)
+	ps.Ctx = ctx
+	ps.TopicServiceRef = NewTopicService(topics, store)
+	ps.TopicServiceRef.Ctx = ctx
+	ps.TopicServiceRef.PostServiceRef = ps
+	ps.AuthorServiceRef = NewAuthorService(people, store)
+	ps.AuthorServiceRef.Ctx = ctx
+
+	if err := ps.TopicServiceRef.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ps.AuthorServiceRef.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	return ps
+}
+
+// createPayload returns valid payload of private markdown post
+func createPayload(title string, slug string) *types.CreatePostPayload {
+	return &types.CreatePostPayload{
+		Title:    title,
+		Slug:     slug,
+		Topic:    "go",
+		Body:     markdown.Body("Hello"),
+		Format:   models.PostFormatMarkdown,
+		AuthorId: testAuthorId.Hex(),
+		License:  models.PostLicenses[0],
+	}
+}
+
+// insertTestPost stores post directly, bypassing validation
+func insertTestPost(t *testing.T, ps *PostService, slug string, topic string) *models.PostDocument {
+	t.Helper()
+
+	post := &models.PostDocument{
+		Id:       primitive.NewObjectID(),
+		Title:    "Stored",
+		Slug:     slug,
+		Topic:    topic,
+		Body:     markdown.Body("Hello"),
+		Format:   models.PostFormatMarkdown,
+		AuthorId: testAuthorId,
+		License:  models.PostLicenses[0],
+	}
+
+	if _, err := ps.Repo.Insert(ps.Ctx, post); err != nil {
+		t.Fatal(err)
+	}
+	return post
+}
+
+// isValidationError reports whether err holds field errors of payload
+func isValidationError(err error) bool {
+	var verr *validation.Error
+	return errors.As(err, &verr)
+}
+
+func TestCreatePost(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, ps *PostService)
+		payload  *types.CreatePostPayload
+		wantSlug string
+		wantErr  bool
+	}{
+		{
+			name:     "slug from title",
+			payload:  createPayload("Hello World", ""),
+			wantSlug: "hello-world",
+		},
+		{
+			name: "generated slug gets suffix",
+			setup: func(t *testing.T, ps *PostService) {
+				insertTestPost(t, ps, "hello-world", "go")
+				insertTestPost(t, ps, "hello-world-2", "go")
+			},
+			payload:  createPayload("Hello World", ""),
+			wantSlug: "hello-world-3",
+		},
+		{
+			name:     "given slug",
+			payload:  createPayload("Hello World", "custom"),
+			wantSlug: "custom",
+		},
+		{
+			name: "given slug taken by post",
+			setup: func(t *testing.T, ps *PostService) {
+				insertTestPost(t, ps, "custom", "go")
+			},
+			payload: createPayload("Hello World", "custom"),
+			wantErr: true,
+		},
+		{
+			name: "given slug taken by redirect",
+			setup: func(t *testing.T, ps *PostService) {
+				post := insertTestPost(t, ps, "renamed", "go")
+				if err := ps.recordSlugChange(ps.Ctx, post.Id, "custom", "renamed"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			payload: createPayload("Hello World", "custom"),
+			wantErr: true,
+		},
+		{
+			name:    "malformed slug",
+			payload: createPayload("Hello World", "Not A Slug"),
+			wantErr: true,
+		},
+		{
+			name: "archived topic",
+			payload: func() *types.CreatePostPayload {
+				p := createPayload("Hello World", "")
+				p.Topic = "old"
+				return p
+			}(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := newTestPostService(t)
+			if tt.setup != nil {
+				tt.setup(t, ps)
+			}
+
+			res, err := ps.CreatePost(tt.payload)
+			if tt.wantErr {
+				if !isValidationError(err) {
+					t.Fatalf("CreatePost() error = %v, want validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreatePost() error = %v", err)
+			}
+
+			post, err := ps.Repo.FindById(ps.Ctx, res.InsertedID.(primitive.ObjectID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if post.Slug != tt.wantSlug {
+				t.Errorf("slug = %q, want %q", post.Slug, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestUpdatePostById(t *testing.T) {
+	tests := []struct {
+		name      string
+		slug      string
+		topic     string
+		update    func(p *types.UpdatePostPayload)
+		wantErr   bool
+		wantMoved bool
+	}{
+		{
+			name:   "legacy slug kept",
+			slug:   "Legacy_Slug",
+			topic:  "go",
+			update: func(p *types.UpdatePostPayload) { p.Title = "Edited" },
+		},
+		{
+			name:   "archived topic kept",
+			slug:   "post",
+			topic:  "old",
+			update: func(p *types.UpdatePostPayload) { p.Title = "Edited" },
+		},
+		{
+			name:    "moved to archived topic",
+			slug:    "post",
+			topic:   "go",
+			update:  func(p *types.UpdatePostPayload) { p.Topic = "old" },
+			wantErr: true,
+		},
+		{
+			name:    "slug taken",
+			slug:    "post",
+			topic:   "go",
+			update:  func(p *types.UpdatePostPayload) { p.Slug = "other" },
+			wantErr: true,
+		},
+		{
+			name:    "malformed new slug",
+			slug:    "post",
+			topic:   "go",
+			update:  func(p *types.UpdatePostPayload) { p.Slug = "Bad Slug" },
+			wantErr: true,
+		},
+		{
+			name:      "slug change",
+			slug:      "post",
+			topic:     "go",
+			update:    func(p *types.UpdatePostPayload) { p.Slug = "renamed" },
+			wantMoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := newTestPostService(t)
+			post := insertTestPost(t, ps, tt.slug, tt.topic)
+			insertTestPost(t, ps, "other", "go")
+
+			payload := types.UpdatePostPayload{
+				Title:   post.Title,
+				Slug:    post.Slug,
+				Topic:   post.Topic,
+				Body:    post.Body,
+				License: post.License,
+			}
+			tt.update(&payload)
+
+			_, err := ps.UpdatePostById(post.Id.Hex(), payload)
+			if tt.wantErr {
+				if !isValidationError(err) {
+					t.Fatalf("UpdatePostById() error = %v, want validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdatePostById() error = %v", err)
+			}
+
+			updated, err := ps.Repo.FindById(ps.Ctx, post.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.Title != payload.Title || updated.Slug != payload.Slug || updated.Topic != payload.Topic {
+				t.Errorf("updated post = %+v, want payload %+v", updated, payload)
+			}
+
+			revs, err := ps.Revisions.FindByPost(ps.Ctx, post.Id)
+			if err != nil || len(revs) != 1 || revs[0].Reason != RevisionReasonUpdate {
+				t.Errorf("revisions = %+v, %v, want single update revision", revs, err)
+			}
+
+			redirect, err := ps.Redirects.FindByFrom(ps.Ctx, tt.slug)
+			if tt.wantMoved {
+				if err != nil || redirect.To != payload.Slug || redirect.PostId != post.Id {
+					t.Errorf("redirect = %+v, %v, want %q to %q", redirect, err, tt.slug, payload.Slug)
+				}
+			} else if err == nil {
+				t.Errorf("unexpected redirect %+v", redirect)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/postbody"
+	"github.com/rajatxs/go-fconsole/slug"
+	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// post payload limits
+const (
+	maxPostTitleLength    = 150
+	maxPostDescLength     = 300
+	maxPostTags           = 10
+	maxPostTagLength      = 40
+	maxPostRelated        = 10
+	maxCoverRefNameLength = 100
+)
+
+// postFields holds payload fields shared by create and update
+type postFields struct {
+	title             string
+	slug              string
+	desc              string
+	tags              []string
+	topic             string
+	public            bool
+	coverImagePath    string
+	coverImageRefName string
+	coverImageRefUrl  string
+	license           string
+	relatedPosts      []string
+}
+
+// validatePostFields records errors of shared payload fields,
+// current is the updated post and nil for new posts
+// Unchanged slug and topic of existing post are kept as stored, so legacy
+// slugs and archived topics do not block other edits
+func (ps *PostService) validatePostFields(verr *validation.Error, current *models.PostDocument, f *postFields) error {
+	var (
+		id          primitive.ObjectID
+		slugChanged = true
+	)
+
+	if current != nil {
+		id = current.Id
+		slugChanged = f.slug != current.Slug
+	}
+
+	if verr.Required("title", f.title) {
+		verr.MaxLength("title", f.title, maxPostTitleLength)
+	}
+
+	// slug of new post is generated from title when missing, only generated slugs get a suffix when taken
+	if f.slug != "" || current != nil {
+		if verr.Required("slug", f.slug) && slugChanged && verr.MaxLength("slug", f.slug, slug.MaxLength) && verr.Slug("slug", f.slug) {
+			taken, err := ps.slugTaken(f.slug, id)
+			if err != nil {
+				return err
+			}
+
+			if taken {
+				verr.Add("slug", "is already used by another post or redirect")
+			}
+		}
+	}
+
+	verr.MaxLength("desc", f.desc, maxPostDescLength)
+	validateTags(verr, f.tags)
+
+	if verr.Required("topic", f.topic) && (current == nil || f.topic != current.Topic) {
+		if _, ok := (*ps.TopicServiceRef.GetAllTopics())[f.topic]; !ok {
+			verr.Add("topic", "references unknown topic '%s'", f.topic)
+		}
+	}
+
+	if f.public && strings.TrimSpace(f.coverImagePath) == "" {
+		verr.Add("coverImagePath", "is required for public posts")
+	}
+
+	verr.MaxLength("coverImageRefName", f.coverImageRefName, maxCoverRefNameLength)
+
+	if f.coverImageRefUrl != "" {
+		verr.Url("coverImageRefUrl", f.coverImageRefUrl)
+	}
+
+	if verr.Required("license", f.license) && !isKnownLicense(f.license) {
+		verr.Add("license", "must be one of %s", strings.Join(models.PostLicenses, ", "))
+	}
+
+	return ps.validateRelatedPosts(verr, id, f.relatedPosts)
+}
+
+// validateTags records errors of empty, long and duplicate tags
+func validateTags(verr *validation.Error, tags []string) {
+	seen := make(map[string]bool)
+
+	if len(tags) > maxPostTags {
+		verr.Add("tags", "must have at most %d tags, got %d", maxPostTags, len(tags))
+	}
+
+	for i, tag := range tags {
+		field := fmt.Sprintf("tags[%d]", i)
+
+		if verr.Required(field, tag) && verr.MaxLength(field, tag, maxPostTagLength) {
+			key := strings.ToLower(strings.TrimSpace(tag))
+
+			if seen[key] {
+				verr.Add(field, "duplicates tag '%s'", tag)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+// isKnownLicense reports whether license is offered by the post editor
+func isKnownLicense(license string) bool {
+	for _, known := range models.PostLicenses {
+		if license == known {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRelatedPosts records errors of related post ids which are malformed,
+// repeated, unknown, deleted or reference the post itself
+func (ps *PostService) validateRelatedPosts(verr *validation.Error, id primitive.ObjectID, ids []string) error {
+	seen := make(map[primitive.ObjectID]bool)
+
+	if len(ids) > maxPostRelated {
+		verr.Add("relatedPosts", "must have at most %d posts, got %d", maxPostRelated, len(ids))
+	}
+
+	for i, rawid := range ids {
+		field := fmt.Sprintf("relatedPosts[%d]", i)
+
+		oid, err := primitive.ObjectIDFromHex(rawid)
+		switch {
+		case err != nil:
+			verr.Add(field, "is not a valid post id")
+			continue
+		case oid == id:
+			verr.Add(field, "must not reference the post itself")
+			continue
+		case seen[oid]:
+			verr.Add(field, "duplicates post '%s'", rawid)
+			continue
+		}
+		seen[oid] = true
+
+		post, err := ps.Repo.FindById(ps.Ctx, oid)
+		switch {
+		case errors.Is(err, mongo.ErrNoDocuments):
+			verr.Add(field, "references unknown post '%s'", rawid)
+		case err != nil:
+			return err
+		case post.Deleted:
+			verr.Add(field, "references deleted post '%s'", rawid)
+		}
+	}
+
+	return nil
+}
+
+// validateCreatePost checks payload of new post
+func (ps *PostService) validateCreatePost(payload *types.CreatePostPayload) error {
+	var (
+		verr   = &validation.Error{}
+		format string
+		err    error
+	)
+
+	if verr.Required("authorId", payload.AuthorId) && ps.AuthorServiceRef.GetAuthorNameById(payload.AuthorId) == "" {
+		verr.Add("authorId", "references unknown author '%s'", payload.AuthorId)
+	}
+
+	if format, err = postbody.Normalize(payload.Format); err != nil {
+		verr.Add("format", err.Error())
+	} else if err = postbody.Validate(format, payload.Body); err != nil {
+		verr.Add("body", err.Error())
+	}
+
+	if err = ps.validatePostFields(verr, nil, &postFields{
+		title:             payload.Title,
+		slug:              payload.Slug,
+		desc:              payload.Desc,
+		tags:              payload.Tags,
+		topic:             payload.Topic,
+		public:            payload.Public,
+		coverImagePath:    payload.CoverImagePath,
+		coverImageRefName: payload.CoverImageRefName,
+		coverImageRefUrl:  payload.CoverImageRefUrl,
+		license:           payload.License,
+		relatedPosts:      payload.RelatedPosts,
+	}); err != nil {
+		return err
+	}
+
+	return verr.Err()
+}
+
+// validateUpdatePost checks payload of existing post, body must match stored format
+func (ps *PostService) validateUpdatePost(current *models.PostDocument, payload *types.UpdatePostPayload) error {
+	verr := &validation.Error{}
+
+	if err := postbody.Validate(current.Format, payload.Body); err != nil {
+		verr.Add("body", err.Error())
+	}
+
+	if err := ps.validatePostFields(verr, current, &postFields{
+		title:             payload.Title,
+		slug:              payload.Slug,
+		desc:              payload.Desc,
+		tags:              payload.Tags,
+		topic:             payload.Topic,
+		public:            payload.Public,
+		coverImagePath:    payload.CoverImagePath,
+		coverImageRefName: payload.CoverImageRefName,
+		coverImageRefUrl:  payload.CoverImageRefUrl,
+		license:           payload.License,
+		relatedPosts:      payload.RelatedPosts,
+	}); err != nil {
+		return err
+	}
+
+	return verr.Err()
+}
+
+// validateRestorePost checks revision snapshot restored over current post,
+// author is not part of the restore and must still exist
+func (ps *PostService) validateRestorePost(current *models.PostDocument, snapshot *models.PostDocument) error {
+	var (
+		verr    = &validation.Error{}
+		cover   = snapshot.CoverImage
+		related = make([]string, len(snapshot.Related))
+	)
+
+	if ps.AuthorServiceRef.GetAuthorNameById(current.AuthorId.Hex()) == "" {
+		verr.Add("authorId", "references unknown author '%s'", current.AuthorId.Hex())
+	}
+
+	if err := postbody.Validate(snapshot.Format, snapshot.Body); err != nil {
+		verr.Add("body", err.Error())
+	}
+
+	if cover == nil {
+		cover = &models.PostCoverImage{}
+	}
+
+	for i, oid := range snapshot.Related {
+		related[i] = oid.Hex()
+	}
+
+	if err := ps.validatePostFields(verr, current, &postFields{
+		title:             snapshot.Title,
+		slug:              snapshot.Slug,
+		desc:              snapshot.Desc,
+		tags:              snapshot.Tags,
+		topic:             snapshot.Topic,
+		public:            snapshot.Public,
+		coverImagePath:    cover.Path,
+		coverImageRefName: cover.RefName,
+		coverImageRefUrl:  cover.RefUrl,
+		license:           snapshot.License,
+		relatedPosts:      related,
+	}); err != nil {
+		return err
+	}
+
+	return verr.Err()
+}
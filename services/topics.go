@@ -2,112 +2,154 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
 
+	"github.com/rajatxs/go-fconsole/media"
+	"github.com/rajatxs/go-fconsole/models"
+	"github.com/rajatxs/go-fconsole/repository"
 	"github.com/rajatxs/go-fconsole/types"
+	"github.com/rajatxs/go-fconsole/util"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type TopicService struct {
-	Ctx context.Context
+	Ctx   context.Context
+	Repo  repository.TopicRepository
+	Media media.MediaStore
+
+	PostServiceRef  *PostService
+	AuditServiceRef *AuditService
+
+	mu    sync.RWMutex
+	cache []models.TopicDocument
 }
 
-var topics types.Topics = map[string]types.Topic{
-	"lifestyle": {
+// topicIdPattern matches valid topic id
+var topicIdPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ErrTopicNotFound is returned when topic does not exist
+var ErrTopicNotFound = errors.New("topic not found")
+
+// seedTopics are written into empty topics collection on first run
+var seedTopics = []models.TopicDocument{
+	{
+		Id:        "lifestyle",
 		Name:      "Lifestyle",
 		ThumbId:   "c25decb56feccf32b56f3bbce1d0649e",
 		ThumbPath: "fivemin-prod/topic-thumb/d6kqr2vxy7siuchz3ugj",
 		Public:    false,
 	},
-	"food-and-cooking": {
+	{
+		Id:        "food-and-cooking",
 		Name:      "Food and Cooking",
 		ThumbId:   "a38d1f899a322069b57f93a3a2aa1a0b",
 		ThumbPath: "fivemin-prod/topic-thumb/ufafc9pcz80k9obim4ly",
 		Public:    false,
 	},
-	"technology": {
+	{
+		Id:        "technology",
 		Name:      "Technology",
 		ThumbId:   "d7032d16343fe1a6330db626bf5feeaf",
 		ThumbPath: "fivemin-prod/topic-thumb/n6rztxn2ujb5b1ccwhbn",
 		Public:    true,
 	},
-	"finance": {
+	{
+		Id:        "finance",
 		Name:      "Finance",
 		ThumbId:   "b386564e5f7bb336c6cda43377f8f21d",
 		ThumbPath: "fivemin-prod/topic-thumb/ewjibbyowrykv0zjuqq7",
 		Public:    true,
 	},
-	"parenting": {
+	{
+		Id:        "parenting",
 		Name:      "Parenting",
 		ThumbId:   "122e62251f9fb3d3e90091a47af983a8",
 		ThumbPath: "fivemin-prod/topic-thumb/xkn9wwkwgpdybn0ynqds",
 		Public:    false,
 	},
-	"sports": {
+	{
+		Id:        "sports",
 		Name:      "Sports",
 		ThumbId:   "4710819168f02f59326504082b132ba7",
 		ThumbPath: "fivemin-prod/topic-thumb/cheancimace34cd4jmib",
 		Public:    false,
 	},
-	"beauty-and-skincare": {
+	{
+		Id:        "beauty-and-skincare",
 		Name:      "Beauty and Skincare",
 		ThumbId:   "391d0fbf180c1864e125ae2de2056f49",
 		ThumbPath: "fivemin-prod/topic-thumb/vpfyyuihzwn1xsrcmu9x",
 		Public:    false,
 	},
-	"home-improvement": {
+	{
+		Id:        "home-improvement",
 		Name:      "Home Improvement",
 		ThumbId:   "545a8568b81b52b21d323e54139f060a",
 		ThumbPath: "fivemin-prod/topic-thumb/cxcp7mxut7g9qqdemxpp",
 		Public:    false,
 	},
-	"education": {
+	{
+		Id:        "education",
 		Name:      "Education",
 		ThumbId:   "ea4a1994e29888ee339a83ba875b2498",
 		ThumbPath: "fivemin-prod/topic-thumb/ezuokyiqie8apwdpunli",
 		Public:    false,
 	},
-	"entertainment": {
+	{
+		Id:        "entertainment",
 		Name:      "Entertainment",
 		ThumbId:   "5455004f1dc1f498d5a4c8ee9f00ecf9",
 		ThumbPath: "fivemin-prod/topic-thumb/vubqex8sbphmqhgxyeb3",
 		Public:    false,
 	},
-	"business": {
+	{
+		Id:        "business",
 		Name:      "Business",
 		ThumbId:   "62180034fffab4bd0b9e2e11c0418876",
 		ThumbPath: "fivemin-prod/topic-thumb/nxzh4ihpddkqm0nputm4",
 		Public:    true,
 	},
-	"travel": {
+	{
+		Id:        "travel",
 		Name:      "Travel",
 		ThumbId:   "9406a84d081a8e72bfba2451c6900872",
 		ThumbPath: "fivemin-prod/topic-thumb/rjpwglx0iavcdhukkhuy",
 		Public:    false,
 	},
-	"health": {
+	{
+		Id:        "health",
 		Name:      "Health",
 		ThumbId:   "7124b21e721cc4477af37edd67ac2549",
 		ThumbPath: "fivemin-prod/topic-thumb/cdagmwqtctswknvqzkxu",
 		Public:    false,
 	},
-	"social": {
+	{
+		Id:        "social",
 		Name:      "Social",
 		ThumbId:   "9fb66dab59f3e0ff79ca57f3c7e1fbd1",
 		ThumbPath: "fivemin-prod/topic-thumb/ti3kpjhcd155t4h5toom",
 		Public:    false,
 	},
-	"relationships": {
+	{
+		Id:        "relationships",
 		Name:      "Relationships",
 		ThumbId:   "36dc7b9c0f4aaf4f56dd0165b7859d16",
 		ThumbPath: "fivemin-prod/topic-thumb/zcdz5monp2zqeipuqzdu",
 		Public:    false,
 	},
-	"science": {
+	{
+		Id:        "science",
 		Name:      "Science",
 		ThumbId:   "f41cd486d292306f036ab3b54cb9749d",
 		ThumbPath: "fivemin-prod/topic-thumb/xtcbet9ywsfif7uiiayu",
 		Public:    true,
 	},
-	"programming": {
+	{
+		Id:        "programming",
 		Name:      "Programming",
 		ThumbId:   "e7b77657550f169f0a6d6f679283b222",
 		ThumbPath: "fivemin-prod/topic-thumb/zmlt6ft5tyivzcfeejci",
@@ -115,52 +157,356 @@ var topics types.Topics = map[string]types.Topic{
 	},
 }
 
-// NewTopicService creates new instance of TopicService
-func NewTopicService() *TopicService {
-	return &TopicService{}
+// NewTopicService creates new instance of TopicService with given topic storage and media storage
+func NewTopicService(repo repository.TopicRepository, store media.MediaStore) *TopicService {
+	return &TopicService{
+		Repo:  repo,
+		Media: store,
+	}
 }
 
-// GetAllTopics returns object of all available topics
-func (ts *TopicService) GetAllTopics() *types.Topics {
-	return &topics
+// load seeds topics collection when empty and loads topic cache
+func (ts *TopicService) load() (err error) {
+	var docs []models.TopicDocument
+
+	if docs, err = ts.Repo.FindAll(ts.Ctx); err != nil {
+		return err
+	}
+
+	if len(docs) == 0 {
+		seeds := make([]*models.TopicDocument, len(seedTopics))
+		now := time.Now()
+
+		for i := range seedTopics {
+			seed := seedTopics[i]
+			seed.Order = i
+			seed.CreatedAt = now
+			seed.UpdatedAt = now
+			seeds[i] = &seed
+		}
+
+		if err = ts.Repo.Insert(ts.Ctx, seeds...); err != nil {
+			util.Log.Error(fmt.Sprintf("[TopicService.load] %s", err.Error()))
+			return err
+		}
+		util.Log.Info(fmt.Sprintf("[TopicService.load] Seeded %d topics", len(seeds)))
+	}
+
+	return ts.refresh()
 }
 
-// GetPublicTopics returns object of publicly available topics
-func (ts *TopicService) GetPublicTopics() (pubtops types.Topics) {
-	pubtops = make(map[string]types.Topic)
+// refresh reloads topic cache from storage
+func (ts *TopicService) refresh() error {
+	docs, err := ts.Repo.FindAll(ts.Ctx)
+	if err != nil {
+		util.Log.Error(fmt.Sprintf("[TopicService.refresh] %s", err.Error()))
+		return err
+	}
 
-	for topicId, topic := range topics {
-		if topic.Public {
-			pubtops[topicId] = topic
+	ts.mu.Lock()
+	ts.cache = docs
+	ts.mu.Unlock()
+	return nil
+}
+
+// filter returns active topics accepted by given predicate
+func (ts *TopicService) filter(accept func(topic *models.TopicDocument) bool) types.Topics {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	topics := make(types.Topics)
+	for i := range ts.cache {
+		if doc := &ts.cache[i]; !doc.Archived && accept(doc) {
+			topics[doc.Id] = types.Topic{
+				Name:      doc.Name,
+				ThumbId:   doc.ThumbId,
+				ThumbPath: doc.ThumbPath,
+				Public:    doc.Public,
+				Order:     doc.Order,
+			}
 		}
 	}
 
-	return pubtops
+	return topics
 }
 
-// GetPrivateTopics returns object of private topics
-func (ts *TopicService) GetPrivateTopics() (pubtops types.Topics) {
-	pubtops = make(map[string]types.Topic)
+// find returns cached topic document by given id, including archived topics
+func (ts *TopicService) find(id string) (models.TopicDocument, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
 
-	for topicId, topic := range topics {
-		if !topic.Public {
-			pubtops[topicId] = topic
+	for _, doc := range ts.cache {
+		if doc.Id == id {
+			return doc, true
 		}
 	}
+	return models.TopicDocument{}, false
+}
 
-	return pubtops
+// topicSummary returns fields of stored topic recorded in audit log,
+// nil for unknown topic
+func topicSummary(doc *models.TopicDocument) bson.M {
+	if doc.Id == "" {
+		return nil
+	}
+	return bson.M{
+		"name":      doc.Name,
+		"public":    doc.Public,
+		"archived":  doc.Archived,
+		"order":     doc.Order,
+		"thumbPath": doc.ThumbPath,
+	}
+}
+
+// summarizeTopic returns audit summary of cached topic by id
+func (ts *TopicService) summarizeTopic(id string) bson.M {
+	doc, _ := ts.find(id)
+	return topicSummary(&doc)
+}
+
+// topicOrder returns ids of cached topics in their order
+func (ts *TopicService) topicOrder() []string {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	ids := make([]string, len(ts.cache))
+	for i, doc := range ts.cache {
+		ids[i] = doc.Id
+	}
+	return ids
+}
+
+// auditTopic records outcome of topic operation, cached topic is
+// summarized as after state when operation succeeded
+func (ts *TopicService) auditTopic(operation string, id string, before bson.M, err error) {
+	var after bson.M
+
+	if err == nil {
+		after = ts.summarizeTopic(id)
+	}
+	ts.AuditServiceRef.Record("", operation, id, before, after, err)
+}
+
+// GetAllTopics returns object of all available topics
+func (ts *TopicService) GetAllTopics() *types.Topics {
+	topics := ts.filter(func(topic *models.TopicDocument) bool {
+		return true
+	})
+	return &topics
+}
+
+// GetPublicTopics returns object of publicly available topics
+func (ts *TopicService) GetPublicTopics() types.Topics {
+	return ts.filter(func(topic *models.TopicDocument) bool {
+		return topic.Public
+	})
+}
+
+// GetPrivateTopics returns object of private topics
+func (ts *TopicService) GetPrivateTopics() types.Topics {
+	return ts.filter(func(topic *models.TopicDocument) bool {
+		return !topic.Public
+	})
+}
+
+// GetArchivedTopics returns list of archived topics
+func (ts *TopicService) GetArchivedTopics() (archived []models.TopicDocument) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	for _, doc := range ts.cache {
+		if doc.Archived {
+			archived = append(archived, doc)
+		}
+	}
+	return archived
 }
 
 // GetTopicById returns single topic object by given id
 func (ts *TopicService) GetTopicById(id string) types.Topic {
-	return topics[id]
+	doc, _ := ts.find(id)
+
+	return types.Topic{
+		Name:      doc.Name,
+		ThumbId:   doc.ThumbId,
+		ThumbPath: doc.ThumbPath,
+		Public:    doc.Public,
+		Order:     doc.Order,
+	}
 }
 
 // GetTopicNameById returns topic name of by given id
+// Archived topics still resolve so existing posts keep their topic name
 func (ts *TopicService) GetTopicNameById(id string) string {
-	if topic, ok := topics[id]; ok {
-		return topic.Name
+	if doc, ok := ts.find(id); ok {
+		return doc.Name
 	} else {
 		return ""
 	}
 }
+
+// CreateTopic inserts new topic at the end of topic order
+func (ts *TopicService) CreateTopic(payload types.CreateTopicPayload) (err error) {
+	defer func() { ts.auditTopic(AuditTopicCreate, payload.Id, nil, err) }()
+
+	if !topicIdPattern.MatchString(payload.Id) {
+		return fmt.Errorf("invalid topic id '%s'", payload.Id)
+	}
+
+	if payload.Name == "" {
+		return errors.New("topic name is required")
+	}
+
+	order := 0
+	ts.mu.RLock()
+	for _, doc := range ts.cache {
+		if doc.Order >= order {
+			order = doc.Order + 1
+		}
+	}
+	ts.mu.RUnlock()
+
+	now := time.Now()
+	doc := &models.TopicDocument{
+		Id:        payload.Id,
+		Name:      payload.Name,
+		Public:    payload.Public,
+		Order:     order,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = ts.Repo.Insert(ts.Ctx, doc); err != nil {
+		util.Log.Error(fmt.Sprintf("[TopicService.CreateTopic] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info(fmt.Sprintf("[TopicService.CreateTopic] Inserted topic (id='%s')", payload.Id))
+	}
+
+	return ts.refresh()
+}
+
+// update sets given fields of topic and refreshes cache
+func (ts *TopicService) update(fn string, id string, fields bson.M) error {
+	fields["updatedAt"] = time.Now()
+
+	if found, err := ts.Repo.Update(ts.Ctx, id, fields); err != nil {
+		util.Log.Error(fmt.Sprintf("[TopicService.%s] %s", fn, err.Error()))
+		return err
+	} else if !found {
+		return ErrTopicNotFound
+	} else {
+		util.Log.Info(fmt.Sprintf("[TopicService.%s] Updated topic (id='%s')", fn, id))
+	}
+
+	return ts.refresh()
+}
+
+// UpdateTopic updates name and scope of existing topic,
+// renamed topic gets search records of its public posts rebuilt
+func (ts *TopicService) UpdateTopic(id string, payload types.UpdateTopicPayload) (err error) {
+	current, _ := ts.find(id)
+
+	defer func() { ts.auditTopic(AuditTopicUpdate, id, topicSummary(&current), err) }()
+
+	if payload.Name == "" {
+		return errors.New("topic name is required")
+	}
+
+	if err = ts.update("UpdateTopic", id, bson.M{"name": payload.Name, "public": payload.Public}); err != nil {
+		return err
+	}
+
+	if payload.Name != current.Name {
+		return ts.PostServiceRef.reindexTopicPosts(id)
+	}
+	return nil
+}
+
+// ArchiveTopic hides topic from topic lists or restores it
+func (ts *TopicService) ArchiveTopic(id string, archived bool) (err error) {
+	before := ts.summarizeTopic(id)
+
+	defer func() { ts.auditTopic(AuditTopicArchive, id, before, err) }()
+
+	return ts.update("ArchiveTopic", id, bson.M{"archived": archived})
+}
+
+// ReorderTopics sets topic order by position in given ids
+// Topics missing from ids keep their relative order after listed ones
+func (ts *TopicService) ReorderTopics(ids []string) (err error) {
+	var (
+		seen    = make(map[string]bool, len(ids))
+		ordered = make([]string, 0, len(ids))
+		before  = bson.M{"order": ts.topicOrder()}
+	)
+
+	defer func() {
+		var after bson.M
+		if err == nil {
+			after = bson.M{"order": ts.topicOrder()}
+		}
+		ts.AuditServiceRef.Record("", AuditTopicReorder, "", before, after, err)
+	}()
+
+	for _, id := range ids {
+		if _, ok := ts.find(id); !ok {
+			return fmt.Errorf("%w (id='%s')", ErrTopicNotFound, id)
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ordered = append(ordered, id)
+		}
+	}
+
+	ts.mu.RLock()
+	for _, doc := range ts.cache {
+		if !seen[doc.Id] {
+			ordered = append(ordered, doc.Id)
+		}
+	}
+	ts.mu.RUnlock()
+
+	if err = ts.Repo.SetOrder(ts.Ctx, ordered); err != nil {
+		util.Log.Error(fmt.Sprintf("[TopicService.ReorderTopics] %s", err.Error()))
+		return err
+	} else {
+		util.Log.Info("[TopicService.ReorderTopics] Updated topic order")
+	}
+
+	return ts.refresh()
+}
+
+// UploadTopicThumb uploads thumbnail image and assigns it to topic
+func (ts *TopicService) UploadTopicThumb(id string, imageData []byte) (res *types.UploadedImageFile, err error) {
+	before := ts.summarizeTopic(id)
+
+	defer func() { ts.auditTopic(AuditTopicThumb, id, before, err) }()
+
+	current, ok := ts.find(id)
+	if !ok {
+		return nil, ErrTopicNotFound
+	}
+
+	res, err = ts.Media.Upload(ts.Ctx, "fivemin-prod/topic-thumb", imageData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ts.update("UploadTopicThumb", id, bson.M{"thumbId": res.AssetId, "thumbPath": res.PublicId}); err != nil {
+		return nil, err
+	}
+
+	// replaced thumbnail is no longer referenced, failing to remove it only leaves an orphan
+	if old := current.ThumbPath; old != "" && old != res.PublicId {
+		derr := ts.Media.Delete(ts.Ctx, old)
+		ts.AuditServiceRef.Record("", AuditImageDelete, old, nil, nil, derr)
+
+		if derr != nil {
+			util.Log.Warning(fmt.Sprintf("[TopicService.UploadTopicThumb] Could not delete previous thumbnail '%s': %s", old, derr.Error()))
+		}
+	}
+
+	return res, nil
+}
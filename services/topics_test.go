@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/rajatxs/go-fconsole/repository"
+	"github.com/rajatxs/go-fconsole/types"
+)
+
+// testImage returns encoded 1x1 PNG image
+func testImage(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newTestTopicService returns topic service of newTestPostService recording audit log in memory
+func newTestTopicService(t *testing.T) *TopicService {
+	t.Helper()
+
+	ts := newTestPostService(t).TopicServiceRef
+	ts.AuditServiceRef = NewAuditService(repository.NewMemoryAuditLogRepository())
+	ts.AuditServiceRef.Ctx = context.Background()
+	return ts
+}
+
+func TestTopicAudit(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(t *testing.T, ts *TopicService) error
+		operation   string
+		target      string
+		wantOutcome string
+		wantBefore  bool
+	}{
+		{
+			name: "create",
+			run: func(t *testing.T, ts *TopicService) error {
+				return ts.CreateTopic(types.CreateTopicPayload{Id: "rust", Name: "Rust"})
+			},
+			operation:   AuditTopicCreate,
+			target:      "rust",
+			wantOutcome: AuditSuccess,
+		},
+		{
+			name: "create with invalid id",
+			run: func(t *testing.T, ts *TopicService) error {
+				return ts.CreateTopic(types.CreateTopicPayload{Id: "Not Valid", Name: "Rust"})
+			},
+			operation:   AuditTopicCreate,
+			target:      "Not Valid",
+			wantOutcome: AuditFailure,
+		},
+		{
+			name: "rename",
+			run: func(t *testing.T, ts *TopicService) error {
+				return ts.UpdateTopic("go", types.UpdateTopicPayload{Name: "Golang", Public: true})
+			},
+			operation:   AuditTopicUpdate,
+			target:      "go",
+			wantOutcome: AuditSuccess,
+			wantBefore:  true,
+		},
+		{
+			name: "archive",
+			run: func(t *testing.T, ts *TopicService) error {
+				return ts.ArchiveTopic("go", true)
+			},
+			operation:   AuditTopicArchive,
+			target:      "go",
+			wantOutcome: AuditSuccess,
+			wantBefore:  true,
+		},
+		{
+			name: "archive unknown",
+			run: func(t *testing.T, ts *TopicService) error {
+				return ts.ArchiveTopic("missing", true)
+			},
+			operation:   AuditTopicArchive,
+			target:      "missing",
+			wantOutcome: AuditFailure,
+		},
+		{
+			name: "reorder",
+			run: func(t *testing.T, ts *TopicService) error {
+				return ts.ReorderTopics([]string{"old", "go"})
+			},
+			operation:   AuditTopicReorder,
+			wantOutcome: AuditSuccess,
+			wantBefore:  true,
+		},
+		{
+			name: "thumbnail",
+			run: func(t *testing.T, ts *TopicService) error {
+				_, err := ts.UploadTopicThumb("go", testImage(t))
+				return err
+			},
+			operation:   AuditTopicThumb,
+			target:      "go",
+			wantOutcome: AuditSuccess,
+			wantBefore:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestTopicService(t)
+
+			err := tt.run(t, ts)
+			if (err != nil) != (tt.wantOutcome == AuditFailure) {
+				t.Fatalf("operation error = %v, want outcome %s", err, tt.wantOutcome)
+			}
+
+			entries, err := ts.AuditServiceRef.GetAuditLog(types.GetAuditLogOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(entries))
+			}
+
+			entry := entries[0]
+			if entry.Operation != tt.operation || entry.TargetId != tt.target || entry.Outcome != tt.wantOutcome {
+				t.Errorf("entry = %s %q %s, want %s %q %s",
+					entry.Operation, entry.TargetId, entry.Outcome, tt.operation, tt.target, tt.wantOutcome)
+			}
+
+			if (entry.Before != nil) != tt.wantBefore {
+				t.Errorf("before = %v, want present %v", entry.Before, tt.wantBefore)
+			}
+
+			if (entry.After != nil) != (tt.wantOutcome == AuditSuccess) {
+				t.Errorf("after = %v for outcome %s", entry.After, entry.Outcome)
+			}
+		})
+	}
+}
+
+func TestUploadTopicThumbReplaces(t *testing.T) {
+	ts := newTestTopicService(t)
+
+	first, err := ts.UploadTopicThumb("go", testImage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := ts.UploadTopicThumb("go", testImage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if topic, _ := ts.find("go"); topic.ThumbPath != second.PublicId {
+		t.Errorf("thumbPath = %q, want %q", topic.ThumbPath, second.PublicId)
+	}
+
+	if _, err := ts.Media.Download(ts.Ctx, first.PublicId); err == nil {
+		t.Errorf("previous thumbnail %q was not deleted", first.PublicId)
+	}
+
+	if _, err := ts.Media.Download(ts.Ctx, second.PublicId); err != nil {
+		t.Errorf("current thumbnail missing: %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+// Package slug generates URL slugs from post titles
+package slug
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxLength is maximum length of generated slug
+const MaxLength = 100
+
+var pattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// latinGroups lists accented Latin letters by their ASCII replacement
+var latinGroups = map[string]string{
+	"a":  "àáâãäåāăąǎǟǡǻȁȃȧ",
+	"ae": "æǣǽ",
+	"c":  "çćĉċč",
+	"d":  "ďđð",
+	"e":  "èéêëēĕėęěȅȇȩ",
+	"g":  "ĝğġģǥǧǵ",
+	"h":  "ĥħȟ",
+	"i":  "ìíîïĩīĭįıǐȉȋ",
+	"ij": "ĳ",
+	"j":  "ĵǰ",
+	"k":  "ķĸǩ",
+	"l":  "ĺļľŀł",
+	"n":  "ñńņňŉŋǹ",
+	"o":  "òóôõöøōŏőǒǫǭǿȍȏȫȭȯȱ",
+	"oe": "œ",
+	"r":  "ŕŗřȑȓ",
+	"s":  "śŝşšș",
+	"ss": "ß",
+	"t":  "ţťŧț",
+	"th": "þ",
+	"u":  "ùúûüũūŭůűųǔǖǘǚǜȕȗ",
+	"w":  "ŵ",
+	"y":  "ýÿŷȳ",
+	"z":  "źżžƶ",
+}
+
+// scripts maps letters of non-Latin alphabets to their Latin transliteration
+var scripts = map[rune]string{
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u", 'ђ': "dj", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz",
+
+	// Greek
+	'α': "a", 'ά': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'έ': "e", 'ζ': "z",
+	'η': "i", 'ή': "i", 'θ': "th", 'ι': "i", 'ί': "i", 'ϊ': "i", 'ΐ': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'ό': "o", 'π': "p", 'ρ': "r",
+	'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'ύ': "y", 'ϋ': "y", 'ΰ': "y", 'φ': "f",
+	'χ': "ch", 'ψ': "ps", 'ω': "o", 'ώ': "o",
+}
+
+// marks are removed without separating words
+var marks = map[rune]bool{'\'': true, '’': true, '‘': true, 'ʼ': true, '`': true}
+
+func init() {
+	for ascii, letters := range latinGroups {
+		for _, r := range letters {
+			scripts[r] = ascii
+		}
+	}
+}
+
+// Make returns slug of given text, letters of Latin, Cyrillic and Greek
+// alphabets are transliterated and other characters separate words
+// Result is empty when text holds no transliterable letters or digits
+func Make(text string) string {
+	var (
+		sb  strings.Builder
+		sep bool
+	)
+
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if sep && sb.Len() > 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(s)
+		sep = false
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			write(string(r))
+		case r == '&':
+			sep = true
+			write("and")
+			sep = true
+		case marks[r]:
+			// apostrophes join words as in "don't"
+		default:
+			if latin, ok := scripts[r]; ok {
+				write(latin)
+			} else {
+				sep = true
+			}
+		}
+	}
+
+	return truncate(sb.String(), MaxLength)
+}
+
+// truncate shortens slug to at most max characters, preferring a word boundary
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	s = s[:max]
+	if cut := strings.LastIndexByte(s, '-'); cut > max/2 {
+		s = s[:cut]
+	}
+	return strings.TrimRight(s, "-")
+}
+
+// WithSuffix returns base slug with numeric suffix, base is shortened
+// so the result does not exceed MaxLength
+func WithSuffix(base string, n int) string {
+	suffix := fmt.Sprintf("-%d", n)
+	return truncate(base, MaxLength-len(suffix)) + suffix
+}
+
+// Valid reports whether s is lowercase words of letters and digits joined by single hyphens
+func Valid(s string) bool {
+	return pattern.MatchString(s)
+}
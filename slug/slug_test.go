@@ -0,0 +1,92 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "Hello World", "hello-world"},
+		{"punctuation", "  Go: tips, tricks & more!  ", "go-tips-tricks-and-more"},
+		{"apostrophe", "Don't panic", "dont-panic"},
+		{"latin accents", "Crème brûlée à la française", "creme-brulee-a-la-francaise"},
+		{"cyrillic", "Привет мир", "privet-mir"},
+		{"greek", "Καλημέρα", "kalimera"},
+		{"digits", "Top 10 of 2024", "top-10-of-2024"},
+		{"no letters", "日本語 !!", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Make(tt.text); got != tt.want {
+				t.Errorf("Make(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeLength(t *testing.T) {
+	got := Make(strings.Repeat("word ", 40))
+
+	if len(got) > MaxLength {
+		t.Errorf("slug has %d characters, want at most %d", len(got), MaxLength)
+	}
+
+	if !Valid(got) {
+		t.Errorf("truncated slug %q is not valid", got)
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		n    int
+		want string
+	}{
+		{"short", "hello", 2, "hello-2"},
+		{"long base is shortened", strings.Repeat("a", MaxLength), 12, strings.Repeat("a", MaxLength-3) + "-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithSuffix(tt.base, tt.n)
+			if got != tt.want {
+				t.Errorf("WithSuffix(%q, %d) = %q, want %q", tt.base, tt.n, got, tt.want)
+			}
+
+			if len(got) > MaxLength {
+				t.Errorf("slug has %d characters, want at most %d", len(got), MaxLength)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{"hello", true},
+		{"hello-world-2", true},
+		{"", false},
+		{"Hello", false},
+		{"hello--world", false},
+		{"-hello", false},
+		{"hello-", false},
+		{"hello_world", false},
+		{"héllo", false},
+	}
+
+	for _, tt := range tests {
+		if got := Valid(tt.slug); got != tt.want {
+			t.Errorf("Valid(%q) = %t, want %t", tt.slug, got, tt.want)
+		}
+	}
+}
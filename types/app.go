@@ -4,6 +4,9 @@ type AppPublicConfigVariables struct {
 	ENV           string `json:"ENV"`
 	ADMIN_ID      string `json:"ADMIN_ID"`
 	CLOUDINARY_ID string `json:"CLOUDINARY_ID"`
+	MEDIA_STORE   string `json:"MEDIA_STORE"`
+	PROFILE       string `json:"PROFILE"`
+	VAULT_LOCKED  bool   `json:"VAULT_LOCKED"`
 }
 
 type AppVersions struct {
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"time"
+)
+
+type GetAuditLogOptions struct {
+	PostId    string     `json:"postId"`
+	Actor     string     `json:"actor"`
+	Operation string     `json:"operation"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	Limit     int64      `json:"limit"`
+	Skip      int64      `json:"skip"`
+}
@@ -0,0 +1,12 @@
+package types
+
+type AuthorLink struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+type AuthorPayload struct {
+	Name  string       `json:"name"`
+	Bio   string       `json:"bio"`
+	Links []AuthorLink `json:"links"`
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+type BackupImage struct {
+	PublicId string `json:"publicId"`
+	File     string `json:"file"`
+}
+
+type BackupManifest struct {
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"createdAt"`
+	Posts     int           `json:"posts"`
+	Topics    int           `json:"topics"`
+	Images    []BackupImage `json:"images"`
+	Missing   []string      `json:"missing,omitempty"`
+}
+
+type RestoreOptions struct {
+	Mode   string `json:"mode"`
+	DryRun bool   `json:"dryRun"`
+	Images bool   `json:"images"`
+}
+
+type RestoreCount struct {
+	Created     int `json:"created"`
+	Overwritten int `json:"overwritten"`
+	Skipped     int `json:"skipped"`
+}
+
+type RestoreReport struct {
+	DryRun bool         `json:"dryRun"`
+	Mode   string       `json:"mode"`
+	Posts  RestoreCount `json:"posts"`
+	Topics RestoreCount `json:"topics"`
+	Images RestoreCount `json:"images"`
+	Errors []string     `json:"errors"`
+}
+
+type BackupResult struct {
+	File     string          `json:"file"`
+	Manifest *BackupManifest `json:"manifest"`
+}
@@ -0,0 +1,31 @@
+package types
+
+type FieldChange struct {
+	Field   string      `json:"field"`
+	Old     interface{} `json:"old"`
+	New     interface{} `json:"new"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+}
+
+type TextChange struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type BlockChange struct {
+	Kind     string       `json:"kind"`
+	BlockId  string       `json:"blockId"`
+	Type     string       `json:"type"`
+	OldIndex int          `json:"oldIndex"`
+	NewIndex int          `json:"newIndex"`
+	OldText  string       `json:"oldText,omitempty"`
+	NewText  string       `json:"newText,omitempty"`
+	Text     []TextChange `json:"text,omitempty"`
+}
+
+type PostDiff struct {
+	Fields  []FieldChange `json:"fields"`
+	Blocks  []BlockChange `json:"blocks"`
+	Changed bool          `json:"changed"`
+}
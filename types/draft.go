@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+type Draft struct {
+	Id        string            `json:"id"`
+	PostId    string            `json:"postId"`
+	Payload   CreatePostPayload `json:"payload"`
+	Queued    bool              `json:"queued"`
+	PushError string            `json:"pushError"`
+	SavedAt   time.Time         `json:"savedAt"`
+}
@@ -0,0 +1,33 @@
+package types
+
+type ImportOptions struct {
+	Source     string            `json:"source"`
+	Topic      string            `json:"topic"`
+	Topics     map[string]string `json:"topics"`
+	License    string            `json:"license"`
+	Public     bool              `json:"public"`
+	Pages      bool              `json:"pages"`
+	SkipImages bool              `json:"skipImages"`
+	SiteUrl    string            `json:"siteUrl"`
+	DryRun     bool              `json:"dryRun"`
+}
+
+type ImportItem struct {
+	SourceId string   `json:"sourceId"`
+	Title    string   `json:"title"`
+	Slug     string   `json:"slug"`
+	Topic    string   `json:"topic"`
+	Status   string   `json:"status"`
+	PostId   string   `json:"postId,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type ImportReport struct {
+	Source  string       `json:"source"`
+	DryRun  bool         `json:"dryRun"`
+	Created int          `json:"created"`
+	Skipped int          `json:"skipped"`
+	Failed  int          `json:"failed"`
+	Items   []ImportItem `json:"items"`
+}
@@ -0,0 +1,28 @@
+package types
+
+// reconciliation stages reported in IndexProgress
+const (
+	IndexStageScan   = "scan"
+	IndexStageSave   = "save"
+	IndexStageDelete = "delete"
+	IndexStageDone   = "done"
+)
+
+// IndexReconcileReport lists differences between public posts and search index,
+// Saved and Deleted are set in fix mode only
+type IndexReconcileReport struct {
+	Fix      bool     `json:"fix"`
+	Posts    int      `json:"posts"`
+	Records  int      `json:"records"`
+	Missing  []string `json:"missing"`
+	Orphaned []string `json:"orphaned"`
+	Stale    []string `json:"stale"`
+	Saved    int      `json:"saved"`
+	Deleted  int      `json:"deleted"`
+}
+
+type IndexProgress struct {
+	Stage string `json:"stage"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
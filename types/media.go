@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+type MediaFile struct {
+	PublicId  string    `json:"publicId"`
+	AssetId   string    `json:"assetId"`
+	Format    string    `json:"format"`
+	Bytes     int       `json:"bytes"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Url       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
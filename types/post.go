@@ -1,13 +1,18 @@
 package types
 
-import "go.mongodb.org/mongo-driver/bson"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
 
 type GetPostsMetadataOptions struct {
-	Private bool   `json:"private"`
-	Topic   string `json:"topic"`
-	SortBy  string `json:"sortBy"`
-	Limit   int64  `json:"limit"`
-	Skip    int64  `json:"skip"`
+	Private  bool   `json:"private"`
+	Topic    string `json:"topic"`
+	AuthorId string `json:"authorId"`
+	SortBy   string `json:"sortBy"`
+	Limit    int64  `json:"limit"`
+	Skip     int64  `json:"skip"`
 }
 
 type CreatePostPayload struct {
@@ -43,3 +48,31 @@ type UpdatePostPayload struct {
 	License           string   `json:"license"`
 	RelatedPosts      []string `json:"relatedPosts"`
 }
+
+type PostSchedulePayload struct {
+	PublishAt   *time.Time `json:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+}
+
+type PostSchedule struct {
+	PostId      string     `json:"postId"`
+	Title       string     `json:"title"`
+	Public      bool       `json:"public"`
+	PublishAt   *time.Time `json:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+}
+
+// SlugRename describes slug change of single post made by duplicate slug migration
+type SlugRename struct {
+	PostId string `json:"postId"`
+	Title  string `json:"title"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// SlugMigrationReport lists posts renamed by duplicate slug migration,
+// nothing is written in dry run mode
+type SlugMigrationReport struct {
+	DryRun  bool         `json:"dryRun"`
+	Renamed []SlugRename `json:"renamed"`
+}
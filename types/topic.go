@@ -5,6 +5,18 @@ type Topic struct {
 	ThumbId   string `json:"thumbId"`
 	ThumbPath string `json:"thumbPath"`
 	Public    bool   `json:"public"`
+	Order     int    `json:"order"`
 }
 
 type Topics = map[string]Topic
+
+type CreateTopicPayload struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
+
+type UpdateTopicPayload struct {
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
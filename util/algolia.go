@@ -1,22 +1,32 @@
 package util
 
 import (
+	"sync"
+
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/rajatxs/go-fconsole/config"
 )
 
 var (
+	algoliaMu sync.RWMutex
 	client    *search.Client
 	postIndex *search.Index
 )
 
 // PostIndex returns "posts" index reference
 func PostIndex() *search.Index {
+	algoliaMu.RLock()
+	defer algoliaMu.RUnlock()
 	return postIndex
 }
 
-// InitAlgolia creates new client instance with default config
+// InitAlgolia creates new client instance with active config
+// and replaces the current one
 func InitAlgolia() {
-	client = search.NewClient(config.AlgoliaAppId(), config.AlgoliaApiKey())
-	postIndex = client.InitIndex("posts")
+	next := search.NewClient(config.AlgoliaAppId(), config.AlgoliaApiKey())
+
+	algoliaMu.Lock()
+	client = next
+	postIndex = next.InitIndex("posts")
+	algoliaMu.Unlock()
 }
@@ -4,27 +4,40 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/rajatxs/go-fconsole/config"
 	"github.com/rajatxs/go-fconsole/types"
 )
 
-var cld *cloudinary.Cloudinary
+var (
+	cldMu sync.RWMutex
+	cld   *cloudinary.Cloudinary
+)
 
-// InitCloudinary creates new instance of cloudinary with default configuration
-func InitCloudinary() (err error) {
-	if cld, err = cloudinary.New(); err != nil {
+// InitCloudinary creates new instance of cloudinary with configured URL
+// and replaces the current one, which stays in use on failure
+func InitCloudinary() error {
+	next, err := cloudinary.NewFromURL(config.CloudinaryURL())
+	if err != nil {
 		return err
-	} else {
-		cld.Config.URL.Secure = true
-		return nil
 	}
+	next.Config.URL.Secure = true
+
+	cldMu.Lock()
+	cld = next
+	cldMu.Unlock()
+	return nil
 }
 
 // CloudinaryInstance returns active instance of cloudinary
 func CloudinaryInstance() *cloudinary.Cloudinary {
+	cldMu.RLock()
+	defer cldMu.RUnlock()
 	return cld
 }
 
@@ -60,6 +73,36 @@ func UploadImage(ctx context.Context, folderName string, imageData []byte) (res
 	}
 }
 
+// PutImage uploads raw image under exact public id, replacing existing image
+func PutImage(ctx context.Context, publicId string, imageData []byte) (res *types.UploadedImageFile, err error) {
+	var (
+		uploadResult *uploader.UploadResult
+		file         = bytes.NewReader(imageData)
+		params       = uploader.UploadParams{
+			ResourceType: "image",
+			PublicID:     publicId,
+			Overwrite:    api.Bool(true),
+			Invalidate:   api.Bool(true),
+		}
+	)
+
+	Log.Info(fmt.Sprintf("[util.PutImage] Uploading image (publicId='%s')", publicId))
+
+	if uploadResult, err = CloudinaryInstance().Upload.Upload(ctx, file, params); err != nil {
+		Log.Error(fmt.Sprintf("[util.PutImage] %s", err.Error()))
+		return nil, err
+	} else {
+		res = &types.UploadedImageFile{
+			PublicId: uploadResult.PublicID,
+			AssetId:  uploadResult.AssetID,
+			Format:   uploadResult.Format,
+		}
+		Log.Info(fmt.Sprintf("[util.PutImage] Image uploaded (format='%s', publicId='%s')", res.Format, res.PublicId))
+
+		return res, nil
+	}
+}
+
 // DeleteImage removes image from storage bucket
 func DeleteImage(ctx context.Context, publicId string) (err error) {
 	if _, err = CloudinaryInstance().Admin.DeleteAssets(ctx, admin.DeleteAssetsParams{
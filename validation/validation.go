@@ -0,0 +1,81 @@
+// Package validation collects field level errors of request payloads
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rajatxs/go-fconsole/slug"
+)
+
+// FieldError describes invalid value of single payload field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error holds every field error found in a payload
+type Error struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// Error returns field errors joined into single message
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + " " + f.Message
+	}
+	return "invalid payload: " + strings.Join(msgs, "; ")
+}
+
+// Add records error of given field
+func (e *Error) Add(field string, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Err returns e when any field error was recorded, nil otherwise
+func (e *Error) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Required records error when trimmed value is empty
+func (e *Error) Required(field string, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		e.Add(field, "is required")
+		return false
+	}
+	return true
+}
+
+// MaxLength records error when value has more than max characters
+func (e *Error) MaxLength(field string, value string, max int) bool {
+	if n := utf8.RuneCountInString(value); n > max {
+		e.Add(field, "must be at most %d characters long, got %d", max, n)
+		return false
+	}
+	return true
+}
+
+// Slug records error unless value is lowercase words of letters and digits joined by hyphens
+func (e *Error) Slug(field string, value string) bool {
+	if !slug.Valid(value) {
+		e.Add(field, "must contain only lowercase letters, digits and single hyphens between words")
+		return false
+	}
+	return true
+}
+
+// Url records error unless value is absolute http or https url
+func (e *Error) Url(field string, value string) bool {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		e.Add(field, "must be an absolute http or https url")
+		return false
+	}
+	return true
+}
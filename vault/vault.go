@@ -0,0 +1,276 @@
+// Package vault keeps secrets in a local file encrypted with
+// a passphrase derived key (scrypt and AES-256-GCM)
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// format version of vault file
+const version = 1
+
+// scrypt parameters of new vaults
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+	keyLen  = 32
+	saltLen = 16
+)
+
+// bounds of scrypt parameters accepted from vault file, so a corrupted
+// or tampered header cannot make key derivation hang or run out of memory
+const (
+	minScryptN = 1 << 14
+	maxScryptN = 1 << 20
+	maxScryptR = 32
+	maxScryptP = 16
+	maxSaltLen = 64
+)
+
+var (
+	// ErrWrongPassphrase is returned when vault cannot be decrypted with given passphrase
+	ErrWrongPassphrase = errors.New("vault: wrong passphrase or corrupted vault")
+
+	// ErrEmptyPassphrase is returned when passphrase is empty
+	ErrEmptyPassphrase = errors.New("vault: passphrase must not be empty")
+)
+
+// file is JSON layout of vault file, only Data is encrypted
+type file struct {
+	Version int    `json:"version"`
+	Kdf     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// additionalData binds key derivation parameters to ciphertext
+func (f *file) additionalData() []byte {
+	return []byte(fmt.Sprintf("fconsole-vault:v%d:%s:%d:%d:%d", f.Version, f.Kdf, f.N, f.R, f.P))
+}
+
+// checkParams rejects key derivation parameters outside of accepted bounds
+func (f *file) checkParams() error {
+	switch {
+	case f.N < minScryptN || f.N > maxScryptN || f.N&(f.N-1) != 0:
+		return fmt.Errorf("vault: unsupported scrypt N=%d", f.N)
+	case f.R < 1 || f.R > maxScryptR:
+		return fmt.Errorf("vault: unsupported scrypt r=%d", f.R)
+	case f.P < 1 || f.P > maxScryptP:
+		return fmt.Errorf("vault: unsupported scrypt p=%d", f.P)
+	case len(f.Salt) < saltLen || len(f.Salt) > maxSaltLen:
+		return fmt.Errorf("vault: unsupported salt length %d", len(f.Salt))
+	}
+	return nil
+}
+
+// Vault holds decrypted secrets of a vault file
+type Vault struct {
+	path    string
+	header  file
+	key     []byte
+	secrets map[string]string
+}
+
+// Exists reports whether vault file exists at given path
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Create returns new empty vault protected by given passphrase,
+// the file is written on first Save
+func Create(path string, passphrase string) (*Vault, error) {
+	v := &Vault{path: path, secrets: map[string]string{}}
+
+	if err := v.derive(passphrase); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Open decrypts vault file at given path with passphrase
+func Open(path string, passphrase string) (*Vault, error) {
+	var (
+		v   = &Vault{path: path}
+		gcm cipher.AEAD
+	)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &v.header); err != nil {
+		return nil, fmt.Errorf("vault: %s", err.Error())
+	}
+
+	if v.header.Version != version || v.header.Kdf != "scrypt" {
+		return nil, fmt.Errorf("vault: unsupported format (version=%d, kdf='%s')", v.header.Version, v.header.Kdf)
+	}
+
+	if err = v.header.checkParams(); err != nil {
+		return nil, err
+	}
+
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+
+	if v.key, err = scrypt.Key([]byte(passphrase), v.header.Salt, v.header.N, v.header.R, v.header.P, keyLen); err != nil {
+		return nil, err
+	}
+
+	if gcm, err = newGCM(v.key); err != nil {
+		return nil, err
+	}
+
+	if len(v.header.Nonce) != gcm.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+
+	plain, err := gcm.Open(nil, v.header.Nonce, v.header.Data, v.header.additionalData())
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	if err = json.Unmarshal(plain, &v.secrets); err != nil {
+		return nil, fmt.Errorf("vault: %s", err.Error())
+	}
+
+	if v.secrets == nil {
+		v.secrets = map[string]string{}
+	}
+	return v, nil
+}
+
+// derive sets fresh salt and key derived from passphrase
+func (v *Vault) derive(passphrase string) (err error) {
+	var key []byte
+
+	if passphrase == "" {
+		return ErrEmptyPassphrase
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err = rand.Read(salt); err != nil {
+		return err
+	}
+
+	if key, err = scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLen); err != nil {
+		return err
+	}
+
+	v.header = file{Version: version, Kdf: "scrypt", N: scryptN, R: scryptR, P: scryptP, Salt: salt}
+	v.key = key
+	return nil
+}
+
+// newGCM returns AES-GCM cipher of given key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Save encrypts secrets with fresh nonce and replaces vault file,
+// the file is readable by its owner only
+func (v *Vault) Save() (err error) {
+	var (
+		gcm  cipher.AEAD
+		data []byte
+	)
+
+	if gcm, err = newGCM(v.key); err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(v.secrets)
+	if err != nil {
+		return err
+	}
+
+	v.header.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(v.header.Nonce); err != nil {
+		return err
+	}
+	v.header.Data = gcm.Seal(nil, v.header.Nonce, plain, v.header.additionalData())
+
+	if data, err = json.MarshalIndent(&v.header, "", "  "); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(v.path), 0755); err != nil {
+		return err
+	}
+
+	// write next to vault and rename so a failed write keeps the old vault
+	tmp := v.path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, v.path)
+}
+
+// Names returns sorted secret names
+func (v *Vault) Names() []string {
+	names := make([]string, 0, len(v.secrets))
+
+	for name := range v.secrets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Secrets returns copy of every secret by name
+func (v *Vault) Secrets() map[string]string {
+	secrets := make(map[string]string, len(v.secrets))
+
+	for name, value := range v.secrets {
+		secrets[name] = value
+	}
+	return secrets
+}
+
+// Set stores secret value under given name and saves vault
+func (v *Vault) Set(name string, value string) error {
+	v.secrets[name] = value
+	return v.Save()
+}
+
+// Remove deletes secret by given name and saves vault,
+// reports whether secret existed
+func (v *Vault) Remove(name string) (bool, error) {
+	if _, ok := v.secrets[name]; !ok {
+		return false, nil
+	}
+
+	delete(v.secrets, name)
+	return true, v.Save()
+}
+
+// Rotate re-encrypts vault with key derived from new passphrase and fresh salt
+func (v *Vault) Rotate(passphrase string) error {
+	if err := v.derive(passphrase); err != nil {
+		return err
+	}
+	return v.Save()
+}
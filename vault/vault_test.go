@@ -0,0 +1,149 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVaultRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	v, err := Create(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = v.Set("prod.mongodb_url", "mongodb://secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("vault file mode = %o, want 600", perm)
+	}
+
+	opened, err := Open(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := opened.Secrets()["prod.mongodb_url"]; got != "mongodb://secret" {
+		t.Errorf("secret = %q, want %q", got, "mongodb://secret")
+	}
+
+	if err = opened.Rotate("battery staple"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Open(path, "correct horse"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("open with old passphrase after rotate: err = %v, want %v", err, ErrWrongPassphrase)
+	}
+
+	if _, err = Open(path, "battery staple"); err != nil {
+		t.Errorf("open with new passphrase: %v", err)
+	}
+}
+
+func TestVaultOpenErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	v, err := Create(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = v.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// tamper rewrites header of saved vault into a new file
+	tamper := func(t *testing.T, edit func(f *file)) string {
+		var f file
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = json.Unmarshal(data, &f); err != nil {
+			t.Fatal(err)
+		}
+		edit(&f)
+
+		if data, err = json.Marshal(&f); err != nil {
+			t.Fatal(err)
+		}
+
+		tampered := filepath.Join(t.TempDir(), "vault.json")
+		if err = os.WriteFile(tampered, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return tampered
+	}
+
+	tests := []struct {
+		name       string
+		path       func(t *testing.T) string
+		passphrase string
+		wantErr    error
+	}{
+		{
+			name:       "wrong passphrase",
+			path:       func(t *testing.T) string { return path },
+			passphrase: "wrong",
+			wantErr:    ErrWrongPassphrase,
+		},
+		{
+			name:       "empty passphrase",
+			path:       func(t *testing.T) string { return path },
+			passphrase: "",
+			wantErr:    ErrEmptyPassphrase,
+		},
+		{
+			name:       "huge N",
+			path:       func(t *testing.T) string { return tamper(t, func(f *file) { f.N = 1 << 40 }) },
+			passphrase: "correct horse",
+		},
+		{
+			name:       "N not power of two",
+			path:       func(t *testing.T) string { return tamper(t, func(f *file) { f.N = 3 << 14 }) },
+			passphrase: "correct horse",
+		},
+		{
+			name:       "huge r",
+			path:       func(t *testing.T) string { return tamper(t, func(f *file) { f.R = 1 << 20 }) },
+			passphrase: "correct horse",
+		},
+		{
+			name:       "zero p",
+			path:       func(t *testing.T) string { return tamper(t, func(f *file) { f.P = 0 }) },
+			passphrase: "correct horse",
+		},
+		{
+			name:       "changed parameters",
+			path:       func(t *testing.T) string { return tamper(t, func(f *file) { f.N = 1 << 14 }) },
+			passphrase: "correct horse",
+			wantErr:    ErrWrongPassphrase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Open(tt.path(t), tt.passphrase)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}